@@ -10,6 +10,9 @@ type Config struct {
 	AppConfig
 	WebConfig
 	PostgresConfig
+	AssignmentConfig
+	SLAConfig
+	SchedulerConfig
 }
 
 type AppConfig struct {
@@ -31,6 +34,36 @@ type PostgresConfig struct {
 	DbSslMode  string
 }
 
+// AssignmentConfig describes how reviewers are chosen for pull requests.
+// TeamStrategies overrides Strategy for the listed teams, team names are
+// matched case-insensitively because viper lowercases map keys.
+type AssignmentConfig struct {
+	Strategy       string
+	TeamStrategies map[string]string
+}
+
+// SLAConfig describes background check of team SLAs, zero CheckInterval disables it.
+// Timers which deadline is closer than WarnBefore are reported as warnings,
+// notices are posted to WebhookURL when it is set and logged otherwise.
+type SLAConfig struct {
+	CheckInterval  int64 // seconds
+	WarnBefore     int64 // minutes
+	WebhookURL     string
+	WebhookTimeout int64 // seconds
+}
+
+// SchedulerConfig describes periodic jobs of the server. Schedules are cron expressions
+// in UTC by job name, jobs without schedule do not run.
+type SchedulerConfig struct {
+	Schedules map[string]string
+	// reviewers are reminded of OPEN PRs they have not reviewed this long after assignment
+	StaleReviewHours int
+	// log or webhook
+	Notifier       string
+	WebhookURL     string
+	WebhookTimeout int64 // seconds
+}
+
 func parseCfg(fileName string) (*viper.Viper, error) {
 	v := viper.New()
 	v.AddConfigPath(".")
@@ -1,12 +1,20 @@
 package app
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/Negat1v9/pr-review-service/config"
+	availabilityservice "github.com/Negat1v9/pr-review-service/internal/availability/service"
+	ownershipservice "github.com/Negat1v9/pr-review-service/internal/ownership/service"
 	prservice "github.com/Negat1v9/pr-review-service/internal/pullRequest/service"
+	"github.com/Negat1v9/pr-review-service/internal/scheduler"
 	"github.com/Negat1v9/pr-review-service/internal/server"
+	"github.com/Negat1v9/pr-review-service/internal/sla"
 	"github.com/Negat1v9/pr-review-service/internal/store"
 	teamservice "github.com/Negat1v9/pr-review-service/internal/team/service"
 	userservice "github.com/Negat1v9/pr-review-service/internal/users/service"
+	"github.com/Negat1v9/pr-review-service/pkg/clock"
 	"github.com/Negat1v9/pr-review-service/pkg/logger"
 	"github.com/Negat1v9/pr-review-service/pkg/postgres"
 )
@@ -32,12 +40,51 @@ func (a *App) Run() error {
 
 	storage := store.NewStore(db)
 
-	teamService := teamservice.NewTeamService(storage)
-	userService := userservice.NewUserService(storage)
-	prService := prservice.NewPRService(storage)
+	selector, err := prservice.NewReviewerSelector(a.cfg.AssignmentConfig)
+	if err != nil {
+		return err
+	}
+	prService := prservice.NewPRService(storage, selector)
+	teamService := teamservice.NewTeamService(storage, prService)
+	userService := userservice.NewUserService(storage, prService)
+	ownershipService := ownershipservice.NewOwnershipService(storage)
+	availabilityService := availabilityservice.NewAvailabilityService(storage)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	slaWorker := sla.NewWorker(a.cfg.SLAConfig, storage, prService, clock.New(), a.log)
+	go slaWorker.Run(ctx)
+
+	jobs, err := a.newScheduler(storage)
+	if err != nil {
+		return err
+	}
+	go jobs.Run(ctx)
 
 	server := server.New(a.cfg, a.log)
 
-	server.MapHandlers(teamService, userService, prService)
+	server.MapHandlers(teamService, userService, prService, ownershipService, availabilityService)
 	return server.Run()
 }
+
+// newScheduler registers jobs which have schedules in config
+func (a *App) newScheduler(storage store.Store) (*scheduler.Scheduler, error) {
+	cfg := a.cfg.SchedulerConfig
+	jobs := scheduler.New(clock.New(), a.log)
+	for name, expr := range cfg.Schedules {
+		switch name {
+		case scheduler.JobStaleReviews:
+			notifier, err := scheduler.NewNotifier(cfg, a.log)
+			if err != nil {
+				return nil, err
+			}
+			job := scheduler.NewStaleReviewsJob(storage, notifier, clock.New(), a.log, cfg.StaleReviewHours)
+			if err := jobs.Add(name, expr, job.Run); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unknown scheduler job %q", name)
+		}
+	}
+	return jobs, nil
+}
@@ -0,0 +1,164 @@
+package availabilityhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	availabilityservice "github.com/Negat1v9/pr-review-service/internal/availability/service"
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/Negat1v9/pr-review-service/pkg/logger"
+	"github.com/Negat1v9/pr-review-service/pkg/utils"
+)
+
+type AvailabilityHandler struct {
+	log     *logger.Logger
+	service *availabilityservice.AvailabilityService
+}
+
+func NewAvailabilityHandler(log *logger.Logger, service *availabilityservice.AvailabilityService) *AvailabilityHandler {
+	return &AvailabilityHandler{
+		log:     log,
+		service: service,
+	}
+}
+
+func (h *AvailabilityHandler) CreatePeriod(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	var req models.CreateUnavailabilityPeriodRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	period, err := h.service.CreatePeriod(ctx, &req)
+	if err != nil {
+		h.log.Errorf("failed to create unavailability period: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusCreated, "period", period)
+}
+
+func (h *AvailabilityHandler) ListPeriods(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		utils.WriteErrResponse(w, utils.NewNotFoundError("resource not found", nil))
+		return
+	}
+
+	periods, err := h.service.ListPeriods(ctx, userID)
+	if err != nil {
+		h.log.Errorf("failed to list unavailability periods: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "periods", periods)
+}
+
+func (h *AvailabilityHandler) DeletePeriod(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	var req models.DeleteUnavailabilityPeriodRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	if err := h.service.DeletePeriod(ctx, req.UserID, req.PeriodID); err != nil {
+		h.log.Errorf("failed to delete unavailability period: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "period_id", req.PeriodID)
+}
+
+func (h *AvailabilityHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		utils.WriteErrResponse(w, utils.NewNotFoundError("resource not found", nil))
+		return
+	}
+
+	schedule, err := h.service.GetSchedule(ctx, userID)
+	if err != nil {
+		h.log.Errorf("failed to get working schedule: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "schedule", schedule)
+}
+
+func (h *AvailabilityHandler) SetSchedule(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	var req models.WorkingSchedule
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	schedule, err := h.service.SetSchedule(ctx, &req)
+	if err != nil {
+		h.log.Errorf("failed to set working schedule: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "schedule", schedule)
+}
+
+func (h *AvailabilityHandler) GetQuietHours(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		utils.WriteErrResponse(w, utils.NewNotFoundError("resource not found", nil))
+		return
+	}
+
+	quietHours, err := h.service.GetQuietHours(ctx, userID)
+	if err != nil {
+		h.log.Errorf("failed to get quiet hours: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "quiet_hours", quietHours)
+}
+
+func (h *AvailabilityHandler) SetQuietHours(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	var req models.QuietHours
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	quietHours, err := h.service.SetQuietHours(ctx, &req)
+	if err != nil {
+		h.log.Errorf("failed to set quiet hours: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "quiet_hours", quietHours)
+}
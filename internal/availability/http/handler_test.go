@@ -0,0 +1,227 @@
+package availabilityhttp
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	availabilityservice "github.com/Negat1v9/pr-review-service/internal/availability/service"
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	mock_store "github.com/Negat1v9/pr-review-service/internal/store/mock"
+	"github.com/Negat1v9/pr-review-service/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testEnv struct {
+	store            *mock_store.MockStore
+	userRepo         *mock_store.MockUserRepository
+	availabilityRepo *mock_store.MockAvailabilityRepository
+	db               *sqlx.DB
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	ctrl := gomock.NewController(t)
+
+	env := &testEnv{
+		store:            mock_store.NewMockStore(ctrl),
+		userRepo:         mock_store.NewMockUserRepository(ctrl),
+		availabilityRepo: mock_store.NewMockAvailabilityRepository(ctrl),
+		db:               &sqlx.DB{},
+	}
+	env.store.EXPECT().UserRepo().Return(env.userRepo).AnyTimes()
+	env.store.EXPECT().AvailabilityRepo().Return(env.availabilityRepo).AnyTimes()
+	env.store.EXPECT().DB().Return(env.db).AnyTimes()
+	env.store.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, env.db)
+		},
+	).AnyTimes()
+	return env
+}
+
+func (env *testEnv) doReq(t *testing.T, method, target string, body any) *httptest.ResponseRecorder {
+	service := availabilityservice.NewAvailabilityService(env.store)
+	handler := NewAvailabilityHandler(logger.NewLogger("local"), service)
+	availabilityMux := AvailabilityRouter(handler)
+
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	req, err := http.NewRequest(method, target, bytes.NewBuffer(data))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+
+	availabilityMux.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestCreatePeriod(t *testing.T) {
+	env := newTestEnv(t)
+	user := models.User{UserID: "u1", Username: "u1", TeamName: "team-1", IsActive: true}
+	startsAt := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2025, 7, 14, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Create success", func(t *testing.T) {
+		env.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&user, nil)
+		env.availabilityRepo.EXPECT().CreatePeriod(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&models.UnavailabilityPeriod{ID: 1, UserID: "u1", StartsAt: startsAt, EndsAt: endsAt}, nil)
+
+		rr := env.doReq(t, "POST", "/createPeriod", models.CreateUnavailabilityPeriodRequest{UserID: "u1", StartsAt: startsAt, EndsAt: endsAt})
+		require.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("Ends before start", func(t *testing.T) {
+		rr := env.doReq(t, "POST", "/createPeriod", models.CreateUnavailabilityPeriodRequest{UserID: "u1", StartsAt: endsAt, EndsAt: startsAt})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		env.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u2").Return(nil, sql.ErrNoRows)
+
+		rr := env.doReq(t, "POST", "/createPeriod", models.CreateUnavailabilityPeriodRequest{UserID: "u2", StartsAt: startsAt, EndsAt: endsAt})
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestListPeriods(t *testing.T) {
+	env := newTestEnv(t)
+	user := models.User{UserID: "u1", Username: "u1", TeamName: "team-1", IsActive: true}
+
+	t.Run("List success", func(t *testing.T) {
+		env.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&user, nil)
+		env.availabilityRepo.EXPECT().GetUserPeriods(gomock.Any(), gomock.Any(), "u1").
+			Return([]models.UnavailabilityPeriod{{ID: 1, UserID: "u1"}}, nil)
+
+		rr := env.doReq(t, "GET", "/listPeriods?user_id=u1", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, 1, len(r["periods"].([]any)))
+	})
+
+	t.Run("Without user", func(t *testing.T) {
+		rr := env.doReq(t, "GET", "/listPeriods", nil)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestDeletePeriod(t *testing.T) {
+	env := newTestEnv(t)
+
+	t.Run("Delete success", func(t *testing.T) {
+		env.availabilityRepo.EXPECT().DeletePeriod(gomock.Any(), gomock.Any(), "u1", int64(1)).Return(nil)
+
+		rr := env.doReq(t, "POST", "/deletePeriod", models.DeleteUnavailabilityPeriodRequest{UserID: "u1", PeriodID: 1})
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Period not found", func(t *testing.T) {
+		env.availabilityRepo.EXPECT().DeletePeriod(gomock.Any(), gomock.Any(), "u1", int64(2)).Return(sql.ErrNoRows)
+
+		rr := env.doReq(t, "POST", "/deletePeriod", models.DeleteUnavailabilityPeriodRequest{UserID: "u1", PeriodID: 2})
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestSchedule(t *testing.T) {
+	env := newTestEnv(t)
+	user := models.User{UserID: "u1", Username: "u1", TeamName: "team-1", IsActive: true}
+
+	t.Run("Set schedule", func(t *testing.T) {
+		env.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&user, nil)
+		env.availabilityRepo.EXPECT().TimezoneExists(gomock.Any(), gomock.Any(), "UTC").Return(true, nil)
+		env.availabilityRepo.EXPECT().UpsertWorkingSchedule(gomock.Any(), gomock.Any(),
+			&models.WorkingSchedule{UserID: "u1", WorkingDays: []int{1, 2, 5}, Timezone: "UTC"}).Return(nil)
+
+		rr := env.doReq(t, "POST", "/setSchedule", models.WorkingSchedule{UserID: "u1", WorkingDays: []int{5, 1, 2, 1}})
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Clear schedule", func(t *testing.T) {
+		env.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&user, nil)
+		env.availabilityRepo.EXPECT().DeleteWorkingSchedule(gomock.Any(), gomock.Any(), "u1").Return(nil)
+
+		rr := env.doReq(t, "POST", "/setSchedule", models.WorkingSchedule{UserID: "u1"})
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Invalid day", func(t *testing.T) {
+		rr := env.doReq(t, "POST", "/setSchedule", models.WorkingSchedule{UserID: "u1", WorkingDays: []int{0}})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Timezone unknown to database", func(t *testing.T) {
+		env.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&user, nil)
+		env.availabilityRepo.EXPECT().TimezoneExists(gomock.Any(), gomock.Any(), "Local").Return(false, nil)
+
+		rr := env.doReq(t, "POST", "/setSchedule", models.WorkingSchedule{UserID: "u1", WorkingDays: []int{1}, Timezone: "Local"})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Get default schedule", func(t *testing.T) {
+		env.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&user, nil)
+		env.availabilityRepo.EXPECT().GetWorkingSchedule(gomock.Any(), gomock.Any(), "u1").Return(nil, sql.ErrNoRows)
+
+		rr := env.doReq(t, "GET", "/getSchedule?user_id=u1", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, 7, len(r["schedule"].(map[string]any)["working_days"].([]any)))
+	})
+}
+
+func TestQuietHours(t *testing.T) {
+	env := newTestEnv(t)
+	user := models.User{UserID: "u1", Username: "u1", TeamName: "team-1", IsActive: true}
+
+	t.Run("Set quiet hours", func(t *testing.T) {
+		env.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&user, nil)
+		env.availabilityRepo.EXPECT().UpsertQuietHours(gomock.Any(), gomock.Any(),
+			&models.QuietHours{UserID: "u1", From: "22:00", To: "08:00", Timezone: "UTC"}).Return(nil)
+
+		rr := env.doReq(t, "POST", "/setQuietHours", models.QuietHours{UserID: "u1", From: "22:00", To: "08:00"})
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Clear quiet hours", func(t *testing.T) {
+		env.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&user, nil)
+		env.availabilityRepo.EXPECT().DeleteQuietHours(gomock.Any(), gomock.Any(), "u1").Return(nil)
+
+		rr := env.doReq(t, "POST", "/setQuietHours", models.QuietHours{UserID: "u1"})
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Invalid time", func(t *testing.T) {
+		for _, quietHours := range []models.QuietHours{
+			{UserID: "u1", From: "9:00", To: "18:00"},
+			{UserID: "u1", From: "22:00"},
+			{UserID: "u1", From: "22:00", To: "22:00"},
+			{UserID: "u1", From: "22:00", To: "08:00", Timezone: "Mars/Olympus"},
+		} {
+			rr := env.doReq(t, "POST", "/setQuietHours", quietHours)
+			require.Equal(t, http.StatusBadRequest, rr.Code)
+		}
+	})
+
+	t.Run("Get without quiet hours", func(t *testing.T) {
+		env.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&user, nil)
+		env.availabilityRepo.EXPECT().GetQuietHours(gomock.Any(), gomock.Any(), "u1").Return(nil, sql.ErrNoRows)
+
+		rr := env.doReq(t, "GET", "/getQuietHours?user_id=u1", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, "", r["quiet_hours"].(map[string]any)["from"])
+	})
+}
@@ -0,0 +1,17 @@
+package availabilityhttp
+
+import "net/http"
+
+func AvailabilityRouter(h *AvailabilityHandler) http.Handler {
+	handler := http.NewServeMux()
+
+	handler.HandleFunc("POST /createPeriod", h.CreatePeriod)
+	handler.HandleFunc("GET /listPeriods", h.ListPeriods)
+	handler.HandleFunc("POST /deletePeriod", h.DeletePeriod)
+	handler.HandleFunc("GET /getSchedule", h.GetSchedule)
+	handler.HandleFunc("POST /setSchedule", h.SetSchedule)
+	handler.HandleFunc("GET /getQuietHours", h.GetQuietHours)
+	handler.HandleFunc("POST /setQuietHours", h.SetQuietHours)
+
+	return handler
+}
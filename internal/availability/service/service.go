@@ -0,0 +1,203 @@
+package availabilityservice
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/Negat1v9/pr-review-service/internal/store"
+	"github.com/Negat1v9/pr-review-service/pkg/utils"
+	"github.com/jmoiron/sqlx"
+)
+
+type AvailabilityService struct {
+	store store.Store
+}
+
+func NewAvailabilityService(store store.Store) *AvailabilityService {
+	return &AvailabilityService{
+		store: store,
+	}
+}
+
+// CreatePeriod adds period when user is not assigned as reviewer even if the user is active
+func (s *AvailabilityService) CreatePeriod(ctx context.Context, req *models.CreateUnavailabilityPeriodRequest) (*models.UnavailabilityPeriod, error) {
+	if req.StartsAt.IsZero() || req.EndsAt.IsZero() || !req.EndsAt.After(req.StartsAt) {
+		return nil, utils.NewBadRequestError("ends_at must be after starts_at", nil)
+	}
+
+	var created *models.UnavailabilityPeriod
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		if err := s.checkUserExists(ctx, exec, req.UserID); err != nil {
+			return err
+		}
+
+		var err error
+		created, err = s.store.AvailabilityRepo().CreatePeriod(ctx, exec, &models.UnavailabilityPeriod{
+			UserID:   req.UserID,
+			StartsAt: req.StartsAt,
+			EndsAt:   req.EndsAt,
+			Reason:   req.Reason,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *AvailabilityService) ListPeriods(ctx context.Context, userID string) ([]models.UnavailabilityPeriod, error) {
+	if err := s.checkUserExists(ctx, s.store.DB(), userID); err != nil {
+		return nil, err
+	}
+	return s.store.AvailabilityRepo().GetUserPeriods(ctx, s.store.DB(), userID)
+}
+
+func (s *AvailabilityService) DeletePeriod(ctx context.Context, userID string, periodID int64) error {
+	err := s.store.AvailabilityRepo().DeletePeriod(ctx, s.store.DB(), userID, periodID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return utils.NewNotFoundError("resource not found", nil)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetSchedule returns working schedule of user, users without schedule work every day
+func (s *AvailabilityService) GetSchedule(ctx context.Context, userID string) (*models.WorkingSchedule, error) {
+	if err := s.checkUserExists(ctx, s.store.DB(), userID); err != nil {
+		return nil, err
+	}
+
+	schedule, err := s.store.AvailabilityRepo().GetWorkingSchedule(ctx, s.store.DB(), userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &models.WorkingSchedule{UserID: userID, WorkingDays: allWeekDays(), Timezone: "UTC"}, nil
+		}
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// SetSchedule replaces working schedule of user, empty working days removes the schedule.
+// Timezone must be known to the database, candidate queries compute the week day in it
+func (s *AvailabilityService) SetSchedule(ctx context.Context, schedule *models.WorkingSchedule) (*models.WorkingSchedule, error) {
+	if schedule.Timezone == "" {
+		schedule.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(schedule.Timezone); err != nil {
+		return nil, utils.NewBadRequestError("unknown timezone", nil)
+	}
+
+	days := make([]int, 0, len(schedule.WorkingDays))
+	for _, day := range schedule.WorkingDays {
+		if day < 1 || day > 7 {
+			return nil, utils.NewBadRequestError("working days must be from 1 (monday) to 7 (sunday)", nil)
+		}
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+	slices.Sort(days)
+	schedule.WorkingDays = days
+
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		if err := s.checkUserExists(ctx, exec, schedule.UserID); err != nil {
+			return err
+		}
+
+		if len(schedule.WorkingDays) == 0 {
+			return s.store.AvailabilityRepo().DeleteWorkingSchedule(ctx, exec, schedule.UserID)
+		}
+
+		// go accepts names unknown to postgres (e.g. Local)
+		exists, err := s.store.AvailabilityRepo().TimezoneExists(ctx, exec, schedule.Timezone)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return utils.NewBadRequestError("unknown timezone", schedule.Timezone)
+		}
+		return s.store.AvailabilityRepo().UpsertWorkingSchedule(ctx, exec, schedule)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(schedule.WorkingDays) == 0 {
+		schedule.WorkingDays = allWeekDays()
+	}
+	return schedule, nil
+}
+
+// GetQuietHours returns quiet hours of user, user without them has empty from and to
+func (s *AvailabilityService) GetQuietHours(ctx context.Context, userID string) (*models.QuietHours, error) {
+	if err := s.checkUserExists(ctx, s.store.DB(), userID); err != nil {
+		return nil, err
+	}
+
+	quietHours, err := s.store.AvailabilityRepo().GetQuietHours(ctx, s.store.DB(), userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &models.QuietHours{UserID: userID, Timezone: "UTC"}, nil
+		}
+		return nil, err
+	}
+	return quietHours, nil
+}
+
+// SetQuietHours replaces quiet hours of user, empty from and to remove them
+func (s *AvailabilityService) SetQuietHours(ctx context.Context, quietHours *models.QuietHours) (*models.QuietHours, error) {
+	if quietHours.Timezone == "" {
+		quietHours.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(quietHours.Timezone); err != nil {
+		return nil, utils.NewBadRequestError("unknown timezone", nil)
+	}
+
+	remove := quietHours.From == "" && quietHours.To == ""
+	if !remove {
+		if !isClockTime(quietHours.From) || !isClockTime(quietHours.To) || quietHours.From == quietHours.To {
+			return nil, utils.NewBadRequestError("from and to must be different times in HH:MM format", nil)
+		}
+	}
+
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		if err := s.checkUserExists(ctx, exec, quietHours.UserID); err != nil {
+			return err
+		}
+
+		if remove {
+			return s.store.AvailabilityRepo().DeleteQuietHours(ctx, exec, quietHours.UserID)
+		}
+		return s.store.AvailabilityRepo().UpsertQuietHours(ctx, exec, quietHours)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return quietHours, nil
+}
+
+func (s *AvailabilityService) checkUserExists(ctx context.Context, exec sqlx.ExtContext, userID string) error {
+	if _, err := s.store.UserRepo().GetUserByID(ctx, exec, userID); err != nil {
+		if err == sql.ErrNoRows {
+			return utils.NewNotFoundError("resource not found", nil)
+		}
+		return err
+	}
+	return nil
+}
+
+// isClockTime checks HH:MM format, so times are compared as strings
+func isClockTime(value string) bool {
+	_, err := time.Parse("15:04", value)
+	return err == nil && len(value) == len("15:04")
+}
+
+func allWeekDays() []int {
+	return []int{1, 2, 3, 4, 5, 6, 7}
+}
@@ -1,6 +1,13 @@
 package middleware
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/Negat1v9/pr-review-service/pkg/utils"
+)
+
+// ActorHeader names the user who makes the request, it is recorded as actor of PR events
+const ActorHeader = "X-Actor-ID"
 
 type Middleware func(http.Handler) http.Handler
 
@@ -12,7 +19,7 @@ func New() *MiddleWareManager {
 
 // adding necessary services such as CORS
 func (mw *MiddleWareManager) BasicMW() Middleware {
-	return createStack(CORS)
+	return createStack(Actor, CORS)
 }
 
 func createStack(xs ...Middleware) Middleware {
@@ -29,7 +36,7 @@ func createStack(xs ...Middleware) Middleware {
 func CORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Add("Access-Control-Allow-Origin", "*")
-		w.Header().Add("Access-Control-Allow-Headers", "Content-Type, Origin, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, Cache-Control, X-Requested-With")
+		w.Header().Add("Access-Control-Allow-Headers", "Content-Type, Origin, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, Cache-Control, X-Requested-With, X-Actor-ID")
 		w.Header().Add("Access-Control-Allow-Methods", "POST, GET, OPTIONS, PUT, DELETE")
 
 		if r.Method == "OPTIONS" {
@@ -40,3 +47,14 @@ func CORS(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// Actor puts id of the user from ActorHeader to request context
+func Actor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if actor := r.Header.Get(ActorHeader); actor != "" {
+			r = r.WithContext(utils.WithActor(r.Context(), actor))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
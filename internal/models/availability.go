@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// UnavailabilityPeriod is a time range when user is not assigned as reviewer, e.g. vacation
+type UnavailabilityPeriod struct {
+	ID       int64     `json:"period_id" db:"period_id"`
+	UserID   string    `json:"user_id" db:"user_id"`
+	StartsAt time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt   time.Time `json:"ends_at" db:"ends_at"`
+	Reason   string    `json:"reason,omitempty" db:"reason"`
+}
+
+// WorkingSchedule is a recurring week schedule of user, on other days user is unavailable
+type WorkingSchedule struct {
+	UserID string `json:"user_id" db:"user_id"`
+	// ISO days of week, 1 is monday and 7 is sunday
+	WorkingDays []int  `json:"working_days" db:"-"`
+	Timezone    string `json:"timezone" db:"timezone"`
+}
+
+type CreateUnavailabilityPeriodRequest struct {
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+type DeleteUnavailabilityPeriodRequest struct {
+	UserID   string `json:"user_id"`
+	PeriodID int64  `json:"period_id"`
+}
+
+// QuietHours is a daily period in user timezone when user gets no reminders,
+// period which ends earlier than starts crosses midnight
+type QuietHours struct {
+	UserID string `json:"user_id" db:"user_id"`
+	// HH:MM
+	From     string `json:"from" db:"starts_at"`
+	To       string `json:"to" db:"ends_at"`
+	Timezone string `json:"timezone" db:"timezone"`
+}
+
+// Contains reports whether t is in quiet hours, unknown timezone is treated as UTC
+func (q *QuietHours) Contains(t time.Time) bool {
+	loc, err := time.LoadLocation(q.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	clock := t.In(loc).Format("15:04")
+
+	if q.From <= q.To {
+		return q.From <= clock && clock < q.To
+	}
+	return clock >= q.From || clock < q.To
+}
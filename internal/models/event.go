@@ -0,0 +1,95 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+)
+
+type PullRequestEventType string
+
+const (
+	PullRequestEventCreated          PullRequestEventType = "PR_CREATED"
+	PullRequestEventStatusChanged    PullRequestEventType = "STATUS_CHANGED"
+	PullRequestEventLabelsChanged    PullRequestEventType = "LABELS_CHANGED"
+	PullRequestEventReviewerAssigned PullRequestEventType = "REVIEWER_ASSIGNED"
+	PullRequestEventReviewerReplaced PullRequestEventType = "REVIEWER_REPLACED"
+	PullRequestEventReviewerRemoved  PullRequestEventType = "REVIEWER_REMOVED"
+	PullRequestEventReviewSubmitted  PullRequestEventType = "REVIEW_SUBMITTED"
+	// dependencies of the PR were replaced
+	PullRequestEventDependenciesChanged PullRequestEventType = "DEPENDENCIES_CHANGED"
+)
+
+// ActorSystem is an actor of events when request does not name the user who made the change
+const ActorSystem = "system"
+
+// AssignmentReason explains why reviewer was assigned, replaced or removed
+type AssignmentReason string
+
+const (
+	// assigned by selection on creation or when PR became OPEN
+	AssignmentReasonAuto AssignmentReason = "AUTO"
+	// added, removed or reassigned by request
+	AssignmentReasonManual AssignmentReason = "MANUAL"
+	// reviews of deactivated users are moved to other reviewers
+	AssignmentReasonReviewerDeactivated AssignmentReason = "REVIEWER_DEACTIVATED"
+	// reviewers are unassigned from PR converted to draft
+	AssignmentReasonConvertedToDraft AssignmentReason = "CONVERTED_TO_DRAFT"
+	// reviewer is added or replaced by escalation of breached SLA
+	AssignmentReasonSLABreach AssignmentReason = "SLA_BREACH"
+	// reviews of PRs of the team are moved from member removed from it
+	AssignmentReasonMemberRemoved AssignmentReason = "MEMBER_REMOVED"
+	// reviewers who are not members of the successor of archived team are replaced
+	AssignmentReasonTeamArchived AssignmentReason = "TEAM_ARCHIVED"
+	// required approver of merge policy is assigned by own review
+	AssignmentReasonRequiredApprover AssignmentReason = "REQUIRED_APPROVER"
+)
+
+// PullRequestEvent is a record of PR timeline, payload depends on event type
+type PullRequestEvent struct {
+	ID            int64                `json:"event_id" db:"event_id"`
+	PullRequestID string               `json:"pull_request_id" db:"pull_request_id"`
+	Type          PullRequestEventType `json:"type" db:"event_type"`
+	Actor         string               `json:"actor" db:"actor"`
+	Payload       types.JSONText       `json:"payload" db:"payload"`
+	CreatedAt     time.Time            `json:"created_at" db:"created_at"`
+}
+
+type PullRequestCreatedPayload struct {
+	Name     string            `json:"pull_request_name"`
+	AuthorID string            `json:"author_id"`
+	Status   PullRequestStatus `json:"status"`
+	Labels   []string          `json:"labels,omitempty"`
+}
+
+type StatusChangedPayload struct {
+	From     PullRequestStatus `json:"from"`
+	To       PullRequestStatus `json:"to"`
+	Override bool              `json:"override,omitempty"`
+}
+
+type LabelsChangedPayload struct {
+	Labels []string `json:"labels"`
+}
+
+// ReviewerPayload is a payload of assigned and removed reviewer events
+type ReviewerPayload struct {
+	ReviewerID string           `json:"reviewer_id"`
+	Reason     AssignmentReason `json:"reason"`
+}
+
+type ReviewerReplacedPayload struct {
+	OldReviewerID string           `json:"old_reviewer_id"`
+	NewReviewerID string           `json:"new_reviewer_id"`
+	Reason        AssignmentReason `json:"reason"`
+}
+
+type DependenciesChangedPayload struct {
+	DependsOn []string `json:"depends_on"`
+}
+
+type ReviewSubmittedPayload struct {
+	ReviewID   int64       `json:"review_id"`
+	ReviewerID string      `json:"reviewer_id"`
+	State      ReviewState `json:"state"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Codeowners is a CODEOWNERS file uploaded for the repository
+type Codeowners struct {
+	Repository string          `json:"repository" db:"repository"`
+	Content    string          `json:"content" db:"content"`
+	UpdatedAt  time.Time       `json:"updated_at" db:"updated_at"`
+	Rules      []OwnershipRule `json:"rules" db:"-"`
+}
+
+type OwnershipRule struct {
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+type UploadCodeownersRequest struct {
+	Repository string `json:"repository"`
+	Content    string `json:"content"`
+}
+
+// DefaultRepository holds PRs created without repository
+const DefaultRepository = "default"
+
+// Repository groups PRs, PRs of repository with owner team are reviewed by that team
+// instead of the author team
+type Repository struct {
+	Name string `json:"repository" db:"repository"`
+	// empty if repository has no owner team
+	TeamName  string    `json:"team_name,omitempty" db:"team_name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// SetRepositoryRequest registers the repository, empty team name removes owner team
+type SetRepositoryRequest struct {
+	Repository string `json:"repository"`
+	TeamName   string `json:"team_name"`
+}
@@ -5,18 +5,90 @@ import "time"
 type PullRequestStatus string
 
 const (
+	// draft PR has no reviewers until it is marked ready for review
+	PullRequestStatusDraft  PullRequestStatus = "DRAFT"
 	PullRequestStatusOpen   PullRequestStatus = "OPEN"
+	PullRequestStatusClosed PullRequestStatus = "CLOSED"
 	PullRequestStatusMerged PullRequestStatus = "MERGED"
 )
 
+// PullRequestKey returns global id of PR with the id in the repository,
+// PRs of the default repository keep their ids for compatibility
+func PullRequestKey(repository, id string) string {
+	if repository == "" || repository == DefaultRepository || id == "" {
+		return id
+	}
+	return repository + "#" + id
+}
+
 type PullRequest struct {
+	// global id, see PullRequestKey
 	ID                string            `json:"pull_request_id" db:"pull_request_id"`
 	Name              string            `json:"pull_request_name" db:"pull_request_name"`
 	AuthorID          string            `json:"author_id" db:"author_id"`
 	Status            PullRequestStatus `json:"status" db:"status"`
 	AssignedReviewers []string          `json:"assigned_reviewers" db:"assigned_reviewers"`
-	CreatedAt         time.Time         `json:"-" db:"created_at"`
+	CreatedAt         time.Time         `json:"createdAt" db:"created_at"`
 	MergerAt          *time.Time        `json:"mergedAt,omitempty" db:"merged_at,omitempty"`
+	ClosedAt          *time.Time        `json:"closedAt,omitempty" db:"closed_at"`
+	Labels            []string          `json:"labels,omitempty" db:"-"`
+	// assigned reviewers with their latest review decision
+	Reviewers []ReviewerState `json:"reviewers,omitempty" db:"-"`
+	// PR was merged without meeting merge policy of author team
+	MergeOverride bool `json:"merge_override,omitempty" db:"merge_override"`
+	// set on creation when less reviewers than team minimum were assigned
+	Understaffed bool `json:"understaffed,omitempty" db:"-"`
+	// set on creation, reviewer id -> PR label matched with reviewer skills
+	MatchedLabels map[string]string `json:"matched_labels,omitempty" db:"-"`
+	// edges of PRs which have to be merged before this one, transitive dependencies included
+	Dependencies []PullRequestDependency `json:"dependencies,omitempty" db:"-"`
+	Repository   string                  `json:"repository,omitempty" db:"repository"`
+	// id of PR inside its repository
+	Number string `json:"number,omitempty" db:"number"`
+	// team of the author chosen on creation, empty means primary team of the author
+	TeamName string `json:"team_name,omitempty" db:"team_name"`
+}
+
+type PullRequestSort string
+
+const (
+	PullRequestSortCreatedAt PullRequestSort = "created_at"
+	PullRequestSortID        PullRequestSort = "pull_request_id"
+	PullRequestSortName      PullRequestSort = "pull_request_name"
+)
+
+// PullRequestFilter selects page of PRs, empty fields do not filter
+type PullRequestFilter struct {
+	Repository string
+	Status     PullRequestStatus
+	AuthorID   string
+	TeamName   string // team of the author which PR is created in
+	ReviewerID string
+	// time ranges include from and exclude to
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+
+	SortBy PullRequestSort
+	Desc   bool
+	Limit  int
+	// page starts after this PR, nil for the first page
+	After *PullRequestCursor
+}
+
+// PullRequestCursor is a position in the list sorted by SortBy and pull_request_id
+type PullRequestCursor struct {
+	SortBy PullRequestSort `json:"s"`
+	Desc   bool            `json:"d,omitempty"`
+	Value  string          `json:"v"` // SortBy column value of the last PR
+	ID     string          `json:"id"`
+}
+
+type PullRequestPage struct {
+	PullRequests []PullRequest `json:"pull_requests"`
+	// empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type PullRequestQuantityReviewers struct {
@@ -26,20 +98,94 @@ type PullRequestQuantityReviewers struct {
 
 // CreatePullRequest represents the data needed to create a new pull request.
 type CreatePullRequest struct {
+	// id inside the repository
 	ID       string `json:"pull_request_id" db:"pull_request_id"`
 	Name     string `json:"pull_request_name" db:"pull_request_name"`
 	AuthorID string `json:"author_id" db:"author_id"`
+	// optional, default repository if empty. Unknown repository is registered,
+	// owners of changed files from repository CODEOWNERS are assigned first
+	Repository   string   `json:"repository,omitempty" db:"-"`
+	ChangedFiles []string `json:"changed_files,omitempty" db:"-"`
+	// reviewers with skills matching labels are preferred
+	Labels []string `json:"labels,omitempty" db:"-"`
+	// draft PR is created without reviewers
+	Draft bool `json:"draft,omitempty" db:"-"`
+	// PRs which have to be merged before this one
+	DependsOn []string `json:"depends_on,omitempty" db:"-"`
+	// one of author teams which reviews the PR and whose policies apply to it,
+	// primary team of the author if empty
+	TeamName string `json:"team_name,omitempty" db:"-"`
+}
+
+// ReadyPullRequest marks draft PR ready for review, reviewers are assigned as for a new PR
+type ReadyPullRequest struct {
+	ID         string `json:"pull_request_id"`
+	Repository string `json:"repository,omitempty"`
+	// optional, owners of changed files from repository CODEOWNERS are assigned first
+	ChangedFiles []string `json:"changed_files,omitempty"`
+}
+
+// ChangePullRequestStatus converts PR to draft, closes or reopens it
+type ChangePullRequestStatus struct {
+	ID         string `json:"pull_request_id"`
+	Repository string `json:"repository,omitempty"`
+}
+
+type SetPullRequestLabelsRequest struct {
+	ID         string   `json:"pull_request_id"`
+	Repository string   `json:"repository,omitempty"`
+	Labels     []string `json:"labels"`
+}
+
+type SetPullRequestDependenciesRequest struct {
+	ID         string `json:"pull_request_id"`
+	Repository string `json:"repository,omitempty"`
+	// global ids of PRs, see PullRequestKey
+	DependsOn []string `json:"depends_on"`
+}
+
+// PullRequestDependency is an edge of dependency graph, PR is merged only after PR it depends on
+type PullRequestDependency struct {
+	PullRequestID string `json:"pull_request_id" db:"pull_request_id"`
+	DependsOnID   string `json:"depends_on" db:"depends_on_id"`
+	// status of the PR it depends on
+	Status PullRequestStatus `json:"status" db:"status"`
 }
 
 type MergePullRequest struct {
-	ID string `json:"pull_request_id"`
+	ID         string `json:"pull_request_id"`
+	Repository string `json:"repository,omitempty"`
+	// merge regardless of the merge policy, recorded on the PR
+	Override bool `json:"override"`
+}
+
+type MergeCondition string
+
+const (
+	MergeConditionMinApprovals     MergeCondition = "MIN_APPROVALS"
+	MergeConditionChangesRequested MergeCondition = "CHANGES_REQUESTED"
+	MergeConditionRequiredApprover MergeCondition = "REQUIRED_APPROVER"
+)
+
+// UnmetMergeCondition is a merge policy condition which blocks the merge
+type UnmetMergeCondition struct {
+	Condition MergeCondition `json:"condition"`
+	Message   string         `json:"message"`
 }
 
 type ReassignPullRequest struct {
 	ID            string `json:"pull_request_id"`
+	Repository    string `json:"repository,omitempty"`
 	OldReviewerID string `json:"old_reviewer_id"`
 }
 
+// PullRequestReviewerRequest adds or removes the reviewer of the PR
+type PullRequestReviewerRequest struct {
+	ID         string `json:"pull_request_id"`
+	Repository string `json:"repository,omitempty"`
+	UserID     string `json:"user_id"`
+}
+
 type ReassignPullRequestResponse struct {
 	PR        PullRequest `json:"pr"`
 	RepacedBy string      `json:"replaced_by"`
@@ -48,3 +194,64 @@ type UserReviews struct {
 	UserID       string        `json:"user_id" db:"user_id"`
 	PullRequests []PullRequest `json:"pull_requests" db:"pull_requests"`
 }
+
+type ReviewerReplacement struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id"`
+}
+
+// ReviewsReassignment is a result of moving OPEN reviews from the reviewer
+type ReviewsReassignment struct {
+	Reassigned []ReviewerReplacement `json:"reassigned"`
+	// PRs without replacement candidate, reviewer stays assigned to them
+	NotReassigned []string `json:"not_reassigned"`
+}
+
+type OpenReview struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+}
+
+// BulkReviewsReassignment is a result of moving OPEN reviews from several reviewers at once
+type BulkReviewsReassignment struct {
+	Reassigned []ReviewerReplacement `json:"reassigned"`
+	// reviews without replacement candidate, reviewer stays assigned to them
+	NotReassigned []OpenReview `json:"not_reassigned"`
+}
+
+type ReviewState string
+
+const (
+	// reviewer is assigned but did not submit any review
+	ReviewStatePending          ReviewState = "PENDING"
+	ReviewStateApproved         ReviewState = "APPROVED"
+	ReviewStateChangesRequested ReviewState = "CHANGES_REQUESTED"
+	ReviewStateCommented        ReviewState = "COMMENTED"
+)
+
+// ReviewerState is assigned reviewer with the state of latest APPROVED or CHANGES_REQUESTED review,
+// COMMENTED if the reviewer only commented
+type ReviewerState struct {
+	UserID      string      `json:"user_id" db:"reviewer_user_id"`
+	State       ReviewState `json:"state" db:"state"`
+	SubmittedAt *time.Time  `json:"submitted_at,omitempty" db:"submitted_at"`
+}
+
+// Review is a review decision submitted by the reviewer, all of them are kept as history
+type Review struct {
+	ID            int64       `json:"review_id" db:"review_id"`
+	PullRequestID string      `json:"pull_request_id" db:"pull_request_id"`
+	ReviewerID    string      `json:"reviewer_id" db:"reviewer_user_id"`
+	State         ReviewState `json:"state" db:"state"`
+	Body          string      `json:"body,omitempty" db:"body"`
+	SubmittedAt   time.Time   `json:"submitted_at" db:"submitted_at"`
+}
+
+type SubmitReviewRequest struct {
+	PullRequestID string      `json:"pull_request_id"`
+	Repository    string      `json:"repository,omitempty"`
+	ReviewerID    string      `json:"reviewer_id"`
+	State         ReviewState `json:"state"`
+	Body          string      `json:"body"`
+}
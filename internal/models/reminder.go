@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// ReminderStatus is a delivery state of the reminder
+type ReminderStatus string
+
+const (
+	// reminder is claimed by a run and is being delivered
+	ReminderStatusPending ReminderStatus = "PENDING"
+	ReminderStatusSent    ReminderStatus = "SENT"
+	// delivery failed, reminder is retried on the next run
+	ReminderStatusFailed ReminderStatus = "FAILED"
+)
+
+// ReviewReminder reminds reviewer of OPEN PR which the reviewer has not reviewed since assignment
+type ReviewReminder struct {
+	ReviewerID      string    `json:"reviewer_id" db:"reviewer_user_id"`
+	PullRequestID   string    `json:"pull_request_id" db:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name" db:"pull_request_name"`
+	AssignedAt      time.Time `json:"assigned_at" db:"assigned_at"`
+	// nil if reviewer has no quiet hours
+	QuietHours *QuietHours `json:"-" db:"-"`
+}
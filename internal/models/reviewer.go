@@ -0,0 +1,63 @@
+package models
+
+// ReviewCandidate is a team member which can be assigned as a PR reviewer.
+type ReviewCandidate struct {
+	UserID      string `json:"user_id" db:"user_id"`
+	TeamName    string `json:"team_name" db:"team_name"`
+	IsActive    bool   `json:"is_active" db:"is_active"`
+	OpenReviews int    `json:"open_reviews" db:"open_reviews"`
+	// nil means no limit of open reviews
+	MaxOpenReviews *int     `json:"max_open_reviews,omitempty" db:"max_open_reviews"`
+	Skills         []string `json:"skills,omitempty" db:"-"`
+	// active user on vacation or on day off, is_active=false excludes user regardless of schedule
+	Unavailable bool `json:"unavailable" db:"unavailable"`
+}
+
+// OverCapacity reports whether candidate already reviews as many OPEN PRs as allowed
+func (c ReviewCandidate) OverCapacity() bool {
+	return c.MaxOpenReviews != nil && c.OpenReviews >= *c.MaxOpenReviews
+}
+
+// OpenAssignment is an assignment of the reviewer to OPEN PR with data needed to replace the reviewer
+type OpenAssignment struct {
+	PullRequestID string `db:"pull_request_id"`
+	AuthorID      string `db:"author_id"`
+	// author team chosen on PR creation, owner team of PR repository or primary team of the author
+	ReviewTeamName string `db:"review_team_name"`
+	ReviewerID     string `db:"reviewer_user_id"`
+	// all reviewers of the PR including ReviewerID
+	AssignedReviewers []string `db:"-"`
+	Labels            []string `db:"-"`
+}
+
+// ExclusionReason explains why team member can not be assigned as PR reviewer
+type ExclusionReason string
+
+const (
+	ExclusionReasonAuthor       ExclusionReason = "AUTHOR"
+	ExclusionReasonInactive     ExclusionReason = "INACTIVE"
+	ExclusionReasonUnavailable  ExclusionReason = "UNAVAILABLE"
+	ExclusionReasonOverCapacity ExclusionReason = "OVER_CAPACITY"
+	// already reviews the PR or was picked earlier, e.g. as code owner
+	ExclusionReasonAlreadyAssigned ExclusionReason = "ALREADY_ASSIGNED"
+)
+
+type PreviewCandidate struct {
+	ReviewCandidate
+	Selected bool `json:"selected"`
+	// empty for eligible candidates, including ones which were not picked by strategy
+	ExcludedReason ExclusionReason `json:"excluded_reason,omitempty"`
+}
+
+// AssignmentPreview shows reviewers which CreatePR would assign for the author
+type AssignmentPreview struct {
+	AuthorID           string             `json:"author_id"`
+	TeamName           string             `json:"team_name"`
+	Labels             []string           `json:"labels"`
+	Candidates         []PreviewCandidate `json:"candidates"`
+	Reviewers          []string           `json:"reviewers"`
+	Understaffed       bool               `json:"understaffed"`
+	UnderstaffedPolicy UnderstaffedPolicy `json:"understaffed_policy"`
+	// reviewer id -> PR label matched with reviewer skills
+	MatchedLabels map[string]string `json:"matched_labels,omitempty"`
+}
@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// SLAEscalation is an action taken when PR of team members breaches the SLA
+type SLAEscalation string
+
+const (
+	// one more reviewer is assigned to the PR
+	SLAEscalationAddReviewer SLAEscalation = "ADD_REVIEWER"
+	// silent reviewers are replaced by ReassignPR rules
+	SLAEscalationReassign SLAEscalation = "REASSIGN"
+	// breach is only reported to the webhook
+	SLAEscalationWebhook SLAEscalation = "WEBHOOK"
+)
+
+type SLAKind string
+
+const (
+	// time from assignment of the reviewer to the review
+	SLAKindReview SLAKind = "REVIEW"
+	// time from creation of the PR to the merge
+	SLAKindMerge SLAKind = "MERGE"
+)
+
+type SLALevel string
+
+const (
+	// SLA is about to be breached
+	SLALevelWarning SLALevel = "WARNING"
+	SLALevelBreach  SLALevel = "BREACH"
+)
+
+// SLAPolicy limits age of reviews and PRs of team members, zero hours disable the limit
+type SLAPolicy struct {
+	TeamName       string        `json:"team_name" db:"team_name"`
+	ReviewSLAHours int           `json:"review_sla_hours" db:"review_sla_hours"`
+	MergeSLAHours  int           `json:"merge_sla_hours" db:"merge_sla_hours"`
+	Escalation     SLAEscalation `json:"escalation" db:"sla_escalation"`
+}
+
+// SLATimer is a running SLA of OPEN PR or of its reviewer who has not reviewed it since assignment
+type SLATimer struct {
+	Kind          SLAKind `json:"sla_kind" db:"sla_kind"`
+	PullRequestID string  `json:"pull_request_id" db:"pull_request_id"`
+	// empty for time to merge
+	ReviewerID string        `json:"reviewer_id,omitempty" db:"reviewer_user_id"`
+	TeamName   string        `json:"team_name" db:"team_name"`
+	StartedAt  time.Time     `json:"started_at" db:"started_at"`
+	Hours      int           `json:"sla_hours" db:"sla_hours"`
+	Escalation SLAEscalation `json:"escalation" db:"sla_escalation"`
+}
+
+func (t *SLATimer) Deadline() time.Time {
+	return t.StartedAt.Add(time.Duration(t.Hours) * time.Hour)
+}
+
+// SLAEscalationResult lists reviewer changes made by escalation
+type SLAEscalationResult struct {
+	AddedReviewer string                `json:"added_reviewer,omitempty"`
+	Reassigned    []ReviewerReplacement `json:"reassigned,omitempty"`
+}
+
+// SLANotice reports warning or breach of the SLA timer
+type SLANotice struct {
+	SLATimer
+	Level    SLALevel  `json:"level"`
+	Deadline time.Time `json:"deadline"`
+	// set for breaches escalated by reviewer changes
+	Escalated *SLAEscalationResult `json:"escalated,omitempty"`
+}
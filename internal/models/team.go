@@ -1,6 +1,195 @@
 package models
 
+import "time"
+
+type UnderstaffedPolicy string
+
+const (
+	// CreatePR fails when the team has less active members than min reviewers
+	UnderstaffedPolicyFail UnderstaffedPolicy = "FAIL"
+	// PR is created with as many reviewers as available
+	UnderstaffedPolicyAssignFewer UnderstaffedPolicy = "ASSIGN_FEWER"
+	// free reviewer slots up to max reviewers are filled from the fallback teams in their order
+	UnderstaffedPolicyFallback UnderstaffedPolicy = "FALLBACK"
+)
+
+type MembershipRole string
+
+const (
+	MembershipRoleMember MembershipRole = "MEMBER"
+	MembershipRoleLead   MembershipRole = "LEAD"
+)
+
+// TeamMembership is a membership of the user in one of user teams,
+// user reviews PRs for the team only while both user and membership are active
+type TeamMembership struct {
+	UserID   string         `json:"user_id" db:"user_id"`
+	TeamName string         `json:"team_name" db:"team_name"`
+	Role     MembershipRole `json:"role" db:"role"`
+	IsActive bool           `json:"is_active" db:"is_active"`
+}
+
 type Team struct {
 	TeamName string `json:"team_name" db:"team_name"`
 	Members  []User `json:"members" db:"members"`
+	// team above this one in the hierarchy, e.g. department
+	ParentTeam string `json:"parent_team,omitempty" db:"parent_team"`
+	// archived team is readable, but its members are not assigned to review its PRs
+	ArchivedAt *time.Time `json:"archived_at,omitempty" db:"archived_at"`
+}
+
+// TeamSettings describes how many reviewers are assigned to PRs of team members
+type TeamSettings struct {
+	TeamName           string             `json:"team_name" db:"team_name"`
+	MinReviewers       int                `json:"min_reviewers" db:"min_reviewers"`
+	MaxReviewers       int                `json:"max_reviewers" db:"max_reviewers"`
+	UnderstaffedPolicy UnderstaffedPolicy `json:"understaffed_policy" db:"understaffed_policy"`
+	// ordered list of teams to take reviewers from when team has not enough of them
+	FallbackTeams []string   `json:"fallback_teams" db:"-"`
+	ArchivedAt    *time.Time `json:"archived_at,omitempty" db:"archived_at"`
+	// changed only by SetParentTeamRequest, reviewers are taken from the hierarchy
+	// when the team has no one to review
+	ParentTeam string `json:"parent_team,omitempty" db:"parent_team"`
+}
+
+// SetParentTeamRequest moves the team in the hierarchy, empty parent makes the team a root
+type SetParentTeamRequest struct {
+	TeamName   string `json:"team_name"`
+	ParentTeam string `json:"parent_team"`
+}
+
+// TeamNode is a team of the subtree with its direct members and child teams
+type TeamNode struct {
+	Team
+	Children []*TeamNode `json:"children"`
+}
+
+type TeamSubtree struct {
+	Team *TeamNode `json:"team"`
+	// users who are members of any team of the subtree with their primary teams
+	Members []User `json:"members"`
+}
+
+// PullRequestCounts counts PRs reviewed by the team by their status
+type PullRequestCounts struct {
+	Draft  int `json:"draft" db:"draft"`
+	Open   int `json:"open" db:"open"`
+	Merged int `json:"merged" db:"merged"`
+	Closed int `json:"closed" db:"closed"`
+	// assigned reviewers of OPEN PRs
+	OpenReviews int `json:"open_reviews" db:"open_reviews"`
+}
+
+func (c *PullRequestCounts) Add(other PullRequestCounts) {
+	c.Draft += other.Draft
+	c.Open += other.Open
+	c.Merged += other.Merged
+	c.Closed += other.Closed
+	c.OpenReviews += other.OpenReviews
+}
+
+type TeamPullRequestCounts struct {
+	TeamName string `db:"team_name"`
+	PullRequestCounts
+}
+
+// TeamStatistics is a node of the subtree statistics, total of the team includes totals of its child teams
+type TeamStatistics struct {
+	TeamName   string            `json:"team_name"`
+	ParentTeam string            `json:"parent_team,omitempty"`
+	ArchivedAt *time.Time        `json:"archived_at,omitempty"`
+	Own        PullRequestCounts `json:"own"`
+	Total      PullRequestCounts `json:"total"`
+	Children   []*TeamStatistics `json:"children"`
+}
+
+// TeamArchivePolicy decides what happens to DRAFT and OPEN PRs reviewed by the archived team
+type TeamArchivePolicy string
+
+const (
+	// archive fails while the team has DRAFT or OPEN PRs
+	TeamArchivePolicyBlock TeamArchivePolicy = "BLOCK"
+	// PRs and repositories of the team are moved to the successor team,
+	// reviewers who are not members of the successor are reassigned
+	TeamArchivePolicyReassign TeamArchivePolicy = "REASSIGN"
+)
+
+type ArchiveTeamRequest struct {
+	TeamName string
+	// BLOCK if empty
+	Policy        TeamArchivePolicy
+	SuccessorTeam string
+}
+
+type ArchiveTeamResponse struct {
+	TeamName      string            `json:"team_name"`
+	ArchivedAt    time.Time         `json:"archived_at"`
+	Policy        TeamArchivePolicy `json:"policy"`
+	SuccessorTeam string            `json:"successor_team,omitempty"`
+	// DRAFT and OPEN PRs moved to the successor team
+	PullRequests []string `json:"pull_requests"`
+	*BulkReviewsReassignment
+}
+
+// SetMembershipRequest changes role of the team member and whether member reviews PRs for the team
+type SetMembershipRequest struct {
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id"`
+	// MEMBER if empty
+	Role     MembershipRole `json:"role,omitempty"`
+	IsActive bool           `json:"is_active"`
+}
+
+// UpsertTeamResponse is a result of making team members match the requested list
+type UpsertTeamResponse struct {
+	Team *Team `json:"team"`
+	// users which were members of the team but are not in the list
+	Removed []string `json:"removed"`
+	// OPEN reviews of deactivated and removed members
+	*BulkReviewsReassignment
+}
+
+type AddTeamMemberRequest struct {
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id"`
+	// required for a new user, existing user keeps name, status and primary team
+	Username string         `json:"username,omitempty"`
+	Role     MembershipRole `json:"role,omitempty"`
+	// membership is active unless the flag is false
+	MembershipActive *bool `json:"membership_active,omitempty"`
+}
+
+type RemoveTeamMemberRequest struct {
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id"`
+}
+
+// RemoveTeamMemberResponse contains reassigned OPEN reviews of PRs of the team,
+// member removed from the primary team is deactivated and loses all OPEN reviews
+type RemoveTeamMemberResponse struct {
+	TeamName    string `json:"team_name"`
+	UserID      string `json:"user_id"`
+	Deactivated bool   `json:"deactivated"`
+	*ReviewsReassignment
+}
+
+type DeactivateTeamUsersRequest struct {
+	TeamName string   `json:"team_name"`
+	UserIDs  []string `json:"user_ids"`
+}
+
+type DeactivateTeamUsersResponse struct {
+	TeamName    string `json:"team_name"`
+	Deactivated []User `json:"deactivated"`
+	*BulkReviewsReassignment
+}
+
+// MergePolicy lists conditions which PRs of team members must meet to be merged
+type MergePolicy struct {
+	TeamName     string `json:"team_name" db:"team_name"`
+	MinApprovals int    `json:"min_approvals" db:"min_approvals"`
+	// latest review of any reviewer is CHANGES_REQUESTED
+	BlockOnChangesRequested bool `json:"block_on_changes_requested" db:"block_on_changes_requested"`
+	// users whose latest review must be APPROVED
+	RequiredApprovers []string `json:"required_approvers" db:"-"`
 }
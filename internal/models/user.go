@@ -3,11 +3,75 @@ package models
 type User struct {
 	UserID   string `json:"user_id" db:"user_id"`
 	Username string `json:"username" db:"username"`
+	// primary team, its members review PRs of the user by default
 	TeamName string `json:"team_name,omitempty" db:"team_name"`
 	IsActive bool   `json:"is_active" db:"is_active"`
+	// limit of OPEN PRs for review, nil means no limit
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty" db:"max_open_reviews"`
+	// tags like "go" or "sql" matched against PR labels
+	Skills []string `json:"skills,omitempty" db:"-"`
+	// optional profile fields
+	Email       string `json:"email,omitempty" db:"email"`
+	DisplayName string `json:"display_name,omitempty" db:"display_name"`
+	// forge -> login of the user on it, e.g. "github" -> "octocat"
+	ForgeLogins map[string]string `json:"forge_logins,omitempty" db:"-"`
+	// role and membership flag of the team member, set only in team members.
+	// Membership of the new member is active unless the flag is false
+	Role             MembershipRole `json:"role,omitempty" db:"role"`
+	MembershipActive *bool          `json:"membership_active,omitempty" db:"membership_active"`
 }
 
 type SetUserActiveStatusRequest struct {
 	UserID   string `json:"user_id"`
 	IsActive bool   `json:"is_active"`
 }
+
+// SetUserActiveStatusResponse contains reassigned OPEN reviews when user is deactivated
+type SetUserActiveStatusResponse struct {
+	User *User `json:"user"`
+	*ReviewsReassignment
+}
+
+type SetUserReviewLimitRequest struct {
+	UserID         string `json:"user_id"`
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+}
+
+type SetUserSkillsRequest struct {
+	UserID string   `json:"user_id"`
+	Skills []string `json:"skills"`
+}
+
+// UpdateUserRequest changes profile of the user, nil fields are not changed.
+// Empty email or display name removes it, forge logins replace all logins of the user
+type UpdateUserRequest struct {
+	UserID      string            `json:"user_id"`
+	Username    *string           `json:"username"`
+	Email       *string           `json:"email"`
+	DisplayName *string           `json:"display_name"`
+	ForgeLogins map[string]string `json:"forge_logins"`
+}
+
+// UserFilter selects page of users ordered by user_id, empty fields do not filter
+type UserFilter struct {
+	// member of the team, not only primary one
+	TeamName string
+	IsActive *bool
+	// case insensitive prefix of username or display name
+	NamePrefix string
+	Limit      int
+	// user_id of the last user of the previous page
+	After string
+}
+
+type UserPage struct {
+	Users []User `json:"users"`
+	// empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type UserForgeLogin struct {
+	UserID string `db:"user_id"`
+	Forge  string `db:"forge"`
+	Login  string `db:"login"`
+}
@@ -0,0 +1,105 @@
+package ownershiphttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	ownershipservice "github.com/Negat1v9/pr-review-service/internal/ownership/service"
+	"github.com/Negat1v9/pr-review-service/pkg/logger"
+	"github.com/Negat1v9/pr-review-service/pkg/utils"
+)
+
+type OwnershipHandler struct {
+	log     *logger.Logger
+	service *ownershipservice.OwnershipService
+}
+
+func NewOwnershipHandler(log *logger.Logger, service *ownershipservice.OwnershipService) *OwnershipHandler {
+	return &OwnershipHandler{
+		log:     log,
+		service: service,
+	}
+}
+
+func (h *OwnershipHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	var req models.UploadCodeownersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	saved, err := h.service.UploadCodeowners(ctx, &req)
+	if err != nil {
+		h.log.Errorf("failed to upload codeowners: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "codeowners", saved)
+}
+
+func (h *OwnershipHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	repository := r.URL.Query().Get("repository")
+	if repository == "" {
+		utils.WriteErrResponse(w, utils.NewNotFoundError("resource not found", nil))
+		return
+	}
+
+	saved, err := h.service.GetCodeowners(ctx, repository)
+	if err != nil {
+		h.log.Errorf("failed to get codeowners: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "codeowners", saved)
+}
+
+func (h *OwnershipHandler) SetRepository(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	var req models.SetRepositoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	repo, err := h.service.SetRepository(ctx, &req)
+	if err != nil {
+		h.log.Errorf("failed to set repository: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "repository", repo)
+}
+
+func (h *OwnershipHandler) GetRepository(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	repository := r.URL.Query().Get("repository")
+	if repository == "" {
+		utils.WriteErrResponse(w, utils.NewNotFoundError("resource not found", nil))
+		return
+	}
+
+	repo, err := h.service.GetRepository(ctx, repository)
+	if err != nil {
+		h.log.Errorf("failed to get repository: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "repository", repo)
+}
@@ -0,0 +1,195 @@
+package ownershiphttp
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	ownershipservice "github.com/Negat1v9/pr-review-service/internal/ownership/service"
+	mock_store "github.com/Negat1v9/pr-review-service/internal/store/mock"
+	"github.com/Negat1v9/pr-review-service/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOwnershipRepo := mock_store.NewMockOwnershipRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+
+	mockStore.EXPECT().OwnershipRepo().Return(mockOwnershipRepo).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+
+	doReq := func(body any) *httptest.ResponseRecorder {
+		service := ownershipservice.NewOwnershipService(mockStore)
+		handler := NewOwnershipHandler(logger.NewLogger("local"), service)
+		ownershipMux := OwnershipRouter(handler)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", "/upload", bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		ownershipMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Upload success", func(t *testing.T) {
+		content := "# backend\n*.go @u1 @org/backend\n/docs/ @u2\n"
+		mockOwnershipRepo.EXPECT().UpsertCodeowners(gomock.Any(), gomock.Any(), "service", content).
+			Return(&models.Codeowners{Repository: "service", Content: content, UpdatedAt: time.Now()}, nil).Times(1)
+
+		rr := doReq(models.UploadCodeownersRequest{Repository: "service", Content: content})
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		rules := r["codeowners"].(map[string]any)["rules"].([]any)
+		require.Equal(t, 2, len(rules))
+		require.Equal(t, "*.go", rules[0].(map[string]any)["pattern"])
+	})
+
+	t.Run("Invalid content", func(t *testing.T) {
+		rr := doReq(models.UploadCodeownersRequest{Repository: "service", Content: "*.go backend-team\n"})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Empty repository", func(t *testing.T) {
+		rr := doReq(models.UploadCodeownersRequest{Content: "*.go @u1\n"})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOwnershipRepo := mock_store.NewMockOwnershipRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+
+	mockStore.EXPECT().OwnershipRepo().Return(mockOwnershipRepo).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+
+	doReq := func(repository string) *httptest.ResponseRecorder {
+		service := ownershipservice.NewOwnershipService(mockStore)
+		handler := NewOwnershipHandler(logger.NewLogger("local"), service)
+		ownershipMux := OwnershipRouter(handler)
+
+		req, err := http.NewRequest("GET", "/get?repository="+repository, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		ownershipMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Get success", func(t *testing.T) {
+		mockOwnershipRepo.EXPECT().GetCodeowners(gomock.Any(), gomock.Any(), "service").
+			Return(&models.Codeowners{Repository: "service", Content: "* @u1\n"}, nil).Times(1)
+
+		rr := doReq("service")
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mockOwnershipRepo.EXPECT().GetCodeowners(gomock.Any(), gomock.Any(), "unknown").
+			Return(nil, sql.ErrNoRows).Times(1)
+
+		rr := doReq("unknown")
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOwnershipRepo := mock_store.NewMockOwnershipRepository(ctrl)
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+
+	mockStore.EXPECT().OwnershipRepo().Return(mockOwnershipRepo).AnyTimes()
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+
+	doReq := func(method, path string, body any) *httptest.ResponseRecorder {
+		service := ownershipservice.NewOwnershipService(mockStore)
+		handler := NewOwnershipHandler(logger.NewLogger("local"), service)
+		ownershipMux := OwnershipRouter(handler)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest(method, path, bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		ownershipMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Set owner team", func(t *testing.T) {
+		repo := models.Repository{Name: "search", TeamName: "backend", CreatedAt: time.Now()}
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "backend").Return(&models.TeamSettings{TeamName: "backend"}, nil).Times(1)
+		mockOwnershipRepo.EXPECT().UpsertRepository(gomock.Any(), gomock.Any(), &models.Repository{Name: "search", TeamName: "backend"}).Return(&repo, nil).Times(1)
+
+		rr := doReq("POST", "/setRepository", models.SetRepositoryRequest{Repository: "search", TeamName: "backend"})
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		r := map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, "backend", r["repository"].(map[string]any)["team_name"])
+	})
+
+	t.Run("Unknown team", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "unknown").Return(nil, sql.ErrNoRows).Times(1)
+
+		rr := doReq("POST", "/setRepository", models.SetRepositoryRequest{Repository: "search", TeamName: "unknown"})
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Archived team", func(t *testing.T) {
+		archivedAt := time.Now()
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "legacy").Return(&models.TeamSettings{TeamName: "legacy", ArchivedAt: &archivedAt}, nil).Times(1)
+
+		rr := doReq("POST", "/setRepository", models.SetRepositoryRequest{Repository: "search", TeamName: "legacy"})
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Invalid name", func(t *testing.T) {
+		rr := doReq("POST", "/setRepository", models.SetRepositoryRequest{Repository: "search#1"})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Get repository", func(t *testing.T) {
+		mockOwnershipRepo.EXPECT().GetRepository(gomock.Any(), gomock.Any(), "search").Return(&models.Repository{Name: "search"}, nil).Times(1)
+
+		rr := doReq("GET", "/getRepository?repository=search", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Get unknown repository", func(t *testing.T) {
+		mockOwnershipRepo.EXPECT().GetRepository(gomock.Any(), gomock.Any(), "unknown").Return(nil, sql.ErrNoRows).Times(1)
+
+		rr := doReq("GET", "/getRepository?repository=unknown", nil)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,14 @@
+package ownershiphttp
+
+import "net/http"
+
+func OwnershipRouter(h *OwnershipHandler) http.Handler {
+	handler := http.NewServeMux()
+
+	handler.HandleFunc("POST /upload", h.Upload)
+	handler.HandleFunc("GET /get", h.Get)
+	handler.HandleFunc("POST /setRepository", h.SetRepository)
+	handler.HandleFunc("GET /getRepository", h.GetRepository)
+
+	return handler
+}
@@ -0,0 +1,109 @@
+package ownershipservice
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/Negat1v9/pr-review-service/internal/store"
+	"github.com/Negat1v9/pr-review-service/pkg/codeowners"
+	"github.com/Negat1v9/pr-review-service/pkg/utils"
+)
+
+type OwnershipService struct {
+	store store.Store
+}
+
+func NewOwnershipService(store store.Store) *OwnershipService {
+	return &OwnershipService{
+		store: store,
+	}
+}
+
+// UploadCodeowners validates CODEOWNERS content and replaces rules of the repository
+func (s *OwnershipService) UploadCodeowners(ctx context.Context, req *models.UploadCodeownersRequest) (*models.Codeowners, error) {
+	if req.Repository == "" {
+		return nil, utils.NewBadRequestError("repository is required", nil)
+	}
+
+	rules, err := codeowners.Parse(req.Content)
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid CODEOWNERS: "+err.Error(), nil)
+	}
+
+	saved, err := s.store.OwnershipRepo().UpsertCodeowners(ctx, s.store.DB(), req.Repository, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	saved.Rules = toOwnershipRules(rules)
+	return saved, nil
+}
+
+func (s *OwnershipService) GetCodeowners(ctx context.Context, repository string) (*models.Codeowners, error) {
+	saved, err := s.store.OwnershipRepo().GetCodeowners(ctx, s.store.DB(), repository)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NewNotFoundError("resource not found", nil)
+		}
+		return nil, err
+	}
+
+	// content was validated on upload
+	rules, err := codeowners.Parse(saved.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	saved.Rules = toOwnershipRules(rules)
+	return saved, nil
+}
+
+// SetRepository registers the repository and sets its owner team, members of owner team review PRs
+// of the repository instead of author team. Empty team name removes the owner team
+func (s *OwnershipService) SetRepository(ctx context.Context, req *models.SetRepositoryRequest) (*models.Repository, error) {
+	if req.Repository == "" {
+		return nil, utils.NewBadRequestError("repository is required", nil)
+	}
+	if strings.Contains(req.Repository, "#") {
+		return nil, utils.NewBadRequestError("repository name cannot contain #", nil)
+	}
+
+	if req.TeamName != "" {
+		settings, err := s.store.TeamRepo().GetTeamSettings(ctx, s.store.DB(), req.TeamName)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, utils.NewNotFoundError("resource not found", nil)
+			}
+			return nil, err
+		}
+		if settings.ArchivedAt != nil {
+			return nil, utils.NewError(409, utils.ErrTeamArchived, "team is archived", nil)
+		}
+	}
+
+	return s.store.OwnershipRepo().UpsertRepository(ctx, s.store.DB(), &models.Repository{
+		Name:     req.Repository,
+		TeamName: req.TeamName,
+	})
+}
+
+func (s *OwnershipService) GetRepository(ctx context.Context, repository string) (*models.Repository, error) {
+	repo, err := s.store.OwnershipRepo().GetRepository(ctx, s.store.DB(), repository)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NewNotFoundError("resource not found", nil)
+		}
+		return nil, err
+	}
+	return repo, nil
+}
+
+func toOwnershipRules(rules []codeowners.Rule) []models.OwnershipRule {
+	res := make([]models.OwnershipRule, 0, len(rules))
+	for _, rule := range rules {
+		res = append(res, models.OwnershipRule{Pattern: rule.Pattern, Owners: rule.Owners})
+	}
+	return res
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Negat1v9/pr-review-service/internal/models"
@@ -43,6 +46,48 @@ func (h *PRHanler) Create(w http.ResponseWriter, r *http.Request) {
 
 	utils.WriteJsonResponse(w, http.StatusCreated, "pr", newPR)
 }
+
+func (h *PRHanler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		utils.WriteErrResponse(w, utils.NewNotFoundError("resource not found", nil))
+		return
+	}
+	prID = models.PullRequestKey(r.URL.Query().Get("repository"), prID)
+
+	pr, err := h.service.GetPR(ctx, prID)
+	if err != nil {
+		h.log.Errorf("failed to get pull request: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "pr", pr)
+}
+
+func (h *PRHanler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	filter, err := parseListFilter(r.URL.Query())
+	if err != nil {
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	page, err := h.service.ListPRs(ctx, filter, r.URL.Query().Get("cursor"))
+	if err != nil {
+		h.log.Errorf("failed to list pull requests: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "", page)
+}
+
 func (h *PRHanler) Merge(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
 	defer cancel()
@@ -52,7 +97,7 @@ func (h *PRHanler) Merge(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	mergedPR, err := h.service.MergePR(ctx, req.ID)
+	mergedPR, err := h.service.MergePR(ctx, models.PullRequestKey(req.Repository, req.ID), req.Override)
 	if err != nil {
 		h.log.Errorf("failed to merge pull request: %v", err)
 		utils.WriteErrResponse(w, err)
@@ -62,6 +107,215 @@ func (h *PRHanler) Merge(w http.ResponseWriter, r *http.Request) {
 	utils.WriteJsonResponse(w, http.StatusOK, "pr", mergedPR)
 }
 
+func (h *PRHanler) MarkReady(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+	var req models.ReadyPullRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	readyPR, err := h.service.MarkReadyForReview(ctx, &req)
+	if err != nil {
+		h.log.Errorf("failed to mark pull request ready for review: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "pr", readyPR)
+}
+
+func (h *PRHanler) ConvertToDraft(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, "convert pull request to draft", h.service.ConvertToDraft)
+}
+
+func (h *PRHanler) Close(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, "close pull request", h.service.ClosePR)
+}
+
+func (h *PRHanler) Reopen(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, "reopen pull request", h.service.ReopenPR)
+}
+
+// changeStatus handles status transitions which need only PR id
+func (h *PRHanler) changeStatus(w http.ResponseWriter, r *http.Request, action string, change func(ctx context.Context, prID string) (*models.PullRequest, error)) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+	var req models.ChangePullRequestStatus
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	updatedPR, err := change(ctx, models.PullRequestKey(req.Repository, req.ID))
+	if err != nil {
+		h.log.Errorf("failed to %s: %v", action, err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "pr", updatedPR)
+}
+
+func (h *PRHanler) SetLabels(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+	var req models.SetPullRequestLabelsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	updatedPR, err := h.service.SetLabels(ctx, models.PullRequestKey(req.Repository, req.ID), req.Labels)
+	if err != nil {
+		h.log.Errorf("failed to set pull request labels: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "pr", updatedPR)
+}
+
+func (h *PRHanler) SetDependencies(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+	var req models.SetPullRequestDependenciesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	updatedPR, err := h.service.SetDependencies(ctx, models.PullRequestKey(req.Repository, req.ID), req.DependsOn)
+	if err != nil {
+		h.log.Errorf("failed to set pull request dependencies: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "pr", updatedPR)
+}
+
+func (h *PRHanler) Blocked(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		utils.WriteErrResponse(w, utils.NewNotFoundError("resource not found", nil))
+		return
+	}
+	prID = models.PullRequestKey(r.URL.Query().Get("repository"), prID)
+
+	blocked, err := h.service.BlockedBy(ctx, prID)
+	if err != nil {
+		h.log.Errorf("failed to get blocked pull requests: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "blocked", blocked)
+}
+
+func (h *PRHanler) AddReviewer(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+	var req models.PullRequestReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	updatedPR, err := h.service.AddReviewer(ctx, models.PullRequestKey(req.Repository, req.ID), req.UserID)
+	if err != nil {
+		h.log.Errorf("failed to add pull request reviewer: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "pr", updatedPR)
+}
+
+func (h *PRHanler) RemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+	var req models.PullRequestReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	updatedPR, err := h.service.RemoveReviewer(ctx, models.PullRequestKey(req.Repository, req.ID), req.UserID)
+	if err != nil {
+		h.log.Errorf("failed to remove pull request reviewer: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "pr", updatedPR)
+}
+
+func (h *PRHanler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+	var req models.SubmitReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	review, err := h.service.SubmitReview(ctx, &req)
+	if err != nil {
+		h.log.Errorf("failed to submit review: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusCreated, "review", review)
+}
+
+func (h *PRHanler) GetReviews(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		utils.WriteErrResponse(w, utils.NewNotFoundError("resource not found", nil))
+		return
+	}
+	prID = models.PullRequestKey(r.URL.Query().Get("repository"), prID)
+
+	reviews, err := h.service.GetReviews(ctx, prID)
+	if err != nil {
+		h.log.Errorf("failed to get reviews: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "reviews", reviews)
+}
+
+func (h *PRHanler) History(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		utils.WriteErrResponse(w, utils.NewNotFoundError("resource not found", nil))
+		return
+	}
+	prID = models.PullRequestKey(r.URL.Query().Get("repository"), prID)
+
+	events, err := h.service.History(ctx, prID)
+	if err != nil {
+		h.log.Errorf("failed to get pull request history: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "events", events)
+}
+
 func (h *PRHanler) Reassign(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
 	defer cancel()
@@ -71,7 +325,7 @@ func (h *PRHanler) Reassign(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedPR, err := h.service.ReassignPR(ctx, req.ID, req.OldReviewerID)
+	updatedPR, err := h.service.ReassignPR(ctx, models.PullRequestKey(req.Repository, req.ID), req.OldReviewerID)
 	if err != nil {
 		h.log.Errorf("failed to reassign pull request: %v", err)
 		utils.WriteErrResponse(w, err)
@@ -81,11 +335,38 @@ func (h *PRHanler) Reassign(w http.ResponseWriter, r *http.Request) {
 	utils.WriteJsonResponse(w, 200, "", updatedPR)
 }
 
+func (h *PRHanler) PreviewAssignment(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	query := r.URL.Query()
+	req := models.CreatePullRequest{
+		AuthorID:     query.Get("author_id"),
+		Repository:   query.Get("repository"),
+		Labels:       splitQueryList(query.Get("labels")),
+		ChangedFiles: splitQueryList(query.Get("changed_files")),
+		TeamName:     query.Get("team_name"),
+	}
+	if req.AuthorID == "" {
+		utils.WriteErrResponse(w, utils.NewNotFoundError("resource not found", nil))
+		return
+	}
+
+	preview, err := h.service.PreviewAssignment(ctx, &req)
+	if err != nil {
+		h.log.Errorf("failed to preview assignment: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "preview", preview)
+}
+
 func (h *PRHanler) Statistics(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
 	defer cancel()
 
-	pullRequestsQuantiReviewers, err := h.service.Statistics(ctx)
+	pullRequestsQuantiReviewers, err := h.service.Statistics(ctx, r.URL.Query().Get("repository"))
 	if err != nil {
 		h.log.Errorf("failed to reassign pull request: %v", err)
 		utils.WriteErrResponse(w, err)
@@ -93,3 +374,58 @@ func (h *PRHanler) Statistics(w http.ResponseWriter, r *http.Request) {
 
 	utils.WriteJsonResponse(w, 200, "stat", pullRequestsQuantiReviewers)
 }
+
+// parseListFilter parses filters, sorting and page size of PRs list
+func parseListFilter(query url.Values) (*models.PullRequestFilter, error) {
+	filter := &models.PullRequestFilter{
+		Repository: query.Get("repository"),
+		Status:     models.PullRequestStatus(query.Get("status")),
+		AuthorID:   query.Get("author_id"),
+		TeamName:   query.Get("team_name"),
+		ReviewerID: query.Get("reviewer_id"),
+		SortBy:     models.PullRequestSort(query.Get("sort_by")),
+	}
+
+	switch query.Get("order") {
+	case "", "desc":
+		filter.Desc = true
+	case "asc":
+	default:
+		return nil, utils.NewBadRequestError("order must be asc or desc", nil)
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, utils.NewBadRequestError("limit must be a number", nil)
+		}
+		filter.Limit = n
+	}
+
+	times := map[string]**time.Time{
+		"created_from": &filter.CreatedFrom,
+		"created_to":   &filter.CreatedTo,
+		"merged_from":  &filter.MergedFrom,
+		"merged_to":    &filter.MergedTo,
+	}
+	for name, field := range times {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, utils.NewBadRequestError(name+" must be RFC3339 time", nil)
+		}
+		*field = &t
+	}
+	return filter, nil
+}
+
+// splitQueryList parses comma separated query parameter
+func splitQueryList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
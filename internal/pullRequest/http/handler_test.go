@@ -7,8 +7,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/Negat1v9/pr-review-service/config"
 	"github.com/Negat1v9/pr-review-service/internal/models"
 	prservice "github.com/Negat1v9/pr-review-service/internal/pullRequest/service"
 	mock_store "github.com/Negat1v9/pr-review-service/internal/store/mock"
@@ -24,8 +27,24 @@ func TestCreate(t *testing.T) {
 
 	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
 	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockOwnershipRepo := mock_store.NewMockOwnershipRepository(ctrl)
 	mockStore := mock_store.NewMockStore(ctrl)
 
+	author := models.User{UserID: "userID", Username: "author", TeamName: "team-1", IsActive: true}
+	reviewLimit := 2
+	candidates := []models.ReviewCandidate{
+		// reached the limit of open reviews
+		{UserID: "u0", TeamName: "team-1", IsActive: true, OpenReviews: 2, MaxOpenReviews: &reviewLimit},
+		{UserID: "u1", TeamName: "team-1", IsActive: true},
+		{UserID: "u2", TeamName: "team-1", IsActive: true},
+		{UserID: "u3", TeamName: "team-1", IsActive: false},
+		{UserID: "userID", TeamName: "team-1", IsActive: true},
+		// active but on vacation
+		{UserID: "on-vacation", TeamName: "team-1", IsActive: true, Unavailable: true},
+	}
+	settings := models.TeamSettings{TeamName: "team-1", MinReviewers: 0, MaxReviewers: 2, UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer}
+
 	newPR := models.CreatePullRequest{
 		ID:       "pr-1",
 		Name:     "pr-name",
@@ -40,20 +59,1198 @@ func TestCreate(t *testing.T) {
 	}
 	db := sqlx.DB{}
 
-	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+
+	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+	mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+
+	mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	mockStore.EXPECT().OwnershipRepo().Return(mockOwnershipRepo).AnyTimes()
+
+	doReq := func() *httptest.ResponseRecorder {
+		service := prservice.NewPRService(mockStore, newTestSelector(t))
+		handler := NewPRHanlder(logger.NewLogger("local"), service)
+		prMux := PRRouter(handler)
+
+		data, err := json.Marshal(&newPR)
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", "/create", bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		prMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Create success", func(t *testing.T) {
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
+
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&settings, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(candidates, nil).Times(1)
+		mockPRRepo.EXPECT().CreatePullRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockPRRepo.EXPECT().AssignManyReviewers(gomock.Any(), gomock.Any(), "pr-1", []string{"u1", "u2"}).Return(nil).Times(1)
+
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&prResult, nil).Times(1)
+		rr := doReq()
+		require.Equal(t, 201, rr.Code)
+	})
+
+	t.Run("Alredy created", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&prResult, nil).Times(1)
+		rr := doReq()
+		require.Equal(t, 409, rr.Code)
+	})
+
+	t.Run("Author not found", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(nil, sql.ErrNoRows).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+		rr := doReq()
+		require.Equal(t, 404, rr.Code)
+	})
+
+	t.Run("Not enough reviewers", func(t *testing.T) {
+		strict := models.TeamSettings{TeamName: "team-1", MinReviewers: 3, MaxReviewers: 3, UnderstaffedPolicy: models.UnderstaffedPolicyFail}
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&strict, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(candidates, nil).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+
+		rr := doReq()
+		require.Equal(t, 409, rr.Code)
+		r := make(map[string]any, 0)
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, "NOT_ENOUGH_REVIEWERS", r["error"].(map[string]any)["code"])
+	})
+
+	t.Run("Understaffed", func(t *testing.T) {
+		fewer := models.TeamSettings{TeamName: "team-1", MinReviewers: 3, MaxReviewers: 3, UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer}
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&fewer, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(candidates, nil).Times(1)
+		mockPRRepo.EXPECT().CreatePullRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockPRRepo.EXPECT().AssignManyReviewers(gomock.Any(), gomock.Any(), "pr-1", []string{"u1", "u2"}).Return(nil).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+		created := prResult
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&created, nil).Times(1)
+
+		rr := doReq()
+		require.Equal(t, 201, rr.Code)
+		r := make(map[string]any, 0)
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, true, r["pr"].(map[string]any)["understaffed"])
+	})
+
+	t.Run("Fallback team fills missing reviewers", func(t *testing.T) {
+		fallback := models.TeamSettings{
+			TeamName:           "team-1",
+			MinReviewers:       3,
+			MaxReviewers:       4,
+			UnderstaffedPolicy: models.UnderstaffedPolicyFallback,
+			FallbackTeams:      []string{"team-2", "team-3"},
+		}
+		fallbackCandidates := []models.ReviewCandidate{
+			{UserID: "f1", TeamName: "team-2", IsActive: true},
+		}
+		secondFallbackCandidates := []models.ReviewCandidate{
+			{UserID: "s1", TeamName: "team-3", IsActive: false},
+			// author is never a reviewer even in other team
+			{UserID: "userID", TeamName: "team-3", IsActive: true},
+		}
+		understaffedPR := prResult
+		understaffedPR.AssignedReviewers = []string{"f1", "u1", "u2"}
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&fallback, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(candidates, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-2").Return(fallbackCandidates, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-3").Return(secondFallbackCandidates, nil).Times(1)
+		mockPRRepo.EXPECT().CreatePullRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockPRRepo.EXPECT().AssignManyReviewers(gomock.Any(), gomock.Any(), "pr-1", []string{"u1", "u2", "f1"}).Return(nil).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&understaffedPR, nil).Times(1)
+
+		rr := doReq()
+		require.Equal(t, 201, rr.Code)
+		r := make(map[string]any, 0)
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(r["pr"].(map[string]any)["assigned_reviewers"].([]any)))
+		require.Equal(t, nil, r["pr"].(map[string]any)["understaffed"])
+	})
+
+	t.Run("Fallback teams are not used without fallback policy", func(t *testing.T) {
+		// author and inactive member only, min_reviewers is 0
+		assignFewer := models.TeamSettings{
+			TeamName:           "team-1",
+			MaxReviewers:       2,
+			UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer,
+			FallbackTeams:      []string{"team-2"},
+		}
+		smallTeam := []models.ReviewCandidate{
+			{UserID: "userID", TeamName: "team-1", IsActive: true},
+			{UserID: "u3", TeamName: "team-1", IsActive: false},
+		}
+		emptyPR := prResult
+		emptyPR.AssignedReviewers = []string{}
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&assignFewer, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(smallTeam, nil).Times(1)
+		mockPRRepo.EXPECT().CreatePullRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&emptyPR, nil).Times(1)
+
+		rr := doReq()
+		require.Equal(t, 201, rr.Code)
+	})
+
+	t.Run("Fallback policy fills slots up to max reviewers", func(t *testing.T) {
+		// author and inactive member only, min_reviewers is 0
+		defaults := models.TeamSettings{
+			TeamName:           "team-1",
+			MaxReviewers:       2,
+			UnderstaffedPolicy: models.UnderstaffedPolicyFallback,
+			FallbackTeams:      []string{"team-2"},
+		}
+		smallTeam := []models.ReviewCandidate{
+			{UserID: "userID", TeamName: "team-1", IsActive: true},
+			{UserID: "u3", TeamName: "team-1", IsActive: false},
+		}
+		filledPR := prResult
+		filledPR.AssignedReviewers = []string{"f1", "f2"}
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&defaults, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(smallTeam, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-2").Return([]models.ReviewCandidate{
+			{UserID: "f1", TeamName: "team-2", IsActive: true},
+			{UserID: "f2", TeamName: "team-2", IsActive: true},
+		}, nil).Times(1)
+		mockPRRepo.EXPECT().CreatePullRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockPRRepo.EXPECT().AssignManyReviewers(gomock.Any(), gomock.Any(), "pr-1", gomock.InAnyOrder([]string{"f1", "f2"})).Return(nil).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&filledPR, nil).Times(1)
+
+		rr := doReq()
+		require.Equal(t, 201, rr.Code)
+	})
+
+	t.Run("Parent teams help team without reviewers", func(t *testing.T) {
+		child := models.TeamSettings{TeamName: "team-1", MaxReviewers: 2, UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer, ParentTeam: "backend"}
+		// sibling team has no one either, so department members review
+		backendCandidates := []models.ReviewCandidate{
+			{UserID: "u3", TeamName: "team-1", IsActive: false},
+			{UserID: "userID", TeamName: "team-1", IsActive: true},
+		}
+		platformCandidates := slices.Concat(backendCandidates, []models.ReviewCandidate{
+			{UserID: "p1", TeamName: "platform", IsActive: true},
+			{UserID: "p2", TeamName: "payments", IsActive: true},
+		})
+		widenedPR := prResult
+		widenedPR.AssignedReviewers = []string{"p1", "p2"}
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&child, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(backendCandidates, nil).Times(1)
+		mockTeamRepo.EXPECT().GetSubtreeReviewCandidates(gomock.Any(), gomock.Any(), "backend").Return(backendCandidates, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "backend").Return(&models.TeamSettings{TeamName: "backend", ParentTeam: "platform"}, nil).Times(1)
+		mockTeamRepo.EXPECT().GetSubtreeReviewCandidates(gomock.Any(), gomock.Any(), "platform").Return(platformCandidates, nil).Times(1)
+		mockPRRepo.EXPECT().CreatePullRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockPRRepo.EXPECT().AssignManyReviewers(gomock.Any(), gomock.Any(), "pr-1", []string{"p1", "p2"}).Return(nil).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&widenedPR, nil).Times(1)
+
+		rr := doReq()
+		require.Equal(t, 201, rr.Code)
+	})
+
+	t.Run("Code owners are assigned first", func(t *testing.T) {
+		newPR.Repository = "service"
+		newPR.ChangedFiles = []string{"db/migrations/1_init.up.sql", "README.md"}
+		defer func() {
+			newPR.Repository = ""
+			newPR.ChangedFiles = nil
+		}()
+
+		threeReviewers := models.TeamSettings{TeamName: "team-1", MinReviewers: 0, MaxReviewers: 3, UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer}
+		rules := models.Codeowners{
+			Repository: "service",
+			Content:    "* @org/team-2\n/db/ @u2 @dba\n",
+		}
+		owners := []models.ReviewCandidate{
+			{UserID: "u2", TeamName: "team-1", IsActive: true},
+			{UserID: "dba", TeamName: "team-3", IsActive: false},
+		}
+		ownerTeamCandidates := []models.ReviewCandidate{
+			{UserID: "f1", TeamName: "team-2", IsActive: true},
+		}
+
+		// PR of repository without owner team is reviewed by author team
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "service#pr-1").Return(nil, sql.ErrNoRows).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil).Times(1)
+		mockOwnershipRepo.EXPECT().GetRepository(gomock.Any(), gomock.Any(), "service").Return(&models.Repository{Name: "service"}, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&threeReviewers, nil).Times(1)
+		mockOwnershipRepo.EXPECT().GetCodeowners(gomock.Any(), gomock.Any(), "service").Return(&rules, nil).Times(1)
+		mockTeamRepo.EXPECT().GetReviewCandidatesByUserIDs(gomock.Any(), gomock.Any(), []string{"u2", "dba"}).Return(owners, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-2").Return(ownerTeamCandidates, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(candidates, nil).Times(1)
+		mockOwnershipRepo.EXPECT().CreateRepository(gomock.Any(), gomock.Any(), "service").Return(nil).Times(1)
+		mockPRRepo.EXPECT().CreatePullRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		// u2 and team-2 own changed files, u1 fills the remaining slot, inactive dba is skipped
+		mockPRRepo.EXPECT().AssignManyReviewers(gomock.Any(), gomock.Any(), "service#pr-1", []string{"u2", "f1", "u1"}).Return(nil).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+		ownedPR := prResult
+		ownedPR.ID = "service#pr-1"
+		ownedPR.AssignedReviewers = []string{"f1", "u1", "u2"}
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "service#pr-1").Return(&ownedPR, nil).Times(1)
+
+		rr := doReq()
+		require.Equal(t, 201, rr.Code)
+	})
+
+	t.Run("Owner team of repository reviews PR", func(t *testing.T) {
+		newPR.Repository = "search"
+		defer func() { newPR.Repository = "" }()
+
+		ownerSettings := models.TeamSettings{TeamName: "team-2", MinReviewers: 0, MaxReviewers: 1, UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer}
+		ownerCandidates := []models.ReviewCandidate{
+			{UserID: "f1", TeamName: "team-2", IsActive: true},
+		}
+		searchPR := prResult
+		searchPR.ID = "search#pr-1"
+		searchPR.Repository = "search"
+		searchPR.Number = "pr-1"
+		searchPR.AssignedReviewers = []string{"f1"}
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "search#pr-1").Return(nil, sql.ErrNoRows).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil).Times(1)
+		mockOwnershipRepo.EXPECT().GetRepository(gomock.Any(), gomock.Any(), "search").Return(&models.Repository{Name: "search", TeamName: "team-2"}, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-2").Return(&ownerSettings, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-2").Return(ownerCandidates, nil).Times(1)
+		mockOwnershipRepo.EXPECT().CreateRepository(gomock.Any(), gomock.Any(), "search").Return(nil).Times(1)
+		mockPRRepo.EXPECT().CreatePullRequest(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ sqlx.ExtContext, pr *models.PullRequest) error {
+				require.Equal(t, "search#pr-1", pr.ID)
+				require.Equal(t, "search", pr.Repository)
+				require.Equal(t, "pr-1", pr.Number)
+				return nil
+			},
+		).Times(1)
+		mockPRRepo.EXPECT().AssignManyReviewers(gomock.Any(), gomock.Any(), "search#pr-1", []string{"f1"}).Return(nil).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "search#pr-1").Return(&searchPR, nil).Times(1)
+
+		rr := doReq()
+		require.Equal(t, 201, rr.Code)
+		r := make(map[string]any, 0)
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, "search#pr-1", r["pr"].(map[string]any)["pull_request_id"])
+		require.Equal(t, "search", r["pr"].(map[string]any)["repository"])
+	})
+
+	t.Run("Chosen team of author reviews PR", func(t *testing.T) {
+		newPR.TeamName = "go-guild"
+		defer func() { newPR.TeamName = "" }()
+
+		guildSettings := models.TeamSettings{TeamName: "go-guild", MinReviewers: 0, MaxReviewers: 1, UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer}
+		guildCandidates := []models.ReviewCandidate{
+			{UserID: "g1", TeamName: "go-guild", IsActive: false},
+			{UserID: "g2", TeamName: "go-guild", IsActive: true},
+		}
+		guildPR := prResult
+		guildPR.TeamName = "go-guild"
+		guildPR.AssignedReviewers = []string{"g2"}
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
+		mockTeamRepo.EXPECT().GetMembership(gomock.Any(), gomock.Any(), "go-guild", "userID").
+			Return(&models.TeamMembership{UserID: "userID", TeamName: "go-guild", Role: models.MembershipRoleMember, IsActive: true}, nil).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "go-guild").Return(&guildSettings, nil).Times(2)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "go-guild").Return(guildCandidates, nil).Times(1)
+		mockPRRepo.EXPECT().CreatePullRequest(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ sqlx.ExtContext, pr *models.PullRequest) error {
+				require.Equal(t, "go-guild", pr.TeamName)
+				return nil
+			},
+		).Times(1)
+		mockPRRepo.EXPECT().AssignManyReviewers(gomock.Any(), gomock.Any(), "pr-1", []string{"g2"}).Return(nil).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&guildPR, nil).Times(1)
+
+		rr := doReq()
+		require.Equal(t, 201, rr.Code)
+		r := make(map[string]any, 0)
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, "go-guild", r["pr"].(map[string]any)["team_name"])
+	})
+
+	t.Run("Author is not a member of chosen team", func(t *testing.T) {
+		newPR.TeamName = "frontend"
+		defer func() { newPR.TeamName = "" }()
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
+		mockTeamRepo.EXPECT().GetMembership(gomock.Any(), gomock.Any(), "frontend", "userID").Return(nil, sql.ErrNoRows).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+
+		rr := doReq()
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Chosen team is archived", func(t *testing.T) {
+		newPR.TeamName = "go-guild"
+		defer func() { newPR.TeamName = "" }()
+		archivedAt := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
+		mockTeamRepo.EXPECT().GetMembership(gomock.Any(), gomock.Any(), "go-guild", "userID").
+			Return(&models.TeamMembership{UserID: "userID", TeamName: "go-guild", Role: models.MembershipRoleMember, IsActive: true}, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "go-guild").
+			Return(&models.TeamSettings{TeamName: "go-guild", MaxReviewers: 1, ArchivedAt: &archivedAt}, nil).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+
+		rr := doReq()
+		require.Equal(t, http.StatusConflict, rr.Code)
+		r := map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, "TEAM_ARCHIVED", r["error"].(map[string]any)["code"])
+	})
+
+	t.Run("Primary team of author is archived", func(t *testing.T) {
+		archivedAt := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").
+			Return(&models.TeamSettings{TeamName: "team-1", MaxReviewers: 2, ArchivedAt: &archivedAt}, nil).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+
+		rr := doReq()
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("PR id with #", func(t *testing.T) {
+		// would be the same global id as PR 5 of repository api
+		newPR.ID = "api#5"
+		defer func() { newPR.ID = "pr-1" }()
+
+		rr := doReq()
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Reviewers with matching skills are preferred", func(t *testing.T) {
+		newPR.Labels = []string{"SQL", "backend"}
+		defer func() {
+			newPR.Labels = nil
+		}()
+
+		oneReviewer := models.TeamSettings{TeamName: "team-1", MinReviewers: 0, MaxReviewers: 1, UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer}
+		skilled := []models.ReviewCandidate{
+			{UserID: "u1", TeamName: "team-1", IsActive: true, Skills: []string{"frontend"}},
+			{UserID: "u2", TeamName: "team-1", IsActive: true, Skills: []string{"go", "sql"}},
+			{UserID: "userID", TeamName: "team-1", IsActive: true, Skills: []string{"sql"}},
+		}
+		labeledPR := prResult
+		labeledPR.AssignedReviewers = []string{"u2"}
+		labeledPR.Labels = []string{"sql", "backend"}
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&oneReviewer, nil).Times(1)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(skilled, nil).Times(1)
+		mockTeamRepo.EXPECT().GetReviewCandidatesByUserIDs(gomock.Any(), gomock.Any(), []string{"u2"}).Return(skilled[1:2], nil).Times(1)
+		mockPRRepo.EXPECT().CreatePullRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockPRRepo.EXPECT().SetPullRequestLabels(gomock.Any(), gomock.Any(), "pr-1", []string{"sql", "backend"}).Return(nil).Times(1)
+		mockPRRepo.EXPECT().AssignManyReviewers(gomock.Any(), gomock.Any(), "pr-1", []string{"u2"}).Return(nil).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&labeledPR, nil).Times(1)
+
+		rr := doReq()
+		require.Equal(t, 201, rr.Code)
+		r := make(map[string]any, 0)
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"u2": "sql"}, r["pr"].(map[string]any)["matched_labels"])
+	})
+}
+
+func TestSetLabels(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+	mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+
+	doReq := func(body any) *httptest.ResponseRecorder {
+		service := prservice.NewPRService(mockStore, newTestSelector(t))
+		handler := NewPRHanlder(logger.NewLogger("local"), service)
+		prMux := PRRouter(handler)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", "/setLabels", bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		prMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	openPR := models.PullRequest{ID: "pr-1", Name: "pr-name", AuthorID: "u1", Status: models.PullRequestStatusOpen}
+
+	t.Run("Set labels success", func(t *testing.T) {
+		labeledPR := openPR
+		labeledPR.Labels = []string{"go"}
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&openPR, nil).Times(1)
+		mockPRRepo.EXPECT().SetPullRequestLabels(gomock.Any(), gomock.Any(), "pr-1", []string{"go"}).Return(nil).Times(1)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&labeledPR, nil).Times(1)
+
+		rr := doReq(models.SetPullRequestLabelsRequest{ID: "pr-1", Labels: []string{"Go"}})
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Merged PR", func(t *testing.T) {
+		mergedPR := openPR
+		mergedPR.Status = models.PullRequestStatusMerged
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&mergedPR, nil).Times(1)
+
+		rr := doReq(models.SetPullRequestLabelsRequest{ID: "pr-1", Labels: []string{"go"}})
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("PR not found", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-2").Return(nil, sql.ErrNoRows).Times(1)
+
+		rr := doReq(models.SetPullRequestLabelsRequest{ID: "pr-2"})
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestDependencies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+	mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockPRRepo.EXPECT().LockDependencies(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+
+	doReq := func(method, path string, body any) *httptest.ResponseRecorder {
+		service := prservice.NewPRService(mockStore, newTestSelector(t))
+		handler := NewPRHanlder(logger.NewLogger("local"), service)
+		prMux := PRRouter(handler)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest(method, path, bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		prMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	pr1 := models.PullRequest{ID: "pr-1", Name: "base", AuthorID: "u1", Status: models.PullRequestStatusOpen}
+	pr2 := models.PullRequest{ID: "pr-2", Name: "stacked", AuthorID: "u1", Status: models.PullRequestStatusOpen}
+
+	t.Run("Set dependencies success", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-2").Return(&pr2, nil).Times(2)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&pr1, nil).Times(1)
+		mockPRRepo.EXPECT().HasDependencyPath(gomock.Any(), gomock.Any(), []string{"pr-1"}, "pr-2").Return(false, nil).Times(1)
+		mockPRRepo.EXPECT().SetPullRequestDependencies(gomock.Any(), gomock.Any(), "pr-2", []string{"pr-1"}).Return(nil).Times(1)
+		mockPRRepo.EXPECT().GetDependencyGraph(gomock.Any(), gomock.Any(), "pr-2").Return([]models.PullRequestDependency{
+			{PullRequestID: "pr-2", DependsOnID: "pr-1", Status: models.PullRequestStatusOpen},
+		}, nil).Times(1)
+
+		// duplicates are dropped
+		rr := doReq("POST", "/setDependencies", models.SetPullRequestDependenciesRequest{ID: "pr-2", DependsOn: []string{"pr-1", "pr-1"}})
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Len(t, r["pr"].(map[string]any)["dependencies"], 1)
+	})
+
+	t.Run("Dependency cycle", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&pr1, nil).Times(1)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-2").Return(&pr2, nil).Times(1)
+		mockPRRepo.EXPECT().HasDependencyPath(gomock.Any(), gomock.Any(), []string{"pr-2"}, "pr-1").Return(true, nil).Times(1)
+
+		rr := doReq("POST", "/setDependencies", models.SetPullRequestDependenciesRequest{ID: "pr-1", DependsOn: []string{"pr-2"}})
+		require.Equal(t, http.StatusConflict, rr.Code)
+		r := map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, "DEPENDENCY_CYCLE", r["error"].(map[string]any)["code"])
+	})
+
+	t.Run("Depends on itself", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&pr1, nil).Times(1)
+
+		rr := doReq("POST", "/setDependencies", models.SetPullRequestDependenciesRequest{ID: "pr-1", DependsOn: []string{"pr-1"}})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Unknown dependency", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-2").Return(&pr2, nil).Times(1)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-404").Return(nil, sql.ErrNoRows).Times(1)
+
+		rr := doReq("POST", "/setDependencies", models.SetPullRequestDependenciesRequest{ID: "pr-2", DependsOn: []string{"pr-404"}})
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Merged PR", func(t *testing.T) {
+		mergedPR := pr2
+		mergedPR.Status = models.PullRequestStatusMerged
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-2").Return(&mergedPR, nil).Times(1)
+
+		rr := doReq("POST", "/setDependencies", models.SetPullRequestDependenciesRequest{ID: "pr-2", DependsOn: []string{"pr-1"}})
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Blocked PRs", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&pr1, nil).Times(1)
+		mockPRRepo.EXPECT().GetBlockedPullRequests(gomock.Any(), gomock.Any(), "pr-1").Return([]models.PullRequest{pr2}, nil).Times(1)
+
+		rr := doReq("GET", "/blocked?pull_request_id=pr-1", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		blocked := r["blocked"].([]any)
+		require.Len(t, blocked, 1)
+		require.Equal(t, "pr-2", blocked[0].(map[string]any)["pull_request_id"])
+	})
+
+	t.Run("Merged PR blocks nothing", func(t *testing.T) {
+		mergedPR := pr1
+		mergedPR.Status = models.PullRequestStatusMerged
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&mergedPR, nil).Times(1)
+
+		rr := doReq("GET", "/blocked?pull_request_id=pr-1", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Empty(t, r["blocked"])
+	})
+}
+
+func TestAddRemoveReviewer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+	mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+	mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	doReq := func(path string, body any) *httptest.ResponseRecorder {
+		service := prservice.NewPRService(mockStore, newTestSelector(t))
+		handler := NewPRHanlder(logger.NewLogger("local"), service)
+		prMux := PRRouter(handler)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", path, bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		prMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	author := models.User{UserID: "u1", TeamName: "team-1", IsActive: true}
+	settings := models.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: 2, UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer}
+	openPR := models.PullRequest{ID: "pr-1", AuthorID: "u1", Status: models.PullRequestStatusOpen, AssignedReviewers: []string{"u2"}}
+	reviewLimit := 1
+
+	t.Run("Add reviewer", func(t *testing.T) {
+		updatedPR := openPR
+		updatedPR.AssignedReviewers = []string{"u2", "u3"}
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&openPR, nil)
+		mockTeamRepo.EXPECT().GetReviewCandidatesByUserIDs(gomock.Any(), gomock.Any(), []string{"u3"}).
+			Return([]models.ReviewCandidate{{UserID: "u3", TeamName: "team-2", IsActive: true}}, nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&author, nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&settings, nil)
+		mockPRRepo.EXPECT().AssignReviewer(gomock.Any(), gomock.Any(), "pr-1", "u3").Return(nil)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&updatedPR, nil)
+
+		rr := doReq("/addReviewer", models.PullRequestReviewerRequest{ID: "pr-1", UserID: "u3"})
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, []any{"u2", "u3"}, r["pr"].(map[string]any)["assigned_reviewers"])
+	})
+
+	t.Run("Add author", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&openPR, nil)
+
+		rr := doReq("/addReviewer", models.PullRequestReviewerRequest{ID: "pr-1", UserID: "u1"})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Add already assigned", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&openPR, nil)
+
+		rr := doReq("/addReviewer", models.PullRequestReviewerRequest{ID: "pr-1", UserID: "u2"})
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Add unknown user", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&openPR, nil)
+		mockTeamRepo.EXPECT().GetReviewCandidatesByUserIDs(gomock.Any(), gomock.Any(), []string{"x"}).Return([]models.ReviewCandidate{}, nil)
+
+		rr := doReq("/addReviewer", models.PullRequestReviewerRequest{ID: "pr-1", UserID: "x"})
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Add user over limit", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&openPR, nil)
+		mockTeamRepo.EXPECT().GetReviewCandidatesByUserIDs(gomock.Any(), gomock.Any(), []string{"u3"}).
+			Return([]models.ReviewCandidate{{UserID: "u3", IsActive: true, OpenReviews: 1, MaxOpenReviews: &reviewLimit}}, nil)
+
+		rr := doReq("/addReviewer", models.PullRequestReviewerRequest{ID: "pr-1", UserID: "u3"})
+		require.Equal(t, http.StatusConflict, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, "REVIEW_LIMIT_REACHED", r["error"].(map[string]any)["code"])
+	})
+
+	t.Run("Add over team maximum", func(t *testing.T) {
+		fullPR := openPR
+		fullPR.AssignedReviewers = []string{"u2", "u4"}
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&fullPR, nil)
+		mockTeamRepo.EXPECT().GetReviewCandidatesByUserIDs(gomock.Any(), gomock.Any(), []string{"u3"}).
+			Return([]models.ReviewCandidate{{UserID: "u3", IsActive: true}}, nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&author, nil).Times(2)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&settings, nil)
+		mockTeamRepo.EXPECT().GetMergePolicy(gomock.Any(), gomock.Any(), "team-1").Return(&models.MergePolicy{TeamName: "team-1"}, nil)
+
+		rr := doReq("/addReviewer", models.PullRequestReviewerRequest{ID: "pr-1", UserID: "u3"})
+		require.Equal(t, http.StatusConflict, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, "TOO_MANY_REVIEWERS", r["error"].(map[string]any)["code"])
+	})
+
+	t.Run("Add required approver over team maximum", func(t *testing.T) {
+		fullPR := openPR
+		fullPR.AssignedReviewers = []string{"u2", "u4"}
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&fullPR, nil)
+		mockTeamRepo.EXPECT().GetReviewCandidatesByUserIDs(gomock.Any(), gomock.Any(), []string{"lead"}).
+			Return([]models.ReviewCandidate{{UserID: "lead", IsActive: true}}, nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&author, nil).Times(2)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&settings, nil)
+		mockTeamRepo.EXPECT().GetMergePolicy(gomock.Any(), gomock.Any(), "team-1").
+			Return(&models.MergePolicy{TeamName: "team-1", RequiredApprovers: []string{"lead"}}, nil)
+		mockPRRepo.EXPECT().AssignReviewer(gomock.Any(), gomock.Any(), "pr-1", "lead").Return(nil)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&fullPR, nil)
+
+		rr := doReq("/addReviewer", models.PullRequestReviewerRequest{ID: "pr-1", UserID: "lead"})
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Add to merged PR", func(t *testing.T) {
+		mergedPR := openPR
+		mergedPR.Status = models.PullRequestStatusMerged
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&mergedPR, nil)
+
+		rr := doReq("/addReviewer", models.PullRequestReviewerRequest{ID: "pr-1", UserID: "u3"})
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Remove reviewer", func(t *testing.T) {
+		updatedPR := openPR
+		updatedPR.AssignedReviewers = []string{}
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&openPR, nil)
+		mockPRRepo.EXPECT().DeleteAssignedReviewer(gomock.Any(), gomock.Any(), "pr-1", "u2").Return(nil)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&updatedPR, nil)
+
+		rr := doReq("/removeReviewer", models.PullRequestReviewerRequest{ID: "pr-1", UserID: "u2"})
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Remove not assigned", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&openPR, nil)
+		mockPRRepo.EXPECT().DeleteAssignedReviewer(gomock.Any(), gomock.Any(), "pr-1", "u3").Return(sql.ErrNoRows)
+
+		rr := doReq("/removeReviewer", models.PullRequestReviewerRequest{ID: "pr-1", UserID: "u3"})
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Remove from unknown PR", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-2").Return(nil, sql.ErrNoRows)
+
+		rr := doReq("/removeReviewer", models.PullRequestReviewerRequest{ID: "pr-2", UserID: "u2"})
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+	mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+	mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	doReq := func(method, path string, body any) *httptest.ResponseRecorder {
+		service := prservice.NewPRService(mockStore, newTestSelector(t))
+		handler := NewPRHanlder(logger.NewLogger("local"), service)
+		prMux := PRRouter(handler)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest(method, path, bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		prMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	openPR := models.PullRequest{ID: "pr-1", AuthorID: "u1", Status: models.PullRequestStatusOpen, AssignedReviewers: []string{"u2"}}
+	author := models.User{UserID: "u1", TeamName: "team-1", IsActive: true}
+
+	t.Run("Submit review", func(t *testing.T) {
+		req := models.SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "u2", State: models.ReviewStateApproved, Body: "lgtm"}
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&openPR, nil)
+		mockPRRepo.EXPECT().CreateReview(gomock.Any(), gomock.Any(), &models.Review{
+			PullRequestID: "pr-1", ReviewerID: "u2", State: models.ReviewStateApproved, Body: "lgtm",
+		}).Return(&models.Review{ID: 1, PullRequestID: "pr-1", ReviewerID: "u2", State: models.ReviewStateApproved, Body: "lgtm"}, nil)
+
+		rr := doReq("POST", "/review", req)
+		require.Equal(t, http.StatusCreated, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, "APPROVED", r["review"].(map[string]any)["state"])
+	})
+
+	t.Run("Unknown state", func(t *testing.T) {
+		rr := doReq("POST", "/review", models.SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "u2", State: "PENDING"})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Not a reviewer", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&openPR, nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&author, nil)
+		mockTeamRepo.EXPECT().GetMergePolicy(gomock.Any(), gomock.Any(), "team-1").Return(&models.MergePolicy{TeamName: "team-1"}, nil)
+
+		rr := doReq("POST", "/review", models.SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "u3", State: models.ReviewStateCommented})
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Required approver is assigned by review", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&openPR, nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&author, nil)
+		mockTeamRepo.EXPECT().GetMergePolicy(gomock.Any(), gomock.Any(), "team-1").
+			Return(&models.MergePolicy{TeamName: "team-1", RequiredApprovers: []string{"lead"}}, nil)
+		mockPRRepo.EXPECT().AssignReviewer(gomock.Any(), gomock.Any(), "pr-1", "lead").Return(nil)
+		mockPRRepo.EXPECT().CreateReview(gomock.Any(), gomock.Any(), &models.Review{
+			PullRequestID: "pr-1", ReviewerID: "lead", State: models.ReviewStateApproved,
+		}).Return(&models.Review{ID: 2, PullRequestID: "pr-1", ReviewerID: "lead", State: models.ReviewStateApproved}, nil)
+
+		rr := doReq("POST", "/review", models.SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "lead", State: models.ReviewStateApproved})
+		require.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("Merged PR", func(t *testing.T) {
+		mergedPR := openPR
+		mergedPR.Status = models.PullRequestStatusMerged
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&mergedPR, nil)
+
+		rr := doReq("POST", "/review", models.SubmitReviewRequest{PullRequestID: "pr-1", ReviewerID: "u2", State: models.ReviewStateApproved})
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Review history", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&openPR, nil)
+		mockPRRepo.EXPECT().GetPullRequestReviews(gomock.Any(), gomock.Any(), "pr-1").Return([]models.Review{
+			{ID: 1, PullRequestID: "pr-1", ReviewerID: "u2", State: models.ReviewStateChangesRequested},
+			{ID: 2, PullRequestID: "pr-1", ReviewerID: "u2", State: models.ReviewStateApproved},
+		}, nil)
+
+		rr := doReq("GET", "/reviews?pull_request_id=pr-1", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(r["reviews"].([]any)))
+	})
+}
+
+func TestHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+
+	doReq := func(path string) *httptest.ResponseRecorder {
+		service := prservice.NewPRService(mockStore, newTestSelector(t))
+		handler := NewPRHanlder(logger.NewLogger("local"), service)
+		prMux := PRRouter(handler)
+
+		req, err := http.NewRequest("GET", path, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		prMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Timeline", func(t *testing.T) {
+		events := []models.PullRequestEvent{
+			{ID: 1, PullRequestID: "pr-1", Type: models.PullRequestEventCreated, Actor: "u1", Payload: []byte(`{"pull_request_name":"search","author_id":"u1","status":"OPEN"}`)},
+			{ID: 2, PullRequestID: "pr-1", Type: models.PullRequestEventReviewerAssigned, Actor: "u1", Payload: []byte(`{"reviewer_id":"u2","reason":"AUTO"}`)},
+			{ID: 3, PullRequestID: "pr-1", Type: models.PullRequestEventReviewerReplaced, Actor: "lead", Payload: []byte(`{"old_reviewer_id":"u2","new_reviewer_id":"u3","reason":"MANUAL"}`)},
+		}
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&models.PullRequest{ID: "pr-1"}, nil)
+		mockPRRepo.EXPECT().GetPullRequestEvents(gomock.Any(), gomock.Any(), "pr-1").Return(events, nil)
+
+		rr := doReq("/history?pull_request_id=pr-1")
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string][]map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Len(t, r["events"], 3)
+		require.Equal(t, "REVIEWER_REPLACED", r["events"][2]["type"])
+		require.Equal(t, "u3", r["events"][2]["payload"].(map[string]any)["new_reviewer_id"])
+	})
+
+	t.Run("Unknown PR", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-404").Return(nil, sql.ErrNoRows)
+
+		rr := doReq("/history?pull_request_id=pr-404")
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestMerge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+
+	newPR := models.MergePullRequest{
+		ID: "pr-1",
+	}
+	prResult := models.PullRequest{
+		ID:                "pr-1",
+		Name:              "pr-name",
+		AuthorID:          "userID",
+		Status:            models.PullRequestStatusOpen,
+		AssignedReviewers: []string{"u1", "u2"},
+		Reviewers: []models.ReviewerState{
+			{UserID: "u1", State: models.ReviewStateApproved},
+			{UserID: "u2", State: models.ReviewStateChangesRequested},
+		},
+	}
+	author := models.User{UserID: "userID", TeamName: "team-1", IsActive: true}
+	db := sqlx.DB{}
+
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+
+	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+	mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+
+	mockPRRepo.EXPECT().LockDependencies(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+
+	doReq := func() *httptest.ResponseRecorder {
+		service := prservice.NewPRService(mockStore, newTestSelector(t))
+		handler := NewPRHanlder(logger.NewLogger("local"), service)
+		prMux := PRRouter(handler)
+
+		data, err := json.Marshal(&newPR)
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", "/merge", bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		prMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Merge success", func(t *testing.T) {
+		mergedPR := models.PullRequest{
+			ID:       "pr-1",
+			Name:     "pr-name",
+			AuthorID: "userID",
+			Status:   models.PullRequestStatusMerged,
+		}
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&prResult, nil).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil).Times(1)
+		mockTeamRepo.EXPECT().GetMergePolicy(gomock.Any(), gomock.Any(), "team-1").
+			Return(&models.MergePolicy{TeamName: "team-1", MinApprovals: 1}, nil).Times(1)
+
+		mockPRRepo.EXPECT().GetUnmergedDependencies(gomock.Any(), gomock.Any(), "pr-1").Return([]string{}, nil).Times(1)
+		mockPRRepo.EXPECT().MergePullRequest(gomock.Any(), gomock.Any(), "pr-1", false).Return(nil).Times(1)
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&mergedPR, nil).Times(1)
+
+		rr := doReq()
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, 200, rr.Code)
+		require.Equal(t, "pr-1", r["pr"].(map[string]any)["pull_request_id"])
+		require.Equal(t, "MERGED", r["pr"].(map[string]any)["status"])
+	})
+
+	t.Run("Merge blocked by policy", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&prResult, nil).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil).Times(1)
+		mockTeamRepo.EXPECT().GetMergePolicy(gomock.Any(), gomock.Any(), "team-1").Return(&models.MergePolicy{
+			TeamName:                "team-1",
+			MinApprovals:            2,
+			BlockOnChangesRequested: true,
+			RequiredApprovers:       []string{"u1", "lead"},
+		}, nil).Times(1)
+
+		rr := doReq()
+		require.Equal(t, http.StatusConflict, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, "MERGE_BLOCKED", r["error"].(map[string]any)["code"])
+
+		conditions := []any{}
+		for _, d := range r["error"].(map[string]any)["details"].([]any) {
+			conditions = append(conditions, d.(map[string]any)["condition"])
+		}
+		require.Equal(t, []any{"CHANGES_REQUESTED", "MIN_APPROVALS", "REQUIRED_APPROVER"}, conditions)
+	})
+
+	t.Run("Merge with override", func(t *testing.T) {
+		mergedPR := prResult
+		mergedPR.Status = models.PullRequestStatusMerged
+		mergedPR.MergeOverride = true
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&prResult, nil).Times(1)
+		mockPRRepo.EXPECT().GetUnmergedDependencies(gomock.Any(), gomock.Any(), "pr-1").Return([]string{}, nil).Times(1)
+		mockPRRepo.EXPECT().MergePullRequest(gomock.Any(), gomock.Any(), "pr-1", true).Return(nil).Times(1)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&mergedPR, nil).Times(1)
+
+		newPR.Override = true
+		defer func() { newPR.Override = false }()
+
+		rr := doReq()
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, true, r["pr"].(map[string]any)["merge_override"])
+	})
+
+	t.Run("Merge blocked by dependencies", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&prResult, nil).Times(1)
+		mockPRRepo.EXPECT().GetUnmergedDependencies(gomock.Any(), gomock.Any(), "pr-1").Return([]string{"pr-0"}, nil).Times(1)
+
+		// override does not bypass dependencies
+		newPR.Override = true
+		defer func() { newPR.Override = false }()
+
+		rr := doReq()
+		require.Equal(t, http.StatusConflict, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, "DEPENDENCY_NOT_MERGED", r["error"].(map[string]any)["code"])
+		require.Equal(t, []any{"pr-0"}, r["error"].(map[string]any)["details"])
+	})
+
+	t.Run("PR not found", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
+
+		rr := doReq()
+		require.Equal(t, 404, rr.Code)
+	})
+
+	t.Run("PR already merged", func(t *testing.T) {
+		mergedPR := models.PullRequest{
+			ID:       "pr-1",
+			Name:     "pr-name",
+			AuthorID: "userID",
+			Status:   models.PullRequestStatusMerged,
+		}
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&mergedPR, nil).Times(1)
+
+		rr := doReq()
+		require.Equal(t, 200, rr.Code)
+	})
+}
 
-	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+func TestGetList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
 
-	doReq := func() *httptest.ResponseRecorder {
-		service := prservice.NewPRService(mockStore)
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+
+	doReq := func(path string) *httptest.ResponseRecorder {
+		service := prservice.NewPRService(mockStore, newTestSelector(t))
 		handler := NewPRHanlder(logger.NewLogger("local"), service)
 		prMux := PRRouter(handler)
 
-		data, err := json.Marshal(&newPR)
-		require.NoError(t, err)
-		req, err := http.NewRequest("POST", "/create", bytes.NewBuffer(data))
+		req, err := http.NewRequest("GET", path, nil)
 		require.NoError(t, err)
 
 		rr := httptest.NewRecorder()
@@ -62,80 +1259,149 @@ func TestCreate(t *testing.T) {
 		return rr
 	}
 
-	t.Run("Create success", func(t *testing.T) {
+	createdAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	prs := []models.PullRequest{
+		{ID: "pr-3", AuthorID: "u1", Status: models.PullRequestStatusOpen, CreatedAt: createdAt.Add(2 * time.Hour), AssignedReviewers: []string{"u2"}},
+		{ID: "pr-2", AuthorID: "u1", Status: models.PullRequestStatusOpen, CreatedAt: createdAt.Add(time.Hour), AssignedReviewers: []string{}},
+		{ID: "pr-1", AuthorID: "u1", Status: models.PullRequestStatusOpen, CreatedAt: createdAt, AssignedReviewers: []string{"u3"}},
+	}
 
-		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
+	t.Run("Get PR", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&prs[2], nil)
+		mockPRRepo.EXPECT().GetDependencyGraph(gomock.Any(), gomock.Any(), "pr-1").Return([]models.PullRequestDependency{
+			{PullRequestID: "pr-1", DependsOnID: "pr-0", Status: models.PullRequestStatusOpen},
+		}, nil)
 
-		mockTeamRepo.EXPECT().GetUsersIDFromUserTeam(gomock.Any(), gomock.Any(), "userID", 2).Return([]string{"u1", "u2"}, nil).Times(1)
-		mockPRRepo.EXPECT().CreatePullRequest(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
-		mockPRRepo.EXPECT().AssignManyReviewers(gomock.Any(), gomock.Any(), "pr-1", []string{"u1", "u2"}).Return(nil).Times(1)
+		rr := doReq("/get?pull_request_id=pr-1")
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, "pr-1", r["pr"].(map[string]any)["pull_request_id"])
+		dependencies := r["pr"].(map[string]any)["dependencies"].([]any)
+		require.Len(t, dependencies, 1)
+		require.Equal(t, "pr-0", dependencies[0].(map[string]any)["depends_on"])
+	})
 
-		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
-			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
-				return fn(ctx, &db)
-			},
-		).Times(1)
+	t.Run("Get PR of repository", func(t *testing.T) {
+		searchPR := models.PullRequest{ID: "search#pr-1", Repository: "search", Number: "pr-1", AuthorID: "u1", Status: models.PullRequestStatusOpen}
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "search#pr-1").Return(&searchPR, nil)
+		mockPRRepo.EXPECT().GetDependencyGraph(gomock.Any(), gomock.Any(), "search#pr-1").Return([]models.PullRequestDependency{}, nil)
 
-		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&prResult, nil).Times(1)
-		rr := doReq()
-		require.Equal(t, 201, rr.Code)
+		rr := doReq("/get?pull_request_id=pr-1&repository=search")
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, "pr-1", r["pr"].(map[string]any)["number"])
 	})
 
-	t.Run("Alredy created", func(t *testing.T) {
-		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&prResult, nil).Times(1)
-		rr := doReq()
-		require.Equal(t, 409, rr.Code)
+	t.Run("Get unknown PR", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-404").Return(nil, sql.ErrNoRows)
+
+		rr := doReq("/get?pull_request_id=pr-404")
+		require.Equal(t, http.StatusNotFound, rr.Code)
 	})
 
-	t.Run("Author not found", func(t *testing.T) {
-		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
-		mockTeamRepo.EXPECT().GetUsersIDFromUserTeam(gomock.Any(), gomock.Any(), "userID", 2).Return([]string{}, sql.ErrNoRows).Times(1)
-		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
-			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
-				return fn(ctx, &db)
+	t.Run("List pages", func(t *testing.T) {
+		mockPRRepo.EXPECT().ListPullRequests(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ sqlx.ExtContext, filter *models.PullRequestFilter) ([]models.PullRequest, error) {
+				require.Equal(t, models.PullRequestStatusOpen, filter.Status)
+				require.Equal(t, "u1", filter.AuthorID)
+				require.Equal(t, models.PullRequestSortCreatedAt, filter.SortBy)
+				require.True(t, filter.Desc)
+				require.Nil(t, filter.After)
+				// one more than page size
+				require.Equal(t, 3, filter.Limit)
+				return prs, nil
 			},
-		).Times(1)
-		rr := doReq()
-		require.Equal(t, 404, rr.Code)
+		)
+
+		rr := doReq("/list?status=OPEN&author_id=u1&limit=2")
+		require.Equal(t, http.StatusOK, rr.Code)
+		page := struct {
+			PullRequests []models.PullRequest `json:"pull_requests"`
+			NextCursor   string               `json:"next_cursor"`
+		}{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+		require.Len(t, page.PullRequests, 2)
+		require.NotEmpty(t, page.NextCursor)
+
+		mockPRRepo.EXPECT().ListPullRequests(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ sqlx.ExtContext, filter *models.PullRequestFilter) ([]models.PullRequest, error) {
+				require.NotNil(t, filter.After)
+				require.Equal(t, "pr-2", filter.After.ID)
+				require.Equal(t, createdAt.Add(time.Hour).Format(time.RFC3339Nano), filter.After.Value)
+				return prs[2:], nil
+			},
+		)
+
+		rr = doReq("/list?status=OPEN&author_id=u1&limit=2&cursor=" + page.NextCursor)
+		require.Equal(t, http.StatusOK, rr.Code)
+		page.NextCursor = ""
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+		require.Len(t, page.PullRequests, 1)
+		require.Empty(t, page.NextCursor)
+	})
+
+	t.Run("Cursor of other sorting", func(t *testing.T) {
+		mockPRRepo.EXPECT().ListPullRequests(gomock.Any(), gomock.Any(), gomock.Any()).Return(prs, nil)
+
+		rr := doReq("/list?limit=2")
+		page := map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+
+		rr = doReq("/list?limit=2&order=asc&cursor=" + page["next_cursor"].(string))
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Invalid filters", func(t *testing.T) {
+		for _, query := range []string{
+			"status=UNKNOWN",
+			"sort_by=author_id",
+			"order=up",
+			"limit=0x",
+			"limit=1000",
+			"created_from=yesterday",
+			"merged_from=2025-10-02T00:00:00Z&merged_to=2025-10-01T00:00:00Z",
+			"cursor=not-a-cursor",
+		} {
+			rr := doReq("/list?" + query)
+			require.Equal(t, http.StatusBadRequest, rr.Code, query)
+		}
 	})
 }
 
-func TestMerge(t *testing.T) {
+func TestStatusTransitions(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
 	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
 	mockStore := mock_store.NewMockStore(ctrl)
 
-	newPR := models.CreatePullRequest{
-		ID:       "pr-1",
-		Name:     "pr-name",
-		AuthorID: "userID",
-	}
-	prResult := models.PullRequest{
-		ID:                "pr-1",
-		Name:              "pr-name",
-		AuthorID:          "userID",
-		Status:            models.PullRequestStatusOpen,
-		AssignedReviewers: []string{"u1", "u2"},
-	}
 	db := sqlx.DB{}
-
 	mockStore.EXPECT().DB().Return(&db).AnyTimes()
-
 	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
-
+	mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+	mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockPRRepo.EXPECT().LockDependencies(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
-	doReq := func() *httptest.ResponseRecorder {
-		service := prservice.NewPRService(mockStore)
+	doReq := func(path string, body any) *httptest.ResponseRecorder {
+		service := prservice.NewPRService(mockStore, newTestSelector(t))
 		handler := NewPRHanlder(logger.NewLogger("local"), service)
 		prMux := PRRouter(handler)
 
-		data, err := json.Marshal(&newPR)
+		data, err := json.Marshal(body)
 		require.NoError(t, err)
-		req, err := http.NewRequest("POST", "/merge", bytes.NewBuffer(data))
+		req, err := http.NewRequest("POST", path, bytes.NewBuffer(data))
 		require.NoError(t, err)
 
 		rr := httptest.NewRecorder()
@@ -143,47 +1409,133 @@ func TestMerge(t *testing.T) {
 		prMux.ServeHTTP(rr, req)
 		return rr
 	}
+	errorCode := func(rr *httptest.ResponseRecorder) any {
+		r := map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		return r["error"].(map[string]any)["code"]
+	}
 
-	t.Run("Merge success", func(t *testing.T) {
-		mergedPR := models.PullRequest{
-			ID:       "pr-1",
-			Name:     "pr-name",
-			AuthorID: "userID",
-			Status:   models.PullRequestStatusMerged,
-		}
-		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&prResult, nil).Times(1)
-
-		mockPRRepo.EXPECT().MergePullRequest(gomock.Any(), gomock.Any(), "pr-1").Return(nil).Times(1)
+	author := models.User{UserID: "u1", TeamName: "team-1", IsActive: true}
+	settings := models.TeamSettings{TeamName: "team-1", MinReviewers: 1, MaxReviewers: 2, UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer}
+	candidates := []models.ReviewCandidate{
+		{UserID: "u1", TeamName: "team-1", IsActive: true},
+		{UserID: "u2", TeamName: "team-1", IsActive: true},
+		{UserID: "u3", TeamName: "team-1", IsActive: true},
+	}
+	draftPR := models.PullRequest{ID: "pr-1", AuthorID: "u1", Status: models.PullRequestStatusDraft, AssignedReviewers: []string{}}
+	openPR := models.PullRequest{ID: "pr-1", AuthorID: "u1", Status: models.PullRequestStatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+	closedPR := openPR
+	closedPR.Status = models.PullRequestStatusClosed
+
+	t.Run("Create draft without reviewers", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&author, nil)
+		mockPRRepo.EXPECT().CreatePullRequest(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ sqlx.ExtContext, pr *models.PullRequest) error {
+				require.Equal(t, models.PullRequestStatusDraft, pr.Status)
+				return nil
+			},
+		)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&draftPR, nil)
 
-		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&mergedPR, nil).Times(1)
+		rr := doReq("/create", models.CreatePullRequest{ID: "pr-1", Name: "pr-name", AuthorID: "u1", Draft: true})
+		require.Equal(t, http.StatusCreated, rr.Code)
+	})
 
-		rr := doReq()
+	t.Run("Ready for review assigns reviewers", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&draftPR, nil)
+		mockPRRepo.EXPECT().UpdatePullRequestStatus(gomock.Any(), gomock.Any(), "pr-1", models.PullRequestStatusDraft, models.PullRequestStatusOpen).Return(nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&author, nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&settings, nil)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(candidates, nil)
+		mockPRRepo.EXPECT().AssignManyReviewers(gomock.Any(), gomock.Any(), "pr-1", []string{"u2", "u3"}).Return(nil)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&openPR, nil)
+
+		rr := doReq("/ready", models.ReadyPullRequest{ID: "pr-1"})
+		require.Equal(t, http.StatusOK, rr.Code)
 		r := map[string]any{}
-		err := json.Unmarshal(rr.Body.Bytes(), &r)
-		require.NoError(t, err)
-		require.Equal(t, 200, rr.Code)
-		require.Equal(t, "pr-1", r["pr"].(map[string]any)["pull_request_id"])
-		require.Equal(t, "MERGED", r["pr"].(map[string]any)["status"])
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, "OPEN", r["pr"].(map[string]any)["status"])
 	})
 
-	t.Run("PR not found", func(t *testing.T) {
-		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
+	t.Run("Ready for review of open PR", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&openPR, nil)
 
-		rr := doReq()
-		require.Equal(t, 404, rr.Code)
+		rr := doReq("/ready", models.ReadyPullRequest{ID: "pr-1"})
+		require.Equal(t, http.StatusConflict, rr.Code)
+		require.Equal(t, "INVALID_STATUS_TRANSITION", errorCode(rr))
 	})
 
-	t.Run("PR already merged", func(t *testing.T) {
-		mergedPR := models.PullRequest{
-			ID:       "pr-1",
-			Name:     "pr-name",
-			AuthorID: "userID",
-			Status:   models.PullRequestStatusMerged,
-		}
-		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&mergedPR, nil).Times(1)
+	t.Run("Convert to draft unassigns reviewers", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&openPR, nil)
+		mockPRRepo.EXPECT().UpdatePullRequestStatus(gomock.Any(), gomock.Any(), "pr-1", models.PullRequestStatusOpen, models.PullRequestStatusDraft).Return(nil)
+		mockPRRepo.EXPECT().DeleteAssignedByPullRequestID(gomock.Any(), gomock.Any(), "pr-1").Return(nil)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&draftPR, nil)
 
-		rr := doReq()
-		require.Equal(t, 200, rr.Code)
+		rr := doReq("/draft", models.ChangePullRequestStatus{ID: "pr-1"})
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Close draft", func(t *testing.T) {
+		closedDraft := draftPR
+		closedDraft.Status = models.PullRequestStatusClosed
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&draftPR, nil)
+		mockPRRepo.EXPECT().UpdatePullRequestStatus(gomock.Any(), gomock.Any(), "pr-1", models.PullRequestStatusDraft, models.PullRequestStatusClosed).Return(nil)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&closedDraft, nil)
+
+		rr := doReq("/close", models.ChangePullRequestStatus{ID: "pr-1"})
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Close merged PR", func(t *testing.T) {
+		mergedPR := openPR
+		mergedPR.Status = models.PullRequestStatusMerged
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&mergedPR, nil)
+
+		rr := doReq("/close", models.ChangePullRequestStatus{ID: "pr-1"})
+		require.Equal(t, http.StatusConflict, rr.Code)
+		require.Equal(t, "PR_MERGED", errorCode(rr))
+	})
+
+	t.Run("Reopen keeps reviewers", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&closedPR, nil)
+		mockPRRepo.EXPECT().UpdatePullRequestStatus(gomock.Any(), gomock.Any(), "pr-1", models.PullRequestStatusClosed, models.PullRequestStatusOpen).Return(nil)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&openPR, nil)
+
+		rr := doReq("/reopen", models.ChangePullRequestStatus{ID: "pr-1"})
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Reopen changed concurrently", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&closedPR, nil)
+		mockPRRepo.EXPECT().UpdatePullRequestStatus(gomock.Any(), gomock.Any(), "pr-1", models.PullRequestStatusClosed, models.PullRequestStatusOpen).Return(sql.ErrNoRows)
+
+		rr := doReq("/reopen", models.ChangePullRequestStatus{ID: "pr-1"})
+		require.Equal(t, http.StatusConflict, rr.Code)
+		require.Equal(t, "INVALID_STATUS_TRANSITION", errorCode(rr))
+	})
+
+	t.Run("Merge draft", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&draftPR, nil)
+
+		rr := doReq("/merge", models.MergePullRequest{ID: "pr-1"})
+		require.Equal(t, http.StatusConflict, rr.Code)
+		require.Equal(t, "INVALID_STATUS_TRANSITION", errorCode(rr))
+	})
+
+	t.Run("Add reviewer to closed PR", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&closedPR, nil)
+
+		rr := doReq("/addReviewer", models.PullRequestReviewerRequest{ID: "pr-1", UserID: "u4"})
+		require.Equal(t, http.StatusConflict, rr.Code)
+		require.Equal(t, "PR_NOT_OPEN", errorCode(rr))
+	})
+
+	t.Run("Unknown PR", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows)
+
+		rr := doReq("/close", models.ChangePullRequestStatus{ID: "pr-1"})
+		require.Equal(t, http.StatusNotFound, rr.Code)
 	})
 }
 
@@ -193,9 +1545,17 @@ func TestReassign(t *testing.T) {
 
 	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
 	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
 	mockStore := mock_store.NewMockStore(ctrl)
 
-	newReviewers := []string{"u3"}
+	author := models.User{UserID: "userID", Username: "author", TeamName: "team-1", IsActive: true}
+	candidates := []models.ReviewCandidate{
+		{UserID: "u1", TeamName: "team-1", IsActive: true},
+		{UserID: "u2", TeamName: "team-1", IsActive: true},
+		{UserID: "u3", TeamName: "team-1", IsActive: true},
+		{UserID: "u4", TeamName: "team-1", IsActive: false},
+		{UserID: "userID", TeamName: "team-1", IsActive: true},
+	}
 	pr := models.PullRequest{
 		ID:                "pr-1",
 		Name:              "pr-name",
@@ -217,8 +1577,12 @@ func TestReassign(t *testing.T) {
 
 	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
 
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+
+	mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	doReq := func() *httptest.ResponseRecorder {
-		service := prservice.NewPRService(mockStore)
+		service := prservice.NewPRService(mockStore, newTestSelector(t))
 		handler := NewPRHanlder(logger.NewLogger("local"), service)
 		prMux := PRRouter(handler)
 
@@ -242,14 +1606,24 @@ func TestReassign(t *testing.T) {
 			AssignedReviewers: []string{"u3", "u2"},
 		}
 		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&pr, nil).Times(1)
-		mockTeamRepo.EXPECT().GetActiveUsersTeamWithException(gomock.Any(), gomock.Any(), "userID", []string{"u1", "u2"}, 1).Return(newReviewers, nil)
 		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
 			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
 				return fn(ctx, &db)
 			},
 		).Times(1)
-		mockPRRepo.EXPECT().DeleteAssignedByReviewerID(gomock.Any(), gomock.Any(), "u1").Return(nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(candidates, nil)
+		mockPRRepo.EXPECT().DeleteAssignedReviewer(gomock.Any(), gomock.Any(), "pr-1", "u1").Return(nil)
 		mockPRRepo.EXPECT().AssignReviewer(gomock.Any(), gomock.Any(), "pr-1", "u3").Return(nil)
+		mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ sqlx.ExtContext, events []models.PullRequestEvent) error {
+				require.Len(t, events, 1)
+				require.Equal(t, models.PullRequestEventReviewerReplaced, events[0].Type)
+				require.Equal(t, models.ActorSystem, events[0].Actor)
+				require.JSONEq(t, `{"old_reviewer_id":"u1","new_reviewer_id":"u3","reason":"MANUAL"}`, events[0].Payload.String())
+				return nil
+			},
+		)
 		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&updPR, nil).Times(1)
 		rr := doReq()
 		require.Equal(t, 200, rr.Code)
@@ -260,11 +1634,34 @@ func TestReassign(t *testing.T) {
 		require.Equal(t, []any{"u3", "u2"}, r["pr"].(map[string]any)["assigned_reviewers"])
 	})
 
+	t.Run("Reviewer is not assigned on delete", func(t *testing.T) {
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&pr, nil).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(candidates, nil)
+		mockPRRepo.EXPECT().DeleteAssignedReviewer(gomock.Any(), gomock.Any(), "pr-1", "u1").Return(sql.ErrNoRows)
+		rr := doReq()
+		require.Equal(t, 409, rr.Code)
+		r := make(map[string]any, 0)
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, "NOT_ASSIGNED", r["error"].(map[string]any)["code"])
+	})
+
 	t.Run("Reassign alredy merged", func(t *testing.T) {
 		merged := models.PullRequest{
 			ID:     "pr-1",
 			Status: models.PullRequestStatusMerged,
 		}
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
 		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&merged, nil).Times(1)
 		rr := doReq()
 		require.Equal(t, 409, rr.Code)
@@ -272,9 +1669,14 @@ func TestReassign(t *testing.T) {
 		err := json.Unmarshal(rr.Body.Bytes(), &r)
 		require.NoError(t, err)
 		require.Equal(t, "PR_MERGED", r["error"].(map[string]any)["code"])
-		require.Equal(t, "cannot reassign on merged PR", r["error"].(map[string]any)["message"])
+		require.Equal(t, "cannot change reviewers of merged PR", r["error"].(map[string]any)["message"])
 	})
 	t.Run("Reassign not found", func(t *testing.T) {
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
 		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(nil, sql.ErrNoRows).Times(1)
 		rr := doReq()
 		require.Equal(t, 404, rr.Code)
@@ -288,8 +1690,14 @@ func TestReassign(t *testing.T) {
 	t.Run("Reassign old user not reviewer", func(t *testing.T) {
 		notReviewwerPR := models.PullRequest{
 			ID:                "pr-1",
+			Status:            models.PullRequestStatusOpen,
 			AssignedReviewers: []string{"u10", "u100"},
 		}
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
 		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&notReviewwerPR, nil).Times(1)
 		rr := doReq()
 		require.Equal(t, 409, rr.Code)
@@ -308,7 +1716,14 @@ func TestReassign(t *testing.T) {
 			AssignedReviewers: []string{"u1", "u2"},
 		}
 		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&noCandidate, nil).Times(1)
-		mockTeamRepo.EXPECT().GetActiveUsersTeamWithException(gomock.Any(), gomock.Any(), "userID", []string{"u1", "u2"}, 1).Return(nil, sql.ErrNoRows)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(candidates[:2], nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&models.TeamSettings{UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer}, nil)
 		rr := doReq()
 		require.Equal(t, 409, rr.Code)
 		r := make(map[string]any, 0)
@@ -318,6 +1733,82 @@ func TestReassign(t *testing.T) {
 		require.Equal(t, "no active replacement candidate in team", r["error"].(map[string]any)["message"])
 
 	})
+
+	t.Run("Reassign from fallback team", func(t *testing.T) {
+		updPR := models.PullRequest{
+			ID:                "pr-1",
+			AuthorID:          "userID",
+			Status:            models.PullRequestStatusOpen,
+			AssignedReviewers: []string{"f1", "u2"},
+		}
+		settings := models.TeamSettings{
+			TeamName:           "team-1",
+			UnderstaffedPolicy: models.UnderstaffedPolicyFallback,
+			FallbackTeams:      []string{"team-2"},
+		}
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&pr, nil).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(candidates[:2], nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&settings, nil)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-2").Return([]models.ReviewCandidate{
+			{UserID: "f1", TeamName: "team-2", IsActive: true},
+		}, nil)
+		mockPRRepo.EXPECT().DeleteAssignedReviewer(gomock.Any(), gomock.Any(), "pr-1", "u1").Return(nil)
+		mockPRRepo.EXPECT().AssignReviewer(gomock.Any(), gomock.Any(), "pr-1", "f1").Return(nil)
+		mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&updPR, nil).Times(1)
+
+		rr := doReq()
+		require.Equal(t, 200, rr.Code)
+		r := make(map[string]any, 0)
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, "f1", r["replaced_by"])
+	})
+
+	t.Run("Reassign from parent team subtree", func(t *testing.T) {
+		updPR := models.PullRequest{
+			ID:                "pr-1",
+			AuthorID:          "userID",
+			Status:            models.PullRequestStatusOpen,
+			AssignedReviewers: []string{"s1", "u2"},
+		}
+		settings := models.TeamSettings{
+			TeamName:           "team-1",
+			UnderstaffedPolicy: models.UnderstaffedPolicyFallback,
+			FallbackTeams:      []string{"team-2"},
+			ParentTeam:         "backend",
+		}
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&pr, nil).Times(1)
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		).Times(1)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(candidates[:2], nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&settings, nil)
+		// sibling team member is taken before fallback teams
+		mockTeamRepo.EXPECT().GetSubtreeReviewCandidates(gomock.Any(), gomock.Any(), "backend").Return(slices.Concat(candidates[:2], []models.ReviewCandidate{
+			{UserID: "s1", TeamName: "team-3", IsActive: true},
+		}), nil)
+		mockPRRepo.EXPECT().DeleteAssignedReviewer(gomock.Any(), gomock.Any(), "pr-1", "u1").Return(nil)
+		mockPRRepo.EXPECT().AssignReviewer(gomock.Any(), gomock.Any(), "pr-1", "s1").Return(nil)
+		mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&updPR, nil).Times(1)
+
+		rr := doReq()
+		require.Equal(t, 200, rr.Code)
+		r := make(map[string]any, 0)
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, "s1", r["replaced_by"])
+	})
 }
 
 func TestStatistics(t *testing.T) {
@@ -349,7 +1840,7 @@ func TestStatistics(t *testing.T) {
 	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
 
 	doReq := func() *httptest.ResponseRecorder {
-		service := prservice.NewPRService(mockStore)
+		service := prservice.NewPRService(mockStore, newTestSelector(t))
 		handler := NewPRHanlder(logger.NewLogger("local"), service)
 		prMux := PRRouter(handler)
 
@@ -363,7 +1854,7 @@ func TestStatistics(t *testing.T) {
 	}
 
 	t.Run("Get PR statistics", func(t *testing.T) {
-		mockPRRepo.EXPECT().GetQuantityPRReviewers(gomock.Any(), gomock.Any()).Return(prStat, nil)
+		mockPRRepo.EXPECT().GetQuantityPRReviewers(gomock.Any(), gomock.Any(), "").Return(prStat, nil)
 		rr := doReq()
 		require.Equal(t, 200, rr.Code)
 		r := make(map[string]any, 0)
@@ -375,7 +1866,7 @@ func TestStatistics(t *testing.T) {
 	})
 
 	t.Run("Get PR no PRs", func(t *testing.T) {
-		mockPRRepo.EXPECT().GetQuantityPRReviewers(gomock.Any(), gomock.Any()).Return([]models.PullRequestQuantityReviewers{}, nil)
+		mockPRRepo.EXPECT().GetQuantityPRReviewers(gomock.Any(), gomock.Any(), "").Return([]models.PullRequestQuantityReviewers{}, nil)
 		rr := doReq()
 		require.Equal(t, 200, rr.Code)
 		r := make(map[string]any, 0)
@@ -384,3 +1875,102 @@ func TestStatistics(t *testing.T) {
 		require.Equal(t, 0, len(r["stat"].([]any)))
 	})
 }
+
+func TestPreviewAssignment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+
+	author := models.User{UserID: "userID", Username: "author", TeamName: "team-1", IsActive: true}
+	reviewLimit := 2
+	candidates := []models.ReviewCandidate{
+		{UserID: "u0", TeamName: "team-1", IsActive: true, OpenReviews: 2, MaxOpenReviews: &reviewLimit},
+		{UserID: "u1", TeamName: "team-1", IsActive: true},
+		{UserID: "u2", TeamName: "team-1", IsActive: true, Skills: []string{"backend"}},
+		{UserID: "u3", TeamName: "team-1", IsActive: false},
+		{UserID: "userID", TeamName: "team-1", IsActive: true},
+		{UserID: "on-vacation", TeamName: "team-1", IsActive: true, Unavailable: true},
+	}
+	// FAIL policy does not fail preview, it is reported as understaffed
+	settings := models.TeamSettings{TeamName: "team-1", MinReviewers: 3, MaxReviewers: 2, UnderstaffedPolicy: models.UnderstaffedPolicyFail}
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+
+	service := prservice.NewPRService(mockStore, newTestSelector(t))
+	doReq := func(query string) *httptest.ResponseRecorder {
+		handler := NewPRHanlder(logger.NewLogger("local"), service)
+		prMux := PRRouter(handler)
+
+		req, err := http.NewRequest("GET", "/previewAssignment"+query, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		prMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Preview does not move round robin", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "userID").Return(&author, nil).Times(2)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&settings, nil).Times(2)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(candidates, nil).Times(2)
+		mockTeamRepo.EXPECT().GetReviewCandidatesByUserIDs(gomock.Any(), gomock.Any(), []string{"u2", "u1"}).
+			Return([]models.ReviewCandidate{candidates[2], candidates[1]}, nil).Times(2)
+
+		for range 2 {
+			rr := doReq("?author_id=userID&labels=Backend,docs")
+			require.Equal(t, http.StatusOK, rr.Code)
+
+			r := map[string]any{}
+			err := json.Unmarshal(rr.Body.Bytes(), &r)
+			require.NoError(t, err)
+			preview := r["preview"].(map[string]any)
+			require.Equal(t, []any{"u2", "u1"}, preview["reviewers"])
+			require.Equal(t, []any{"backend", "docs"}, preview["labels"])
+			require.Equal(t, true, preview["understaffed"])
+			require.Equal(t, map[string]any{"u2": "backend"}, preview["matched_labels"])
+
+			reasons := map[string]any{}
+			for _, c := range preview["candidates"].([]any) {
+				candidate := c.(map[string]any)
+				reasons[candidate["user_id"].(string)] = candidate["excluded_reason"]
+				if candidate["selected"].(bool) {
+					reasons[candidate["user_id"].(string)] = "SELECTED"
+				}
+			}
+			require.Equal(t, map[string]any{
+				"u0":          "OVER_CAPACITY",
+				"u1":          "SELECTED",
+				"u2":          "SELECTED",
+				"u3":          "INACTIVE",
+				"userID":      "AUTHOR",
+				"on-vacation": "UNAVAILABLE",
+			}, reasons)
+		}
+	})
+
+	t.Run("Author not found", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "unknown").Return(nil, sql.ErrNoRows)
+
+		rr := doReq("?author_id=unknown")
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Without author", func(t *testing.T) {
+		rr := doReq("")
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+// round robin gives the same reviewers for every new service
+func newTestSelector(t *testing.T) prservice.ReviewerSelector {
+	selector, err := prservice.NewReviewerSelector(config.AssignmentConfig{Strategy: prservice.StrategyRoundRobin})
+	require.NoError(t, err)
+	return selector
+}
@@ -6,9 +6,24 @@ func PRRouter(h *PRHanler) http.Handler {
 	handler := http.NewServeMux()
 
 	handler.HandleFunc("POST /create", h.Create)
+	handler.HandleFunc("GET /get", h.Get)
+	handler.HandleFunc("GET /list", h.List)
 	handler.HandleFunc("POST /merge", h.Merge)
 	handler.HandleFunc("POST /reassign", h.Reassign)
+	handler.HandleFunc("POST /ready", h.MarkReady)
+	handler.HandleFunc("POST /draft", h.ConvertToDraft)
+	handler.HandleFunc("POST /close", h.Close)
+	handler.HandleFunc("POST /reopen", h.Reopen)
+	handler.HandleFunc("POST /addReviewer", h.AddReviewer)
+	handler.HandleFunc("POST /removeReviewer", h.RemoveReviewer)
+	handler.HandleFunc("POST /review", h.SubmitReview)
+	handler.HandleFunc("GET /reviews", h.GetReviews)
+	handler.HandleFunc("GET /history", h.History)
+	handler.HandleFunc("POST /setLabels", h.SetLabels)
+	handler.HandleFunc("POST /setDependencies", h.SetDependencies)
+	handler.HandleFunc("GET /blocked", h.Blocked)
 	handler.HandleFunc("GET /statistics", h.Statistics)
+	handler.HandleFunc("GET /previewAssignment", h.PreviewAssignment)
 
 	return handler
 }
@@ -0,0 +1,205 @@
+package prservice
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+// ReassignOpenReviewsOfUsers moves OPEN reviews of all listed reviewers to other eligible members by ReassignPR rules,
+// none of the listed reviewers is chosen as a replacement.
+// Assignments, candidates and settings are loaded once per team and all replacements are written in one statement.
+// It runs in the caller transaction which has to hold exclusive assignments lock.
+func (s *PRService) ReassignOpenReviewsOfUsers(ctx context.Context, exec sqlx.ExtContext, reviewerIDs []string) (*models.BulkReviewsReassignment, error) {
+	res, err := s.reassignOpenReviewsOfUsers(ctx, exec, "", reviewerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("ReassignOpenReviewsOfUsers: %w", err)
+	}
+	return res, nil
+}
+
+// ReassignTeamReviewsOfUsers moves OPEN reviews of listed reviewers on PRs reviewed by the team
+// as ReassignOpenReviewsOfUsers does, their reviews for other teams are kept
+func (s *PRService) ReassignTeamReviewsOfUsers(ctx context.Context, exec sqlx.ExtContext, teamName string, reviewerIDs []string) (*models.BulkReviewsReassignment, error) {
+	res, err := s.reassignOpenReviewsOfUsers(ctx, exec, teamName, reviewerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("ReassignTeamReviewsOfUsers: %w", err)
+	}
+	return res, nil
+}
+
+// reassignOpenReviewsOfUsers reassigns OPEN reviews of reviewers on PRs of the team, empty team means all PRs
+func (s *PRService) reassignOpenReviewsOfUsers(ctx context.Context, exec sqlx.ExtContext, teamName string, reviewerIDs []string) (*models.BulkReviewsReassignment, error) {
+	assignments, err := s.store.PRRepo().GetOpenAssignmentsByReviewers(ctx, exec, reviewerIDs, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get open assignments: %v", err)
+	}
+
+	planner := &reassignmentPlanner{
+		service:    s,
+		exec:       exec,
+		candidates: make(map[string][]models.ReviewCandidate),
+		subtrees:   make(map[string][]models.ReviewCandidate),
+		settings:   make(map[string]*models.TeamSettings),
+		reviewers:  make(map[string][]string),
+	}
+
+	res := &models.BulkReviewsReassignment{
+		Reassigned:    make([]models.ReviewerReplacement, 0),
+		NotReassigned: make([]models.OpenReview, 0),
+	}
+	for _, assignment := range assignments {
+		// PR reviewers changed by previous replacements
+		current, ok := planner.reviewers[assignment.PullRequestID]
+		if !ok {
+			current = assignment.AssignedReviewers
+		}
+
+		newReviewerID, err := planner.replacement(ctx, &assignment, slices.Concat(current, reviewerIDs))
+		if err != nil {
+			return nil, fmt.Errorf("PR %s: %w", assignment.PullRequestID, err)
+		}
+
+		if newReviewerID == "" {
+			res.NotReassigned = append(res.NotReassigned, models.OpenReview{
+				PullRequestID: assignment.PullRequestID,
+				ReviewerID:    assignment.ReviewerID,
+			})
+			continue
+		}
+
+		planner.reviewers[assignment.PullRequestID] = append(slices.DeleteFunc(slices.Clone(current), func(id string) bool {
+			return id == assignment.ReviewerID
+		}), newReviewerID)
+		res.Reassigned = append(res.Reassigned, models.ReviewerReplacement{
+			PullRequestID: assignment.PullRequestID,
+			OldReviewerID: assignment.ReviewerID,
+			NewReviewerID: newReviewerID,
+		})
+	}
+
+	if err := s.store.PRRepo().ReplaceManyReviewers(ctx, exec, res.Reassigned); err != nil {
+		return nil, fmt.Errorf("unable to replace reviewers: %v", err)
+	}
+	if err := s.recordEvents(ctx, exec, replacedEvents(ctx, res.Reassigned, models.AssignmentReasonReviewerDeactivated)...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// reassignmentPlanner caches team candidates and settings during bulk reassignment
+// and counts reviews planned for candidates so later picks see their new load
+type reassignmentPlanner struct {
+	service *PRService
+	exec    sqlx.ExtContext
+
+	candidates map[string][]models.ReviewCandidate
+	// candidates of team subtrees by the subtree root
+	subtrees map[string][]models.ReviewCandidate
+	settings map[string]*models.TeamSettings
+	// current reviewers of PRs which already had replacements
+	reviewers map[string][]string
+}
+
+// replacement picks new reviewer from review team, its ancestors or its fallback teams, returns empty id if there is no candidate
+func (p *reassignmentPlanner) replacement(ctx context.Context, assignment *models.OpenAssignment, exclude []string) (string, error) {
+	newReviewerID, err := p.pick(ctx, assignment.ReviewTeamName, assignment, exclude)
+	if err != nil || newReviewerID != "" {
+		return newReviewerID, err
+	}
+
+	settings, err := p.teamSettings(ctx, assignment.ReviewTeamName)
+	if err != nil {
+		return "", err
+	}
+
+	visited := map[string]bool{settings.TeamName: true}
+	for parent := settings.ParentTeam; parent != "" && !visited[parent]; {
+		visited[parent] = true
+
+		newReviewerID, err := p.pickFromSubtree(ctx, parent, assignment, exclude)
+		if err != nil || newReviewerID != "" {
+			return newReviewerID, err
+		}
+
+		parentSettings, err := p.teamSettings(ctx, parent)
+		if err != nil {
+			return "", err
+		}
+		parent = parentSettings.ParentTeam
+	}
+
+	if settings.UnderstaffedPolicy != models.UnderstaffedPolicyFallback {
+		return "", nil
+	}
+
+	for _, fallbackTeam := range settings.FallbackTeams {
+		newReviewerID, err := p.pick(ctx, fallbackTeam, assignment, exclude)
+		if err != nil || newReviewerID != "" {
+			return newReviewerID, err
+		}
+	}
+	return "", nil
+}
+
+func (p *reassignmentPlanner) teamSettings(ctx context.Context, teamName string) (*models.TeamSettings, error) {
+	if settings, ok := p.settings[teamName]; ok {
+		return settings, nil
+	}
+
+	settings, err := p.service.store.TeamRepo().GetTeamSettings(ctx, p.exec, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get team settings: %v", err)
+	}
+	p.settings[teamName] = settings
+	return settings, nil
+}
+
+func (p *reassignmentPlanner) pick(ctx context.Context, teamName string, assignment *models.OpenAssignment, exclude []string) (string, error) {
+	candidates, ok := p.candidates[teamName]
+	if !ok {
+		var err error
+		candidates, err = p.service.store.TeamRepo().GetTeamReviewCandidates(ctx, p.exec, teamName)
+		if err != nil {
+			return "", fmt.Errorf("unable to get candidates of team %s: %v", teamName, err)
+		}
+		p.candidates[teamName] = candidates
+	}
+	return p.pickFrom(teamName, candidates, assignment, exclude), nil
+}
+
+func (p *reassignmentPlanner) pickFromSubtree(ctx context.Context, teamName string, assignment *models.OpenAssignment, exclude []string) (string, error) {
+	candidates, ok := p.subtrees[teamName]
+	if !ok {
+		var err error
+		candidates, err = p.service.store.TeamRepo().GetSubtreeReviewCandidates(ctx, p.exec, teamName)
+		if err != nil {
+			return "", fmt.Errorf("unable to get candidates of team %s subtree: %v", teamName, err)
+		}
+		p.subtrees[teamName] = candidates
+	}
+	return p.pickFrom(teamName, candidates, assignment, exclude), nil
+}
+
+// pickFrom selects one of the candidates and counts the planned review in its load
+// in every cached candidates list, as team and subtree lists share members
+func (p *reassignmentPlanner) pickFrom(teamName string, candidates []models.ReviewCandidate, assignment *models.OpenAssignment, exclude []string) string {
+	selected := p.service.pickReviewers(teamName, eligibleCandidates(candidates, assignment.AuthorID, exclude), assignment.Labels, 1)
+	if len(selected) == 0 {
+		return ""
+	}
+
+	for _, cached := range []map[string][]models.ReviewCandidate{p.candidates, p.subtrees} {
+		for _, list := range cached {
+			for i := range list {
+				if list[i].UserID == selected[0] {
+					list[i].OpenReviews++
+				}
+			}
+		}
+	}
+	return selected[0]
+}
@@ -0,0 +1,127 @@
+package prservice
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/Negat1v9/pr-review-service/pkg/utils"
+	"github.com/jmoiron/sqlx"
+)
+
+// SetDependencies replaces PRs which have to be merged before DRAFT or OPEN PR,
+// dependency which makes a cycle in the graph is rejected
+func (s *PRService) SetDependencies(ctx context.Context, prID string, dependsOn []string) (*models.PullRequest, error) {
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		// graph is locked before the check, concurrent changes could make a cycle together
+		if err := s.store.PRRepo().LockDependencies(ctx, exec); err != nil {
+			return fmt.Errorf("SetDependencies: unable to lock dependencies: %v", err)
+		}
+
+		pr, err := s.store.PRRepo().GetPullRequestByID(ctx, exec, prID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return utils.NewNotFoundError("resource not found", nil)
+			}
+			return fmt.Errorf("SetDependencies: unable to get PR: %v", err)
+		}
+		if pr.Status == models.PullRequestStatusMerged {
+			return utils.NewError(409, utils.ErrPrAlredyMerged, "cannot change dependencies of merged PR", nil)
+		}
+		if pr.Status == models.PullRequestStatusClosed {
+			return utils.NewError(409, utils.ErrPrNotOpen, "cannot change dependencies of closed PR", nil)
+		}
+
+		dependsOn, err = s.validateDependencies(ctx, exec, prID, dependsOn)
+		if err != nil {
+			return err
+		}
+
+		if len(dependsOn) > 0 {
+			cycle, err := s.store.PRRepo().HasDependencyPath(ctx, exec, dependsOn, prID)
+			if err != nil {
+				return fmt.Errorf("SetDependencies: unable to check dependency cycle: %v", err)
+			}
+			if cycle {
+				return utils.NewError(409, utils.ErrDependencyCycle, "dependencies make a cycle", nil)
+			}
+		}
+
+		if err := s.store.PRRepo().SetPullRequestDependencies(ctx, exec, prID, dependsOn); err != nil {
+			return fmt.Errorf("SetDependencies: unable to set dependencies: %v", err)
+		}
+
+		changed := newEvent(ctx, prID, models.PullRequestEventDependenciesChanged, "", models.DependenciesChangedPayload{DependsOn: dependsOn})
+		if err := s.recordEvents(ctx, exec, changed); err != nil {
+			return fmt.Errorf("SetDependencies: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPR(ctx, prID)
+}
+
+// BlockedBy returns DRAFT and OPEN PRs which can not be merged until the PR is merged,
+// PRs which depend on it through other PRs are included
+func (s *PRService) BlockedBy(ctx context.Context, prID string) ([]models.PullRequest, error) {
+	pr, err := s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), prID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NewNotFoundError("resource not found", nil)
+		}
+		return nil, fmt.Errorf("BlockedBy: unable to get PR: %v", err)
+	}
+
+	// merged or closed PR blocks nothing
+	if pr.Status != models.PullRequestStatusDraft && pr.Status != models.PullRequestStatusOpen {
+		return []models.PullRequest{}, nil
+	}
+
+	blocked, err := s.store.PRRepo().GetBlockedPullRequests(ctx, s.store.DB(), prID)
+	if err != nil {
+		return nil, fmt.Errorf("BlockedBy: unable to get blocked PRs: %v", err)
+	}
+	return blocked, nil
+}
+
+// validateDependencies returns dependencies without duplicates, each of them has to exist
+func (s *PRService) validateDependencies(ctx context.Context, exec sqlx.ExtContext, prID string, dependsOn []string) ([]string, error) {
+	unique := make([]string, 0, len(dependsOn))
+	for _, id := range dependsOn {
+		if id == "" || slices.Contains(unique, id) {
+			continue
+		}
+		if id == prID {
+			return nil, utils.NewBadRequestError("PR cannot depend on itself", nil)
+		}
+
+		if _, err := s.store.PRRepo().GetPullRequestByID(ctx, exec, id); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, utils.NewNotFoundError("resource not found", nil)
+			}
+			return nil, fmt.Errorf("unable to get dependency PR: %v", err)
+		}
+		unique = append(unique, id)
+	}
+	return unique, nil
+}
+
+// checkDependenciesMerged fails if any direct dependency of the PR is still DRAFT or OPEN,
+// closed dependency is abandoned and does not block
+func (s *PRService) checkDependenciesMerged(ctx context.Context, exec sqlx.ExtContext, prID string) error {
+	unmerged, err := s.store.PRRepo().GetUnmergedDependencies(ctx, exec, prID)
+	if err != nil {
+		return fmt.Errorf("unable to get unmerged dependencies: %v", err)
+	}
+	if len(unmerged) > 0 {
+		notMergedErr := utils.NewError(409, utils.ErrDependencyNotMerged, "PR depends on not merged PRs", nil)
+		notMergedErr.Details = unmerged
+		return notMergedErr
+	}
+	return nil
+}
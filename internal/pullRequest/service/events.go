@@ -0,0 +1,81 @@
+package prservice
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/Negat1v9/pr-review-service/pkg/utils"
+	"github.com/jmoiron/sqlx"
+)
+
+// History returns timeline of the PR in order of changes
+func (s *PRService) History(ctx context.Context, prID string) ([]models.PullRequestEvent, error) {
+	if _, err := s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), prID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NewNotFoundError("resource not found", nil)
+		}
+		return nil, fmt.Errorf("History: unable to get PR: %v", err)
+	}
+
+	return s.store.PRRepo().GetPullRequestEvents(ctx, s.store.DB(), prID)
+}
+
+// recordEvents appends events to PR timelines, it has to run in the transaction of the change
+func (s *PRService) recordEvents(ctx context.Context, exec sqlx.ExtContext, events ...models.PullRequestEvent) error {
+	if err := s.store.PRRepo().CreateEvents(ctx, exec, events); err != nil {
+		return fmt.Errorf("unable to record PR events: %v", err)
+	}
+	return nil
+}
+
+// newEvent creates event made by the request actor, fallback is used when request does not name the actor
+func newEvent(ctx context.Context, prID string, eventType models.PullRequestEventType, fallbackActor string, payload any) models.PullRequestEvent {
+	actor := utils.ActorFromContext(ctx)
+	if actor == "" {
+		actor = fallbackActor
+	}
+	if actor == "" {
+		actor = models.ActorSystem
+	}
+
+	// payloads are plain structs which are always marshaled
+	data, _ := json.Marshal(payload)
+	return models.PullRequestEvent{
+		PullRequestID: prID,
+		Type:          eventType,
+		Actor:         actor,
+		Payload:       data,
+	}
+}
+
+func statusChangedEvent(ctx context.Context, prID string, from, to models.PullRequestStatus) models.PullRequestEvent {
+	return newEvent(ctx, prID, models.PullRequestEventStatusChanged, "", models.StatusChangedPayload{From: from, To: to})
+}
+
+// assignedEvents records reviewers assigned to the PR for the reason
+func assignedEvents(ctx context.Context, prID string, reviewerIDs []string, reason models.AssignmentReason) []models.PullRequestEvent {
+	events := make([]models.PullRequestEvent, 0, len(reviewerIDs))
+	for _, reviewerID := range reviewerIDs {
+		events = append(events, newEvent(ctx, prID, models.PullRequestEventReviewerAssigned, "", models.ReviewerPayload{
+			ReviewerID: reviewerID,
+			Reason:     reason,
+		}))
+	}
+	return events
+}
+
+// replacedEvents records replacements of reviewers for the reason
+func replacedEvents(ctx context.Context, replacements []models.ReviewerReplacement, reason models.AssignmentReason) []models.PullRequestEvent {
+	events := make([]models.PullRequestEvent, 0, len(replacements))
+	for _, replacement := range replacements {
+		events = append(events, newEvent(ctx, replacement.PullRequestID, models.PullRequestEventReviewerReplaced, "", models.ReviewerReplacedPayload{
+			OldReviewerID: replacement.OldReviewerID,
+			NewReviewerID: replacement.NewReviewerID,
+			Reason:        reason,
+		}))
+	}
+	return events
+}
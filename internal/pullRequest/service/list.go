@@ -0,0 +1,139 @@
+package prservice
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/Negat1v9/pr-review-service/pkg/utils"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// GetPR returns PR with reviewers, their latest review states, labels and dependency graph
+func (s *PRService) GetPR(ctx context.Context, prID string) (*models.PullRequest, error) {
+	pr, err := s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), prID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NewNotFoundError("resource not found", nil)
+		}
+		return nil, fmt.Errorf("GetPR: unable to get PR: %v", err)
+	}
+
+	pr.Dependencies, err = s.store.PRRepo().GetDependencyGraph(ctx, s.store.DB(), prID)
+	if err != nil {
+		return nil, fmt.Errorf("GetPR: unable to get PR dependencies: %v", err)
+	}
+	return pr, nil
+}
+
+// ListPRs returns page of PRs by the filter, cursor is the next_cursor of the previous page
+// and it has to be used with the same sorting
+func (s *PRService) ListPRs(ctx context.Context, filter *models.PullRequestFilter, cursor string) (*models.PullRequestPage, error) {
+	if err := validateFilter(filter); err != nil {
+		return nil, err
+	}
+
+	if cursor != "" {
+		after, err := decodeCursor(cursor)
+		if err != nil || after.SortBy != filter.SortBy || after.Desc != filter.Desc {
+			return nil, utils.NewBadRequestError("invalid cursor", nil)
+		}
+		filter.After = after
+	}
+
+	pageSize := filter.Limit
+	// one more PR shows there is a next page
+	filter.Limit++
+	prs, err := s.store.PRRepo().ListPullRequests(ctx, s.store.DB(), filter)
+	if err != nil {
+		return nil, fmt.Errorf("ListPRs: unable to list PRs: %v", err)
+	}
+
+	page := &models.PullRequestPage{PullRequests: prs}
+	if len(prs) > pageSize {
+		page.PullRequests = prs[:pageSize]
+		page.NextCursor = encodeCursor(cursorAfter(&prs[pageSize-1], filter))
+	}
+	return page, nil
+}
+
+// validateFilter checks the filter and fills default sorting and page size
+func validateFilter(filter *models.PullRequestFilter) error {
+	switch filter.Status {
+	case "", models.PullRequestStatusDraft, models.PullRequestStatusOpen, models.PullRequestStatusClosed, models.PullRequestStatusMerged:
+	default:
+		return utils.NewBadRequestError("unknown PR status", nil)
+	}
+
+	switch filter.SortBy {
+	case "":
+		filter.SortBy = models.PullRequestSortCreatedAt
+	case models.PullRequestSortCreatedAt, models.PullRequestSortID, models.PullRequestSortName:
+	default:
+		return utils.NewBadRequestError("sort_by must be one of created_at, pull_request_id, pull_request_name", nil)
+	}
+
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = defaultPageSize
+	case filter.Limit < 0 || filter.Limit > maxPageSize:
+		return utils.NewBadRequestError(fmt.Sprintf("limit must be between 1 and %d", maxPageSize), nil)
+	}
+
+	if isInvalidRange(filter.CreatedFrom, filter.CreatedTo) || isInvalidRange(filter.MergedFrom, filter.MergedTo) {
+		return utils.NewBadRequestError("time range start must be before its end", nil)
+	}
+	return nil
+}
+
+func isInvalidRange(from, to *time.Time) bool {
+	return from != nil && to != nil && !from.Before(*to)
+}
+
+// cursorAfter returns position of the PR in the list sorted by the filter
+func cursorAfter(pr *models.PullRequest, filter *models.PullRequestFilter) *models.PullRequestCursor {
+	cursor := &models.PullRequestCursor{SortBy: filter.SortBy, Desc: filter.Desc, ID: pr.ID}
+	switch filter.SortBy {
+	case models.PullRequestSortCreatedAt:
+		cursor.Value = pr.CreatedAt.Format(time.RFC3339Nano)
+	case models.PullRequestSortID:
+		cursor.Value = pr.ID
+	case models.PullRequestSortName:
+		cursor.Value = pr.Name
+	}
+	return cursor
+}
+
+func encodeCursor(cursor *models.PullRequestCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string) (*models.PullRequestCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor models.PullRequestCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	if cursor.ID == "" {
+		return nil, fmt.Errorf("cursor without PR id")
+	}
+	if cursor.SortBy == models.PullRequestSortCreatedAt {
+		if _, err := time.Parse(time.RFC3339Nano, cursor.Value); err != nil {
+			return nil, err
+		}
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,46 @@
+package prservice
+
+import (
+	"github.com/Negat1v9/pr-review-service/internal/models"
+)
+
+// assignmentTrace collects every candidate seen by reviewers selection
+// with the reason of exclusion at the moment candidate was seen first
+type assignmentTrace struct {
+	candidates []models.PreviewCandidate
+	seen       map[string]bool
+}
+
+// add is no-op for nil trace, so selection does not check whether it runs for preview
+func (t *assignmentTrace) add(candidates []models.ReviewCandidate, authorID string, exclude []string) {
+	if t == nil {
+		return
+	}
+	for _, candidate := range candidates {
+		if t.seen[candidate.UserID] {
+			continue
+		}
+		t.seen[candidate.UserID] = true
+		t.candidates = append(t.candidates, models.PreviewCandidate{
+			ReviewCandidate: candidate,
+			ExcludedReason:  exclusionReason(candidate, authorID, exclude),
+		})
+	}
+}
+
+// peeker is implemented by strategies with state to select reviewers without moving it
+type peeker interface {
+	Peek(teamName string, candidates []models.ReviewCandidate, n int) []string
+}
+
+// previewSelector selects reviewers as the wrapped selector but keeps its state
+type previewSelector struct {
+	selector ReviewerSelector
+}
+
+func (s previewSelector) Select(teamName string, candidates []models.ReviewCandidate, n int) []string {
+	if p, ok := s.selector.(peeker); ok {
+		return p.Peek(teamName, candidates, n)
+	}
+	return s.selector.Select(teamName, candidates, n)
+}
@@ -0,0 +1,167 @@
+package prservice
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/Negat1v9/pr-review-service/pkg/utils"
+	"github.com/jmoiron/sqlx"
+)
+
+// SubmitReview records review decision of the assigned reviewer, previous decisions are kept in history.
+// Required approver of the merge policy is assigned by own review
+func (s *PRService) SubmitReview(ctx context.Context, req *models.SubmitReviewRequest) (*models.Review, error) {
+	switch req.State {
+	case models.ReviewStateApproved, models.ReviewStateChangesRequested, models.ReviewStateCommented:
+	default:
+		return nil, utils.NewBadRequestError("state must be one of APPROVED, CHANGES_REQUESTED, COMMENTED", nil)
+	}
+
+	prID := models.PullRequestKey(req.Repository, req.PullRequestID)
+	var review *models.Review
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		// merge evaluates the reviews under the same lock
+		if err := s.store.PRRepo().LockAssignments(ctx, exec); err != nil {
+			return fmt.Errorf("SubmitReview: unable to lock assignments: %v", err)
+		}
+
+		pr, err := s.getOpenPR(ctx, exec, prID)
+		if err != nil {
+			return err
+		}
+		if !isUserIDInReviewers(req.ReviewerID, pr.AssignedReviewers) {
+			if err := s.assignRequiredApprover(ctx, exec, pr, req.ReviewerID); err != nil {
+				return err
+			}
+		}
+
+		review, err = s.store.PRRepo().CreateReview(ctx, exec, &models.Review{
+			PullRequestID: prID,
+			ReviewerID:    req.ReviewerID,
+			State:         req.State,
+			Body:          req.Body,
+		})
+		if err != nil {
+			return fmt.Errorf("SubmitReview: unable to create review: %v", err)
+		}
+
+		submitted := newEvent(ctx, pr.ID, models.PullRequestEventReviewSubmitted, req.ReviewerID, models.ReviewSubmittedPayload{
+			ReviewID:   review.ID,
+			ReviewerID: review.ReviewerID,
+			State:      review.State,
+		})
+		if err := s.recordEvents(ctx, exec, submitted); err != nil {
+			return fmt.Errorf("SubmitReview: %v", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// GetReviews returns all reviews submitted to the PR in submission order
+func (s *PRService) GetReviews(ctx context.Context, prID string) ([]models.Review, error) {
+	if _, err := s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), prID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NewNotFoundError("resource not found", nil)
+		}
+		return nil, fmt.Errorf("GetReviews: unable to get PR: %v", err)
+	}
+
+	return s.store.PRRepo().GetPullRequestReviews(ctx, s.store.DB(), prID)
+}
+
+// assignRequiredApprover assigns user whose approval is required by the merge policy,
+// other users who are not reviewers of the PR can not review it
+func (s *PRService) assignRequiredApprover(ctx context.Context, exec sqlx.ExtContext, pr *models.PullRequest, userID string) error {
+	policy, err := s.mergePolicy(ctx, exec, pr)
+	if err != nil {
+		return err
+	}
+	if userID == pr.AuthorID || !slices.Contains(policy.RequiredApprovers, userID) {
+		return utils.NewError(409, utils.ErrUserNotReviewer, "reviewer is not assigned to this PR", nil)
+	}
+
+	if err := s.store.PRRepo().AssignReviewer(ctx, exec, pr.ID, userID); err != nil {
+		return fmt.Errorf("unable to assign required approver: %v", err)
+	}
+	return s.recordEvents(ctx, exec, assignedEvents(ctx, pr.ID, []string{userID}, models.AssignmentReasonRequiredApprover)...)
+}
+
+// mergePolicy returns merge policy of the team which reviews the PR
+func (s *PRService) mergePolicy(ctx context.Context, exec sqlx.ExtContext, pr *models.PullRequest) (*models.MergePolicy, error) {
+	author, err := s.store.UserRepo().GetUserByID(ctx, exec, pr.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get PR author: %v", err)
+	}
+	teamName, err := s.reviewTeam(ctx, exec, pr.TeamName, pr.Repository, author)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.store.TeamRepo().GetMergePolicy(ctx, exec, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get merge policy: %v", err)
+	}
+	return policy, nil
+}
+
+// checkMergePolicy returns MERGE_BLOCKED error with unmet conditions of the merge policy
+func (s *PRService) checkMergePolicy(ctx context.Context, exec sqlx.ExtContext, pr *models.PullRequest) error {
+	policy, err := s.mergePolicy(ctx, exec, pr)
+	if err != nil {
+		return err
+	}
+
+	unmet := unmetMergeConditions(pr.Reviewers, policy)
+	if len(unmet) == 0 {
+		return nil
+	}
+
+	mergeErr := utils.NewError(409, utils.ErrMergeBlocked, "merge conditions are not met", nil)
+	mergeErr.Details = unmet
+	return mergeErr
+}
+
+// unmetMergeConditions checks decisions of assigned reviewers against the policy, comments are not decisions
+func unmetMergeConditions(reviewers []models.ReviewerState, policy *models.MergePolicy) []models.UnmetMergeCondition {
+	unmet := make([]models.UnmetMergeCondition, 0)
+
+	approved := make(map[string]bool, len(reviewers))
+	for _, reviewer := range reviewers {
+		switch reviewer.State {
+		case models.ReviewStateApproved:
+			approved[reviewer.UserID] = true
+		case models.ReviewStateChangesRequested:
+			if policy.BlockOnChangesRequested {
+				unmet = append(unmet, models.UnmetMergeCondition{
+					Condition: models.MergeConditionChangesRequested,
+					Message:   fmt.Sprintf("%s requested changes", reviewer.UserID),
+				})
+			}
+		}
+	}
+
+	if len(approved) < policy.MinApprovals {
+		unmet = append(unmet, models.UnmetMergeCondition{
+			Condition: models.MergeConditionMinApprovals,
+			Message:   fmt.Sprintf("%d of %d required approvals", len(approved), policy.MinApprovals),
+		})
+	}
+
+	for _, approver := range policy.RequiredApprovers {
+		if !approved[approver] {
+			unmet = append(unmet, models.UnmetMergeCondition{
+				Condition: models.MergeConditionRequiredApprover,
+				Message:   fmt.Sprintf("approval of %s is required", approver),
+			})
+		}
+	}
+	return unmet
+}
@@ -0,0 +1,207 @@
+package prservice
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Negat1v9/pr-review-service/config"
+	"github.com/Negat1v9/pr-review-service/internal/models"
+)
+
+const (
+	StrategyRandom         = "random"
+	StrategyRoundRobin     = "round_robin"
+	StrategyLeastLoaded    = "least_loaded"
+	StrategyWeightedRandom = "weighted_random"
+)
+
+// ReviewerSelector chooses up to n reviewers from already filtered candidates of the team.
+// Candidates passed to Select are always eligible, so strategy only decides the order.
+type ReviewerSelector interface {
+	Select(teamName string, candidates []models.ReviewCandidate, n int) []string
+}
+
+// NewReviewerSelector creates selector from config, teams from TeamStrategies
+// use their own strategy and all other teams use the global one.
+func NewReviewerSelector(cfg config.AssignmentConfig) (ReviewerSelector, error) {
+	def, err := newStrategy(cfg.Strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make(map[string]ReviewerSelector, len(cfg.TeamStrategies))
+	for teamName, strategy := range cfg.TeamStrategies {
+		selector, err := newStrategy(strategy)
+		if err != nil {
+			return nil, fmt.Errorf("team %s: %w", teamName, err)
+		}
+		teams[strings.ToLower(teamName)] = selector
+	}
+
+	return &teamSelector{def: def, teams: teams}, nil
+}
+
+func newStrategy(name string) (ReviewerSelector, error) {
+	switch name {
+	case StrategyRandom, "":
+		return &randomSelector{}, nil
+	case StrategyRoundRobin:
+		return &roundRobinSelector{last: make(map[string]string)}, nil
+	case StrategyLeastLoaded:
+		return &leastLoadedSelector{}, nil
+	case StrategyWeightedRandom:
+		return &weightedRandomSelector{}, nil
+	default:
+		return nil, fmt.Errorf("unknown reviewer selection strategy: %s", name)
+	}
+}
+
+// teamSelector dispatches selection to the strategy configured for the team
+type teamSelector struct {
+	def   ReviewerSelector
+	teams map[string]ReviewerSelector
+}
+
+func (s *teamSelector) Select(teamName string, candidates []models.ReviewCandidate, n int) []string {
+	if selector, ok := s.teams[strings.ToLower(teamName)]; ok {
+		return selector.Select(teamName, candidates, n)
+	}
+	return s.def.Select(teamName, candidates, n)
+}
+
+func (s *teamSelector) Peek(teamName string, candidates []models.ReviewCandidate, n int) []string {
+	if selector, ok := s.teams[strings.ToLower(teamName)]; ok {
+		return previewSelector{selector: selector}.Select(teamName, candidates, n)
+	}
+	return previewSelector{selector: s.def}.Select(teamName, candidates, n)
+}
+
+// randomSelector picks reviewers uniformly at random
+type randomSelector struct{}
+
+func (s *randomSelector) Select(_ string, candidates []models.ReviewCandidate, n int) []string {
+	shuffled := make([]models.ReviewCandidate, len(candidates))
+	copy(shuffled, candidates)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return firstIDs(shuffled, n)
+}
+
+// roundRobinSelector walks through team members ordered by user_id and
+// continues after the last picked member on the next call.
+// State is kept in memory, so it starts over after restart.
+type roundRobinSelector struct {
+	mu   sync.Mutex
+	last map[string]string // team name -> last picked user_id
+}
+
+func (s *roundRobinSelector) Select(teamName string, candidates []models.ReviewCandidate, n int) []string {
+	return s.pick(teamName, candidates, n, true)
+}
+
+// Peek returns members which Select would pick without remembering the last one
+func (s *roundRobinSelector) Peek(teamName string, candidates []models.ReviewCandidate, n int) []string {
+	return s.pick(teamName, candidates, n, false)
+}
+
+func (s *roundRobinSelector) pick(teamName string, candidates []models.ReviewCandidate, n int, remember bool) []string {
+	if len(candidates) == 0 || n <= 0 {
+		return []string{}
+	}
+
+	sorted := make([]models.ReviewCandidate, len(candidates))
+	copy(sorted, candidates)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].UserID < sorted[j].UserID })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// first member after the last picked one, members could be changed between calls
+	start := sort.Search(len(sorted), func(i int) bool { return sorted[i].UserID > s.last[teamName] })
+
+	if n > len(sorted) {
+		n = len(sorted)
+	}
+	res := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		res = append(res, sorted[(start+i)%len(sorted)].UserID)
+	}
+	if remember {
+		s.last[teamName] = res[len(res)-1]
+	}
+
+	return res
+}
+
+// leastLoadedSelector prefers members with fewer OPEN reviews,
+// members with equal load are ordered by user_id so the result is deterministic
+type leastLoadedSelector struct{}
+
+func (s *leastLoadedSelector) Select(_ string, candidates []models.ReviewCandidate, n int) []string {
+	sorted := make([]models.ReviewCandidate, len(candidates))
+	copy(sorted, candidates)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].OpenReviews != sorted[j].OpenReviews {
+			return sorted[i].OpenReviews < sorted[j].OpenReviews
+		}
+		return sorted[i].UserID < sorted[j].UserID
+	})
+	return firstIDs(sorted, n)
+}
+
+// weightedRandomSelector picks reviewers at random with weight 1/(1+open reviews),
+// so loaded members still can be picked but less often
+type weightedRandomSelector struct{}
+
+func (s *weightedRandomSelector) Select(_ string, candidates []models.ReviewCandidate, n int) []string {
+	if n <= 0 {
+		return []string{}
+	}
+
+	pool := make([]models.ReviewCandidate, len(candidates))
+	copy(pool, candidates)
+
+	res := make([]string, 0, n)
+	for len(res) < n && len(pool) > 0 {
+		total := 0.0
+		for _, c := range pool {
+			total += candidateWeight(c)
+		}
+
+		target := rand.Float64() * total
+		picked := len(pool) - 1
+		for i, c := range pool {
+			target -= candidateWeight(c)
+			if target < 0 {
+				picked = i
+				break
+			}
+		}
+
+		res = append(res, pool[picked].UserID)
+		pool = append(pool[:picked], pool[picked+1:]...)
+	}
+	return res
+}
+
+func candidateWeight(c models.ReviewCandidate) float64 {
+	return 1 / float64(1+c.OpenReviews)
+}
+
+func firstIDs(candidates []models.ReviewCandidate, n int) []string {
+	if n < 0 {
+		n = 0
+	}
+	if n > len(candidates) {
+		n = len(candidates)
+	}
+	res := make([]string, 0, n)
+	for _, c := range candidates[:n] {
+		res = append(res, c.UserID)
+	}
+	return res
+}
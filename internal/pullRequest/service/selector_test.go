@@ -0,0 +1,79 @@
+package prservice
+
+import (
+	"testing"
+
+	"github.com/Negat1v9/pr-review-service/config"
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewerSelector(t *testing.T) {
+	candidates := []models.ReviewCandidate{
+		{UserID: "u3", OpenReviews: 0},
+		{UserID: "u1", OpenReviews: 2},
+		{UserID: "u2", OpenReviews: 1},
+	}
+
+	t.Run("Unknown strategy", func(t *testing.T) {
+		_, err := NewReviewerSelector(config.AssignmentConfig{Strategy: "oldest"})
+		require.Error(t, err)
+
+		_, err = NewReviewerSelector(config.AssignmentConfig{TeamStrategies: map[string]string{"backend": "oldest"}})
+		require.Error(t, err)
+	})
+
+	t.Run("Round robin continues after last picked", func(t *testing.T) {
+		selector, err := NewReviewerSelector(config.AssignmentConfig{Strategy: StrategyRoundRobin})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"u1", "u2"}, selector.Select("backend", candidates, 2))
+		require.Equal(t, []string{"u3", "u1"}, selector.Select("backend", candidates, 2))
+		// other team has own cursor
+		require.Equal(t, []string{"u1"}, selector.Select("frontend", candidates, 1))
+	})
+
+	t.Run("Preview keeps round robin position", func(t *testing.T) {
+		selector, err := NewReviewerSelector(config.AssignmentConfig{Strategy: StrategyRoundRobin})
+		require.NoError(t, err)
+		preview := previewSelector{selector: selector}
+
+		require.Equal(t, []string{"u1"}, selector.Select("backend", candidates, 1))
+		require.Equal(t, []string{"u2", "u3"}, preview.Select("backend", candidates, 2))
+		require.Equal(t, []string{"u2", "u3"}, selector.Select("backend", candidates, 2))
+	})
+
+	t.Run("Least loaded", func(t *testing.T) {
+		selector, err := NewReviewerSelector(config.AssignmentConfig{Strategy: StrategyLeastLoaded})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"u3", "u2"}, selector.Select("backend", candidates, 2))
+
+		// equal load is ordered by user_id
+		equalLoad := []models.ReviewCandidate{{UserID: "u9"}, {UserID: "u5"}, {UserID: "u7"}}
+		for i := 0; i < 5; i++ {
+			require.Equal(t, []string{"u5", "u7"}, selector.Select("backend", equalLoad, 2))
+		}
+	})
+
+	t.Run("Team override", func(t *testing.T) {
+		selector, err := NewReviewerSelector(config.AssignmentConfig{
+			Strategy:       StrategyRandom,
+			TeamStrategies: map[string]string{"backend": StrategyLeastLoaded},
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"u3"}, selector.Select("Backend", candidates, 1))
+	})
+
+	t.Run("Random strategies pick distinct candidates", func(t *testing.T) {
+		for _, strategy := range []string{StrategyRandom, StrategyWeightedRandom} {
+			selector, err := NewReviewerSelector(config.AssignmentConfig{Strategy: strategy})
+			require.NoError(t, err)
+
+			picked := selector.Select("backend", candidates, 5)
+			require.ElementsMatch(t, []string{"u1", "u2", "u3"}, picked)
+			require.Empty(t, selector.Select("backend", nil, 2))
+		}
+	})
+}
@@ -4,26 +4,42 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Negat1v9/pr-review-service/internal/models"
 	"github.com/Negat1v9/pr-review-service/internal/store"
+	"github.com/Negat1v9/pr-review-service/pkg/codeowners"
 	"github.com/Negat1v9/pr-review-service/pkg/utils"
 	"github.com/jmoiron/sqlx"
 )
 
 type PRService struct {
-	store store.Store
+	store    store.Store
+	selector ReviewerSelector
+	// collects candidates seen by selection, set only for assignment preview
+	trace *assignmentTrace
 }
 
-func NewPRService(store store.Store) *PRService {
+func NewPRService(store store.Store, selector ReviewerSelector) *PRService {
 	return &PRService{
-		store: store,
+		store:    store,
+		selector: selector,
 	}
 }
 
 func (s *PRService) CreatePR(ctx context.Context, pr *models.CreatePullRequest) (*models.PullRequest, error) {
-	exitstPR, err := s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), pr.ID)
+	if strings.Contains(pr.Repository, "#") {
+		return nil, utils.NewBadRequestError("repository name cannot contain #", nil)
+	}
+	// id with # of the default repository would be the same as global id of PR in other repository
+	if strings.Contains(pr.ID, "#") {
+		return nil, utils.NewBadRequestError("pull_request_id cannot contain #", nil)
+	}
+	prID := models.PullRequestKey(pr.Repository, pr.ID)
+
+	exitstPR, err := s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), prID)
 	if err == nil && exitstPR != nil {
 		return nil, utils.NewError(409, utils.ErrPrExists, "PR id already exists", nil)
 	}
@@ -32,21 +48,64 @@ func (s *PRService) CreatePR(ctx context.Context, pr *models.CreatePullRequest)
 		return nil, fmt.Errorf("CreatePR: unable to get pull request by ID: %v", err)
 	}
 	newPr := &models.PullRequest{
-		ID:        pr.ID,
-		Name:      pr.Name,
-		AuthorID:  pr.AuthorID,
-		CreatedAt: time.Now(),
+		ID:         prID,
+		Name:       pr.Name,
+		AuthorID:   pr.AuthorID,
+		Status:     models.PullRequestStatusOpen,
+		CreatedAt:  time.Now(),
+		Repository: models.DefaultRepository,
+		Number:     pr.ID,
+		TeamName:   pr.TeamName,
+	}
+	if pr.Repository != "" {
+		newPr.Repository = pr.Repository
 	}
+	if pr.Draft {
+		newPr.Status = models.PullRequestStatusDraft
+	}
+	labels := utils.NormalizeTags(pr.Labels)
 
+	var selection *reviewersSelection
+	var dependsOn []string
 	err = s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
-		// get active team members of PR author to assign as reviewers
-		activeAuthorsTeamMembers, err := s.store.TeamRepo().GetUsersIDFromUserTeam(ctx, exec, pr.AuthorID, 2)
+		// new PR has no dependents, its dependencies can not make a cycle
+		dependsOn, err = s.validateDependencies(ctx, exec, prID, pr.DependsOn)
 		if err != nil {
-			if err == sql.ErrNoRows {
-				// user with
-				return utils.NewNotFoundError("resource not found", nil)
+			return err
+		}
+		if err := s.checkAuthorTeam(ctx, exec, pr); err != nil {
+			return err
+		}
+
+		var reviewers []string
+		// draft PR gets reviewers when it is marked ready for review
+		if pr.Draft {
+			if _, err := s.store.UserRepo().GetUserByID(ctx, exec, pr.AuthorID); err != nil {
+				if err == sql.ErrNoRows {
+					return utils.NewNotFoundError("resource not found", nil)
+				}
+				return fmt.Errorf("CreatePR: unable to get PR author: %v", err)
+			}
+		} else {
+			if err := s.store.PRRepo().LockAssignments(ctx, exec); err != nil {
+				return fmt.Errorf("CreatePR: unable to lock assignments: %v", err)
+			}
+
+			selection, err = s.selectReviewers(ctx, exec, pr, labels)
+			if err != nil {
+				return err
+			}
+			if selection.understaffed() && selection.settings.UnderstaffedPolicy == models.UnderstaffedPolicyFail {
+				return utils.NewError(409, utils.ErrNotEnoughReviewers, "not enough active reviewers in team", nil)
+			}
+			reviewers = selection.reviewers
+		}
+
+		// PRs of unknown repository register it without owner team
+		if pr.Repository != "" {
+			if err := s.store.OwnershipRepo().CreateRepository(ctx, exec, pr.Repository); err != nil {
+				return fmt.Errorf("CreatePR: unable to create repository: %v", err)
 			}
-			return fmt.Errorf("CreatePR: unable to get active team members of PR author: %v", err)
 		}
 
 		// create PR
@@ -54,12 +113,38 @@ func (s *PRService) CreatePR(ctx context.Context, pr *models.CreatePullRequest)
 			return fmt.Errorf("CreatePR: unable to create PR: %v", err)
 		}
 
+		if len(labels) > 0 {
+			if err := s.store.PRRepo().SetPullRequestLabels(ctx, exec, prID, labels); err != nil {
+				return fmt.Errorf("CreatePR: unable to set PR labels: %v", err)
+			}
+		}
+
+		if len(dependsOn) > 0 {
+			if err := s.store.PRRepo().SetPullRequestDependencies(ctx, exec, prID, dependsOn); err != nil {
+				return fmt.Errorf("CreatePR: unable to set PR dependencies: %v", err)
+			}
+		}
+
 		// assign only if there are active members in author's team
-		if len(activeAuthorsTeamMembers) > 0 {
-			if err := s.store.PRRepo().AssignManyReviewers(ctx, exec, pr.ID, activeAuthorsTeamMembers); err != nil {
+		if len(reviewers) > 0 {
+			if err := s.store.PRRepo().AssignManyReviewers(ctx, exec, prID, reviewers); err != nil {
 				return fmt.Errorf("CreatePR: unable to assign reviewers to PR: %v", err)
 			}
 		}
+
+		created := newEvent(ctx, prID, models.PullRequestEventCreated, pr.AuthorID, models.PullRequestCreatedPayload{
+			Name:     pr.Name,
+			AuthorID: pr.AuthorID,
+			Status:   newPr.Status,
+			Labels:   labels,
+		})
+		events := append([]models.PullRequestEvent{created}, assignedEvents(ctx, prID, reviewers, models.AssignmentReasonAuto)...)
+		if len(dependsOn) > 0 {
+			events = append(events, newEvent(ctx, prID, models.PullRequestEventDependenciesChanged, pr.AuthorID, models.DependenciesChangedPayload{DependsOn: dependsOn}))
+		}
+		if err := s.recordEvents(ctx, exec, events...); err != nil {
+			return fmt.Errorf("CreatePR: %v", err)
+		}
 		return nil
 	})
 
@@ -68,32 +153,164 @@ func (s *PRService) CreatePR(ctx context.Context, pr *models.CreatePullRequest)
 	}
 
 	// receive created PR
-	createdPR, err := s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), pr.ID)
+	createdPR, err := s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), prID)
 	if err != nil {
 		return nil, fmt.Errorf("CreatePR: unable to get created PR: %v", err)
 	}
+	if len(dependsOn) > 0 {
+		createdPR.Dependencies, err = s.store.PRRepo().GetDependencyGraph(ctx, s.store.DB(), prID)
+		if err != nil {
+			return nil, fmt.Errorf("CreatePR: unable to get PR dependencies: %v", err)
+		}
+	}
+	if selection != nil {
+		createdPR.Understaffed = selection.understaffed()
+		createdPR.MatchedLabels = selection.matchedLabels
+	}
 	return createdPR, nil
 }
 
-func (s *PRService) MergePR(ctx context.Context, prID string) (*models.PullRequest, error) {
+// PreviewAssignment runs reviewers selection of CreatePR for the author without creating PR,
+// selector state (round robin position) is not moved
+func (s *PRService) PreviewAssignment(ctx context.Context, pr *models.CreatePullRequest) (*models.AssignmentPreview, error) {
+	preview := &PRService{
+		store:    s.store,
+		selector: previewSelector{selector: s.selector},
+		trace:    &assignmentTrace{seen: make(map[string]bool)},
+	}
+	labels := utils.NormalizeTags(pr.Labels)
+
+	if err := s.checkAuthorTeam(ctx, s.store.DB(), pr); err != nil {
+		return nil, err
+	}
+	selection, err := preview.selectReviewers(ctx, s.store.DB(), pr, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := preview.trace.candidates
+	for i := range candidates {
+		if isUserIDInReviewers(candidates[i].UserID, selection.reviewers) {
+			candidates[i].Selected = true
+			candidates[i].ExcludedReason = ""
+		}
+	}
+
+	return &models.AssignmentPreview{
+		AuthorID:           selection.author.UserID,
+		TeamName:           selection.teamName,
+		Labels:             labels,
+		Candidates:         candidates,
+		Reviewers:          selection.reviewers,
+		Understaffed:       selection.understaffed(),
+		UnderstaffedPolicy: selection.settings.UnderstaffedPolicy,
+		MatchedLabels:      selection.matchedLabels,
+	}, nil
+}
+
+// SetLabels replaces labels of OPEN or DRAFT PR, already assigned reviewers are kept
+func (s *PRService) SetLabels(ctx context.Context, prID string, labels []string) (*models.PullRequest, error) {
 	pr, err := s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), prID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, utils.NewNotFoundError("resource not found", nil)
 		}
+		return nil, fmt.Errorf("SetLabels: unable to get PR: %v", err)
 	}
 
-	// pr alredy merged not merge it twice
 	if pr.Status == models.PullRequestStatusMerged {
-		return pr, nil
+		return nil, utils.NewError(409, utils.ErrPrAlredyMerged, "cannot change labels on merged PR", nil)
+	}
+	if pr.Status == models.PullRequestStatusClosed {
+		return nil, utils.NewError(409, utils.ErrPrNotOpen, "cannot change labels on closed PR", nil)
+	}
+
+	labels = utils.NormalizeTags(labels)
+	err = s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		if err := s.store.PRRepo().SetPullRequestLabels(ctx, exec, prID, labels); err != nil {
+			return fmt.Errorf("SetLabels: unable to set PR labels: %v", err)
+		}
+
+		changed := newEvent(ctx, prID, models.PullRequestEventLabelsChanged, "", models.LabelsChangedPayload{Labels: labels})
+		if err := s.recordEvents(ctx, exec, changed); err != nil {
+			return fmt.Errorf("SetLabels: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	err = s.store.PRRepo().MergePullRequest(ctx, s.store.DB(), prID)
+	updatedPR, err := s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), prID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, utils.NewNotFoundError("resource not found", nil)
+		return nil, fmt.Errorf("SetLabels: unable to get updated PR: %v", err)
+	}
+	return updatedPR, nil
+}
+
+// MergePR merges PR if it meets merge policy of author team and all its dependencies are merged,
+// override merges it regardless of the policy (not of dependencies) and is recorded on the PR
+func (s *PRService) MergePR(ctx context.Context, prID string, override bool) (*models.PullRequest, error) {
+	var mergedPR *models.PullRequest
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		// dependencies can not be changed until the merge is committed
+		if err := s.store.PRRepo().LockDependencies(ctx, exec); err != nil {
+			return fmt.Errorf("MergePR: unable to lock dependencies: %v", err)
+		}
+		// reviewers and reviews can not be changed while the policy is checked
+		if err := s.store.PRRepo().LockAssignments(ctx, exec); err != nil {
+			return fmt.Errorf("MergePR: unable to lock assignments: %v", err)
+		}
+
+		pr, err := s.store.PRRepo().GetPullRequestByID(ctx, exec, prID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return utils.NewNotFoundError("resource not found", nil)
+			}
+			return fmt.Errorf("MergePR: unable to get PR: %v", err)
+		}
+
+		// pr alredy merged not merge it twice
+		if pr.Status == models.PullRequestStatusMerged {
+			mergedPR = pr
+			return nil
+		}
+		if pr.Status != models.PullRequestStatusOpen {
+			return statusTransitionError(pr, "merge")
+		}
+
+		if !override {
+			if err := s.checkMergePolicy(ctx, exec, pr); err != nil {
+				return err
+			}
 		}
-		return nil, fmt.Errorf("MergePR: unable to merge PR: %v", err)
+		if err := s.checkDependenciesMerged(ctx, exec, prID); err != nil {
+			return err
+		}
+
+		if err := s.store.PRRepo().MergePullRequest(ctx, exec, prID, override); err != nil {
+			// PR was closed or converted to draft by concurrent request
+			if err == sql.ErrNoRows {
+				return utils.NewError(409, utils.ErrInvalidTransition, "PR status was changed concurrently", nil)
+			}
+			return fmt.Errorf("MergePR: unable to merge PR: %v", err)
+		}
+
+		merged := newEvent(ctx, prID, models.PullRequestEventStatusChanged, "", models.StatusChangedPayload{
+			From:     pr.Status,
+			To:       models.PullRequestStatusMerged,
+			Override: override,
+		})
+		if err := s.recordEvents(ctx, exec, merged); err != nil {
+			return fmt.Errorf("MergePR: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if mergedPR != nil {
+		return mergedPR, nil
 	}
 
 	updatedPR, err := s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), prID)
@@ -104,61 +321,314 @@ func (s *PRService) MergePR(ctx context.Context, prID string) (*models.PullReque
 	return updatedPR, nil
 }
 
-func (s *PRService) ReassignPR(ctx context.Context, prID string, oldReviewerID string) (*models.ReassignPullRequestResponse, error) {
-	pr, err := s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), prID)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, utils.NewNotFoundError("resource not found", nil)
+// AddReviewer assigns the user to OPEN PR, user must be active, not the author,
+// below own limit of open reviews and PR must have less reviewers than review team maximum
+// unless the user is required approver of the merge policy
+func (s *PRService) AddReviewer(ctx context.Context, prID, userID string) (*models.PullRequest, error) {
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		if err := s.store.PRRepo().LockAssignments(ctx, exec); err != nil {
+			return fmt.Errorf("AddReviewer: unable to lock assignments: %v", err)
+		}
+
+		pr, err := s.getOpenPR(ctx, exec, prID)
+		if err != nil {
+			return err
+		}
+		if userID == pr.AuthorID {
+			return utils.NewBadRequestError("author cannot review own PR", nil)
+		}
+		if isUserIDInReviewers(userID, pr.AssignedReviewers) {
+			return utils.NewError(409, utils.ErrAlreadyAssigned, "reviewer is already assigned to this PR", nil)
+		}
+
+		candidates, err := s.store.TeamRepo().GetReviewCandidatesByUserIDs(ctx, exec, []string{userID})
+		if err != nil {
+			return fmt.Errorf("AddReviewer: unable to get user: %v", err)
 		}
+		if len(candidates) == 0 {
+			return utils.NewNotFoundError("resource not found", nil)
+		}
+		if !candidates[0].IsActive {
+			return utils.NewError(409, utils.ErrUserInactive, "user is not active", nil)
+		}
+		if candidates[0].OverCapacity() {
+			return utils.NewError(409, utils.ErrReviewLimit, "user reached the limit of open reviews", nil)
+		}
+
+		author, err := s.store.UserRepo().GetUserByID(ctx, exec, pr.AuthorID)
+		if err != nil {
+			return fmt.Errorf("AddReviewer: unable to get PR author: %v", err)
+		}
+		teamName, err := s.reviewTeam(ctx, exec, pr.TeamName, pr.Repository, author)
+		if err != nil {
+			return fmt.Errorf("AddReviewer: %v", err)
+		}
+		settings, err := s.store.TeamRepo().GetTeamSettings(ctx, exec, teamName)
+		if err != nil {
+			return fmt.Errorf("AddReviewer: unable to get team settings: %v", err)
+		}
+		if len(pr.AssignedReviewers) >= settings.MaxReviewers {
+			// merge policy could never be met without the required approvers, they are not limited
+			policy, err := s.mergePolicy(ctx, exec, pr)
+			if err != nil {
+				return fmt.Errorf("AddReviewer: %v", err)
+			}
+			if !slices.Contains(policy.RequiredApprovers, userID) {
+				return utils.NewError(409, utils.ErrTooManyReviewers, "PR already has max reviewers of the team", nil)
+			}
+		}
+
+		if err := s.store.PRRepo().AssignReviewer(ctx, exec, prID, userID); err != nil {
+			return fmt.Errorf("AddReviewer: unable to assign reviewer: %v", err)
+		}
+		if err := s.recordEvents(ctx, exec, assignedEvents(ctx, prID, []string{userID}, models.AssignmentReasonManual)...); err != nil {
+			return fmt.Errorf("AddReviewer: %v", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
-	// pr alredy merged not merge
-	if pr.Status == models.PullRequestStatusMerged {
-		return nil, utils.NewError(409, utils.ErrPrAlredyMerged, "cannot reassign on merged PR", nil)
+
+	updatedPR, err := s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), prID)
+	if err != nil {
+		return nil, fmt.Errorf("AddReviewer: unable to get updated PR: %v", err)
 	}
+	return updatedPR, nil
+}
+
+// RemoveReviewer removes the reviewer from OPEN PR without replacement
+func (s *PRService) RemoveReviewer(ctx context.Context, prID, userID string) (*models.PullRequest, error) {
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		// merge evaluates the reviewers under the same lock
+		if err := s.store.PRRepo().LockAssignments(ctx, exec); err != nil {
+			return fmt.Errorf("RemoveReviewer: unable to lock assignments: %v", err)
+		}
 
-	// user not reviewer
-	if !isUserIDInReviewers(oldReviewerID, pr.AssignedReviewers) {
-		return nil, utils.NewError(409, utils.ErrUserNotReviewer, "reviewer is not assigned to this PR", nil)
+		if _, err := s.getOpenPR(ctx, exec, prID); err != nil {
+			return err
+		}
+
+		if err := s.store.PRRepo().DeleteAssignedReviewer(ctx, exec, prID, userID); err != nil {
+			if err == sql.ErrNoRows {
+				return utils.NewError(409, utils.ErrUserNotReviewer, "reviewer is not assigned to this PR", nil)
+			}
+			return fmt.Errorf("RemoveReviewer: unable to delete reviewer: %v", err)
+		}
+
+		removed := newEvent(ctx, prID, models.PullRequestEventReviewerRemoved, "", models.ReviewerPayload{
+			ReviewerID: userID,
+			Reason:     models.AssignmentReasonManual,
+		})
+		if err := s.recordEvents(ctx, exec, removed); err != nil {
+			return fmt.Errorf("RemoveReviewer: %v", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	updatedPR, err := s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), prID)
+	if err != nil {
+		return nil, fmt.Errorf("RemoveReviewer: unable to get updated PR: %v", err)
 	}
+	return updatedPR, nil
+}
 
-	// get active users from author team without oldest
-	newActiveUsers, err := s.store.TeamRepo().GetActiveUsersTeamWithException(ctx, s.store.DB(), pr.AuthorID, pr.AssignedReviewers, 1)
+// getOpenPR returns PR which reviewers can be changed
+func (s *PRService) getOpenPR(ctx context.Context, exec sqlx.ExtContext, prID string) (*models.PullRequest, error) {
+	pr, err := s.store.PRRepo().GetPullRequestByID(ctx, exec, prID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, utils.NewError(409, utils.ErrNoCantidate, "no active replacement candidate in team", nil)
+			return nil, utils.NewNotFoundError("resource not found", nil)
 		}
-		return nil, err
+		return nil, fmt.Errorf("unable to get PR: %v", err)
+	}
+	if pr.Status == models.PullRequestStatusMerged {
+		return nil, utils.NewError(409, utils.ErrPrAlredyMerged, "cannot change reviewers of merged PR", nil)
+	}
+	if pr.Status != models.PullRequestStatusOpen {
+		return nil, utils.NewError(409, utils.ErrPrNotOpen, fmt.Sprintf("cannot change reviewers of %s PR", pr.Status), nil)
 	}
+	return pr, nil
+}
 
-	err = s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
-		// delete old
-		txErr := s.store.PRRepo().DeleteAssignedByReviewerID(ctx, exec, oldReviewerID)
-		if err != nil {
+// ReassignPR replaces the reviewer of OPEN PR with another member of review team, PR is checked after
+// assignments lock is taken, so concurrent close or reassign is not missed
+func (s *PRService) ReassignPR(ctx context.Context, prID string, oldReviewerID string) (*models.ReassignPullRequestResponse, error) {
+	var newReviewerID string
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		if txErr := s.store.PRRepo().LockAssignments(ctx, exec); txErr != nil {
+			return fmt.Errorf("ReassignPR: unable to lock assignments: %v", txErr)
+		}
+
+		pr, txErr := s.getOpenPR(ctx, exec, prID)
+		if txErr != nil {
 			return txErr
 		}
-		txErr = s.store.PRRepo().AssignReviewer(ctx, exec, prID, newActiveUsers[0])
-		if err != nil {
+		// user not reviewer
+		if !isUserIDInReviewers(oldReviewerID, pr.AssignedReviewers) {
+			return utils.NewError(409, utils.ErrUserNotReviewer, "reviewer is not assigned to this PR", nil)
+		}
+
+		newReviewerID, txErr = s.replaceReviewer(ctx, exec, pr, oldReviewerID, models.AssignmentReasonManual)
+		if txErr != nil {
 			return txErr
 		}
-		return txErr
+		if newReviewerID == "" {
+			return utils.NewError(409, utils.ErrNoCantidate, "no active replacement candidate in team", nil)
+		}
+		return nil
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	pr, err = s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), prID)
+	pr, err := s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), prID)
 	if err != nil {
 		return nil, err
 	}
 	return &models.ReassignPullRequestResponse{
 		PR:        *pr,
-		RepacedBy: newActiveUsers[0],
+		RepacedBy: newReviewerID,
 	}, nil
 }
 
-func (s *PRService) Statistics(ctx context.Context) ([]models.PullRequestQuantityReviewers, error) {
-	return s.store.PRRepo().GetQuantityPRReviewers(ctx, s.store.DB())
+// ReassignOpenReviews moves all OPEN reviews of the reviewer to other eligible members by ReassignPR rules,
+// PRs without replacement candidate keep the reviewer and are reported as not reassigned.
+// It runs in the caller transaction which has to hold exclusive assignments lock.
+func (s *PRService) ReassignOpenReviews(ctx context.Context, exec sqlx.ExtContext, reviewerID string) (*models.ReviewsReassignment, error) {
+	res, err := s.reassignOpenReviews(ctx, exec, reviewerID, "", models.AssignmentReasonReviewerDeactivated)
+	if err != nil {
+		return nil, fmt.Errorf("ReassignOpenReviews: %w", err)
+	}
+	return res, nil
+}
+
+// ReassignTeamReviews moves OPEN reviews of the reviewer on PRs reviewed by the team as ReassignOpenReviews does,
+// reviews of PRs of other teams are kept. It runs in the caller transaction which has to hold exclusive assignments lock.
+func (s *PRService) ReassignTeamReviews(ctx context.Context, exec sqlx.ExtContext, teamName, reviewerID string, reason models.AssignmentReason) (*models.ReviewsReassignment, error) {
+	res, err := s.reassignOpenReviews(ctx, exec, reviewerID, teamName, reason)
+	if err != nil {
+		return nil, fmt.Errorf("ReassignTeamReviews: %w", err)
+	}
+	return res, nil
+}
+
+// MoveToTeam makes the team review the PRs and replaces their reviewers who are not members of the team
+// with ReassignPR rules, reviewers without replacement stay assigned. It runs in the caller transaction
+// which has to hold exclusive assignments lock.
+func (s *PRService) MoveToTeam(ctx context.Context, exec sqlx.ExtContext, prIDs []string, teamName string) (*models.BulkReviewsReassignment, error) {
+	res := &models.BulkReviewsReassignment{
+		Reassigned:    make([]models.ReviewerReplacement, 0),
+		NotReassigned: make([]models.OpenReview, 0),
+	}
+	if len(prIDs) == 0 {
+		return res, nil
+	}
+
+	if err := s.store.PRRepo().SetPullRequestsTeam(ctx, exec, prIDs, teamName); err != nil {
+		return nil, fmt.Errorf("MoveToTeam: unable to set team of PRs: %v", err)
+	}
+
+	candidates, err := s.store.TeamRepo().GetTeamReviewCandidates(ctx, exec, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("MoveToTeam: unable to get team members: %v", err)
+	}
+	members := make(map[string]bool, len(candidates))
+	for _, candidate := range candidates {
+		members[candidate.UserID] = true
+	}
+
+	for _, prID := range prIDs {
+		pr, err := s.store.PRRepo().GetPullRequestByID(ctx, exec, prID)
+		if err != nil {
+			return nil, fmt.Errorf("MoveToTeam: unable to get PR %s: %v", prID, err)
+		}
+
+		reviewers := append([]string(nil), pr.AssignedReviewers...)
+		for _, reviewerID := range reviewers {
+			if members[reviewerID] {
+				continue
+			}
+
+			newReviewerID, err := s.replaceReviewer(ctx, exec, pr, reviewerID, models.AssignmentReasonTeamArchived)
+			if err != nil {
+				return nil, fmt.Errorf("MoveToTeam: PR %s: %w", prID, err)
+			}
+			if newReviewerID == "" {
+				res.NotReassigned = append(res.NotReassigned, models.OpenReview{PullRequestID: prID, ReviewerID: reviewerID})
+				continue
+			}
+			// replaced reviewer stays in the list, so the next replacement does not pick the new one
+			pr.AssignedReviewers = append(pr.AssignedReviewers, newReviewerID)
+			res.Reassigned = append(res.Reassigned, models.ReviewerReplacement{
+				PullRequestID: prID,
+				OldReviewerID: reviewerID,
+				NewReviewerID: newReviewerID,
+			})
+		}
+	}
+	return res, nil
+}
+
+// reassignOpenReviews replaces the reviewer in OPEN PRs with ReassignPR rules,
+// only PRs reviewed by the team are changed unless team name is empty
+func (s *PRService) reassignOpenReviews(ctx context.Context, exec sqlx.ExtContext, reviewerID, teamName string, reason models.AssignmentReason) (*models.ReviewsReassignment, error) {
+	prIDs, err := s.store.PRRepo().GetOpenPullRequestIDsByReviewer(ctx, exec, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get reviewer PRs: %v", err)
+	}
+
+	res := &models.ReviewsReassignment{
+		Reassigned:    make([]models.ReviewerReplacement, 0),
+		NotReassigned: make([]string, 0),
+	}
+	for _, prID := range prIDs {
+		pr, err := s.store.PRRepo().GetPullRequestByID(ctx, exec, prID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get PR %s: %v", prID, err)
+		}
+
+		if teamName != "" {
+			author, err := s.store.UserRepo().GetUserByID(ctx, exec, pr.AuthorID)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get author of PR %s: %v", prID, err)
+			}
+			reviewTeam, err := s.reviewTeam(ctx, exec, pr.TeamName, pr.Repository, author)
+			if err != nil {
+				return nil, fmt.Errorf("PR %s: %v", prID, err)
+			}
+			if reviewTeam != teamName {
+				continue
+			}
+		}
+
+		newReviewerID, err := s.replaceReviewer(ctx, exec, pr, reviewerID, reason)
+		if err != nil {
+			return nil, fmt.Errorf("PR %s: %w", prID, err)
+		}
+
+		if newReviewerID == "" {
+			res.NotReassigned = append(res.NotReassigned, prID)
+			continue
+		}
+		res.Reassigned = append(res.Reassigned, models.ReviewerReplacement{
+			PullRequestID: prID,
+			OldReviewerID: reviewerID,
+			NewReviewerID: newReviewerID,
+		})
+	}
+	return res, nil
+}
+
+// Statistics counts reviewers of PRs in the repository, empty repository counts all PRs
+func (s *PRService) Statistics(ctx context.Context, repository string) ([]models.PullRequestQuantityReviewers, error) {
+	return s.store.PRRepo().GetQuantityPRReviewers(ctx, s.store.DB(), repository)
 
 }
 
@@ -170,3 +640,431 @@ func isUserIDInReviewers(userID string, reviewers []string) bool {
 	}
 	return false
 }
+
+// replaceReviewer replaces old reviewer of the PR with member of review team or its fallback teams
+// and records the replacement with the reason, returns empty id without changes if there is no candidate
+func (s *PRService) replaceReviewer(ctx context.Context, exec sqlx.ExtContext, pr *models.PullRequest, oldReviewerID string, reason models.AssignmentReason) (string, error) {
+	newReviewers, err := s.selectAdditionalReviewer(ctx, exec, pr)
+	if err != nil {
+		return "", err
+	}
+	if len(newReviewers) == 0 {
+		return "", nil
+	}
+
+	if err := s.store.PRRepo().DeleteAssignedReviewer(ctx, exec, pr.ID, oldReviewerID); err != nil {
+		if err == sql.ErrNoRows {
+			return "", utils.NewError(409, utils.ErrUserNotReviewer, "reviewer is not assigned to this PR", nil)
+		}
+		return "", fmt.Errorf("unable to delete old reviewer: %v", err)
+	}
+	if err := s.store.PRRepo().AssignReviewer(ctx, exec, pr.ID, newReviewers[0]); err != nil {
+		return "", fmt.Errorf("unable to assign new reviewer: %v", err)
+	}
+
+	replacement := models.ReviewerReplacement{PullRequestID: pr.ID, OldReviewerID: oldReviewerID, NewReviewerID: newReviewers[0]}
+	if err := s.recordEvents(ctx, exec, replacedEvents(ctx, []models.ReviewerReplacement{replacement}, reason)...); err != nil {
+		return "", err
+	}
+	return newReviewers[0], nil
+}
+
+// selectAdditionalReviewer picks one reviewer of PR review team who is not assigned to it yet,
+// team ancestors and then fallback teams are used if review team has no one
+func (s *PRService) selectAdditionalReviewer(ctx context.Context, exec sqlx.ExtContext, pr *models.PullRequest) ([]string, error) {
+	author, err := s.store.UserRepo().GetUserByID(ctx, exec, pr.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get PR author: %v", err)
+	}
+	teamName, err := s.reviewTeam(ctx, exec, pr.TeamName, pr.Repository, author)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers, err := s.selectFromTeam(ctx, exec, teamName, author.UserID, pr.Labels, pr.AssignedReviewers, 1)
+	if err != nil {
+		return nil, fmt.Errorf("unable to select reviewer: %v", err)
+	}
+	if len(reviewers) > 0 {
+		return reviewers, nil
+	}
+
+	// no one in review team, try the hierarchy and then teams which help it with reviews
+	settings, err := s.store.TeamRepo().GetTeamSettings(ctx, exec, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get team settings: %v", err)
+	}
+	reviewers, err = s.selectFromAncestors(ctx, exec, settings, author.UserID, pr.Labels, pr.AssignedReviewers, 1)
+	if err != nil {
+		return nil, fmt.Errorf("unable to select reviewer from parent teams: %v", err)
+	}
+	if len(reviewers) > 0 {
+		return reviewers, nil
+	}
+
+	if settings.UnderstaffedPolicy != models.UnderstaffedPolicyFallback {
+		return reviewers, nil
+	}
+	reviewers, err = s.selectFromFallbacks(ctx, exec, settings, author.UserID, pr.Labels, pr.AssignedReviewers, 1)
+	if err != nil {
+		return nil, fmt.Errorf("unable to select fallback reviewer: %v", err)
+	}
+	return reviewers, nil
+}
+
+// reviewTeam returns team which reviews PR: author team chosen on PR creation, owner team of PR repository
+// or primary team of the author if the repository has no owner
+func (s *PRService) reviewTeam(ctx context.Context, exec sqlx.ExtContext, teamName, repository string, author *models.User) (string, error) {
+	if teamName != "" {
+		return teamName, nil
+	}
+	if repository == "" {
+		return author.TeamName, nil
+	}
+
+	repo, err := s.store.OwnershipRepo().GetRepository(ctx, exec, repository)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return author.TeamName, nil
+		}
+		return "", fmt.Errorf("unable to get repository: %v", err)
+	}
+	if repo.TeamName == "" {
+		return author.TeamName, nil
+	}
+	return repo.TeamName, nil
+}
+
+// checkAuthorTeam checks that the author is a member of the team chosen for a new PR and the team is not archived
+func (s *PRService) checkAuthorTeam(ctx context.Context, exec sqlx.ExtContext, pr *models.CreatePullRequest) error {
+	if pr.TeamName == "" {
+		return nil
+	}
+
+	if _, err := s.store.TeamRepo().GetMembership(ctx, exec, pr.TeamName, pr.AuthorID); err != nil {
+		if err == sql.ErrNoRows {
+			return utils.NewNotFoundError("author is not a member of the team", nil)
+		}
+		return fmt.Errorf("unable to get author membership: %v", err)
+	}
+
+	settings, err := s.store.TeamRepo().GetTeamSettings(ctx, exec, pr.TeamName)
+	if err != nil {
+		return fmt.Errorf("unable to get team settings: %v", err)
+	}
+	if settings.ArchivedAt != nil {
+		return utils.NewError(409, utils.ErrTeamArchived, "team is archived", pr.TeamName)
+	}
+	return nil
+}
+
+// reviewersSelection is a result of reviewers selection for a new PR
+type reviewersSelection struct {
+	author *models.User
+	// team which members review the PR
+	teamName      string
+	settings      *models.TeamSettings
+	reviewers     []string
+	matchedLabels map[string]string
+}
+
+func (sel *reviewersSelection) understaffed() bool {
+	return len(sel.reviewers) < sel.settings.MinReviewers
+}
+
+// selectReviewers picks reviewers for a new PR without changing anything: code owners first,
+// then members of review team (chosen author team, repository owner or primary author team) and fallback teams members by team settings
+func (s *PRService) selectReviewers(ctx context.Context, exec sqlx.ExtContext, pr *models.CreatePullRequest, labels []string) (*reviewersSelection, error) {
+	author, err := s.store.UserRepo().GetUserByID(ctx, exec, pr.AuthorID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NewNotFoundError("resource not found", nil)
+		}
+		return nil, fmt.Errorf("unable to get PR author: %v", err)
+	}
+
+	teamName, err := s.reviewTeam(ctx, exec, pr.TeamName, pr.Repository, author)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.store.TeamRepo().GetTeamSettings(ctx, exec, teamName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NewNotFoundError("resource not found", nil)
+		}
+		return nil, fmt.Errorf("unable to get team settings: %v", err)
+	}
+	// archived team has no one to review, e.g. primary team of the author was archived
+	if settings.ArchivedAt != nil {
+		return nil, utils.NewError(409, utils.ErrTeamArchived, "review team is archived", teamName)
+	}
+
+	// owners of changed files take the first slots
+	owners, err := s.selectOwners(ctx, exec, pr, author.UserID, labels, settings.MaxReviewers)
+	if err != nil {
+		return nil, fmt.Errorf("unable to select code owners: %v", err)
+	}
+
+	// select active members of review team to assign as reviewers
+	reviewers, err := s.assignReviewers(ctx, exec, teamName, author.UserID, settings, labels, owners)
+	if err != nil {
+		return nil, err
+	}
+
+	matchedLabels, err := s.matchLabels(ctx, exec, reviewers, labels)
+	if err != nil {
+		return nil, fmt.Errorf("unable to match reviewers skills: %v", err)
+	}
+
+	return &reviewersSelection{
+		author:        author,
+		teamName:      teamName,
+		settings:      settings,
+		reviewers:     reviewers,
+		matchedLabels: matchedLabels,
+	}, nil
+}
+
+// assignReviewers selects reviewers for a new PR of author from the team according to its settings,
+// already selected reviewers are kept and team members only fill the remaining slots.
+// Team without anyone to review is helped by its ancestors, with FALLBACK policy fallback teams fill the rest up to max_reviewers
+func (s *PRService) assignReviewers(ctx context.Context, exec sqlx.ExtContext, teamName, authorID string, settings *models.TeamSettings, labels, selected []string) ([]string, error) {
+	teamReviewers, err := s.selectFromTeam(ctx, exec, teamName, authorID, labels, selected, settings.MaxReviewers-len(selected))
+	if err != nil {
+		return nil, fmt.Errorf("unable to select reviewers: %v", err)
+	}
+	if len(teamReviewers) == 0 {
+		teamReviewers, err = s.selectFromAncestors(ctx, exec, settings, authorID, labels, selected, settings.MaxReviewers-len(selected))
+		if err != nil {
+			return nil, fmt.Errorf("unable to select reviewers from parent teams: %v", err)
+		}
+	}
+	reviewers := slices.Concat(selected, teamReviewers)
+
+	if settings.UnderstaffedPolicy != models.UnderstaffedPolicyFallback {
+		// FAIL or ASSIGN_FEWER is applied by the caller
+		return reviewers, nil
+	}
+
+	fallbackReviewers, err := s.selectFromFallbacks(ctx, exec, settings, authorID, labels, reviewers, settings.MaxReviewers-len(reviewers))
+	if err != nil {
+		return nil, fmt.Errorf("unable to select fallback reviewers: %v", err)
+	}
+	return append(reviewers, fallbackReviewers...), nil
+}
+
+// selectOwners picks up to n reviewers from CODEOWNERS owners of the changed files in order of rules matching,
+// user owners are taken if they are eligible and every owner team gives one of its members
+func (s *PRService) selectOwners(ctx context.Context, exec sqlx.ExtContext, pr *models.CreatePullRequest, authorID string, labels []string, n int) ([]string, error) {
+	if pr.Repository == "" || len(pr.ChangedFiles) == 0 || n <= 0 {
+		return []string{}, nil
+	}
+
+	saved, err := s.store.OwnershipRepo().GetCodeowners(ctx, exec, pr.Repository)
+	if err != nil {
+		// repository without rules
+		if err == sql.ErrNoRows {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	rules, err := codeowners.Parse(saved.Content)
+	if err != nil {
+		return nil, err
+	}
+	owners := codeowners.Owners(rules, pr.ChangedFiles)
+
+	var userIDs []string
+	for _, owner := range owners {
+		if userID, _ := codeowners.ParseOwner(owner); userID != "" {
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	eligible := make(map[string]bool, len(userIDs))
+	if len(userIDs) > 0 {
+		candidates, err := s.store.TeamRepo().GetReviewCandidatesByUserIDs(ctx, exec, userIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range candidates {
+			eligible[candidate.UserID] = exclusionReason(candidate, authorID, nil) == ""
+		}
+		s.trace.add(candidates, authorID, nil)
+	}
+
+	res := make([]string, 0, n)
+	for _, owner := range owners {
+		if len(res) >= n {
+			break
+		}
+
+		userID, teamName := codeowners.ParseOwner(owner)
+		if teamName != "" {
+			member, err := s.selectFromTeam(ctx, exec, teamName, authorID, labels, res, 1)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, member...)
+			continue
+		}
+
+		if eligible[userID] && !isUserIDInReviewers(userID, res) {
+			res = append(res, userID)
+		}
+	}
+	return res, nil
+}
+
+// selectFromAncestors picks up to n reviewers from the subtree of the nearest team ancestor
+// which has eligible members, so a team is helped by its sibling teams before the whole department
+func (s *PRService) selectFromAncestors(ctx context.Context, exec sqlx.ExtContext, settings *models.TeamSettings, authorID string, labels, exclude []string, n int) ([]string, error) {
+	if n <= 0 {
+		return []string{}, nil
+	}
+
+	visited := map[string]bool{settings.TeamName: true}
+	for parent := settings.ParentTeam; parent != "" && !visited[parent]; {
+		visited[parent] = true
+
+		candidates, err := s.store.TeamRepo().GetSubtreeReviewCandidates(ctx, exec, parent)
+		if err != nil {
+			return nil, err
+		}
+		s.trace.add(candidates, authorID, exclude)
+
+		if reviewers := s.pickReviewers(parent, eligibleCandidates(candidates, authorID, exclude), labels, n); len(reviewers) > 0 {
+			return reviewers, nil
+		}
+
+		parentSettings, err := s.store.TeamRepo().GetTeamSettings(ctx, exec, parent)
+		if err != nil {
+			return nil, err
+		}
+		parent = parentSettings.ParentTeam
+	}
+	return []string{}, nil
+}
+
+// selectFromFallbacks fills up to n reviewers from fallback teams in their order
+func (s *PRService) selectFromFallbacks(ctx context.Context, exec sqlx.ExtContext, settings *models.TeamSettings, authorID string, labels, exclude []string, n int) ([]string, error) {
+	if n <= 0 {
+		return []string{}, nil
+	}
+
+	res := make([]string, 0, n)
+	for _, fallbackTeam := range settings.FallbackTeams {
+		if len(res) >= n {
+			break
+		}
+
+		reviewers, err := s.selectFromTeam(ctx, exec, fallbackTeam, authorID, labels, slices.Concat(exclude, res), n-len(res))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, reviewers...)
+	}
+	return res, nil
+}
+
+// selectFromTeam picks up to n active and available members of the team except author, excluded users
+// and members who reached their limit of open reviews
+func (s *PRService) selectFromTeam(ctx context.Context, exec sqlx.ExtContext, teamName, authorID string, labels, exclude []string, n int) ([]string, error) {
+	if n <= 0 {
+		return []string{}, nil
+	}
+
+	candidates, err := s.store.TeamRepo().GetTeamReviewCandidates(ctx, exec, teamName)
+	if err != nil {
+		return nil, err
+	}
+	s.trace.add(candidates, authorID, exclude)
+
+	return s.pickReviewers(teamName, eligibleCandidates(candidates, authorID, exclude), labels, n), nil
+}
+
+// eligibleCandidates filters out author, excluded users and members who can not review now
+func eligibleCandidates(candidates []models.ReviewCandidate, authorID string, exclude []string) []models.ReviewCandidate {
+	eligible := make([]models.ReviewCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if exclusionReason(candidate, authorID, exclude) != "" {
+			continue
+		}
+		eligible = append(eligible, candidate)
+	}
+	return eligible
+}
+
+// exclusionReason explains why candidate can not review the PR, empty reason means candidate is eligible
+func exclusionReason(candidate models.ReviewCandidate, authorID string, exclude []string) models.ExclusionReason {
+	switch {
+	case candidate.UserID == authorID:
+		return models.ExclusionReasonAuthor
+	case !candidate.IsActive:
+		return models.ExclusionReasonInactive
+	case candidate.Unavailable:
+		return models.ExclusionReasonUnavailable
+	case candidate.OverCapacity():
+		return models.ExclusionReasonOverCapacity
+	case isUserIDInReviewers(candidate.UserID, exclude):
+		return models.ExclusionReasonAlreadyAssigned
+	}
+	return ""
+}
+
+// pickReviewers selects up to n eligible candidates with selector strategy,
+// members whose skills match PR labels are picked before the others
+func (s *PRService) pickReviewers(teamName string, eligible []models.ReviewCandidate, labels []string, n int) []string {
+	if len(labels) == 0 {
+		return s.selector.Select(teamName, eligible, n)
+	}
+
+	var matching, rest []models.ReviewCandidate
+	for _, candidate := range eligible {
+		if matchedLabel(labels, candidate.Skills) != "" {
+			matching = append(matching, candidate)
+		} else {
+			rest = append(rest, candidate)
+		}
+	}
+
+	res := []string{}
+	if len(matching) > 0 {
+		res = s.selector.Select(teamName, matching, n)
+	}
+	if len(res) < n && len(rest) > 0 {
+		res = append(res, s.selector.Select(teamName, rest, n-len(res))...)
+	}
+	return res
+}
+
+// matchLabels returns reviewer id -> first PR label which is in reviewer skills,
+// reviewers without matching skills are not included
+func (s *PRService) matchLabels(ctx context.Context, exec sqlx.ExtContext, reviewers, labels []string) (map[string]string, error) {
+	if len(reviewers) == 0 || len(labels) == 0 {
+		return nil, nil
+	}
+
+	candidates, err := s.store.TeamRepo().GetReviewCandidatesByUserIDs(ctx, exec, reviewers)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make(map[string]string)
+	for _, candidate := range candidates {
+		if label := matchedLabel(labels, candidate.Skills); label != "" {
+			matched[candidate.UserID] = label
+		}
+	}
+	return matched, nil
+}
+
+func matchedLabel(labels, skills []string) string {
+	for _, label := range labels {
+		if slices.Contains(skills, label) {
+			return label
+		}
+	}
+	return ""
+}
@@ -0,0 +1,116 @@
+package prservice
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+// EscalateSLABreach changes reviewers of the PR by escalation of the breached timer in the caller transaction.
+// PR which is not OPEN anymore and reviewer who is not assigned anymore are left as is,
+// escalation without candidates makes no changes.
+func (s *PRService) EscalateSLABreach(ctx context.Context, exec sqlx.ExtContext, timer *models.SLATimer) (*models.SLAEscalationResult, error) {
+	res := &models.SLAEscalationResult{}
+	if timer.Escalation != models.SLAEscalationAddReviewer && timer.Escalation != models.SLAEscalationReassign {
+		return res, nil
+	}
+
+	if err := s.store.PRRepo().LockAssignments(ctx, exec); err != nil {
+		return nil, fmt.Errorf("EscalateSLABreach: unable to lock assignments: %v", err)
+	}
+	pr, err := s.store.PRRepo().GetPullRequestByID(ctx, exec, timer.PullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("EscalateSLABreach: unable to get PR: %v", err)
+	}
+	if pr.Status != models.PullRequestStatusOpen {
+		return res, nil
+	}
+
+	if timer.Escalation == models.SLAEscalationAddReviewer {
+		res.AddedReviewer, err = s.addEscalationReviewer(ctx, exec, pr)
+		if err != nil {
+			return nil, fmt.Errorf("EscalateSLABreach: %v", err)
+		}
+		return res, nil
+	}
+
+	for _, reviewerID := range silentReviewers(pr, timer) {
+		newReviewerID, err := s.replaceReviewer(ctx, exec, pr, reviewerID, models.AssignmentReasonSLABreach)
+		if err != nil {
+			return nil, fmt.Errorf("EscalateSLABreach: %w", err)
+		}
+		if newReviewerID == "" {
+			continue
+		}
+		res.Reassigned = append(res.Reassigned, models.ReviewerReplacement{
+			PullRequestID: pr.ID,
+			OldReviewerID: reviewerID,
+			NewReviewerID: newReviewerID,
+		})
+		// next replacement must not pick the same reviewer
+		pr.AssignedReviewers = append(pr.AssignedReviewers, newReviewerID)
+	}
+	return res, nil
+}
+
+// addEscalationReviewer assigns one more reviewer by ReassignPR rules. Escalation has to reach someone,
+// so PR with max reviewers of review team gets one more, but not a second one: breach of the added reviewer
+// timer must not add reviewers until the team runs out
+func (s *PRService) addEscalationReviewer(ctx context.Context, exec sqlx.ExtContext, pr *models.PullRequest) (string, error) {
+	author, err := s.store.UserRepo().GetUserByID(ctx, exec, pr.AuthorID)
+	if err != nil {
+		return "", fmt.Errorf("unable to get PR author: %v", err)
+	}
+	teamName, err := s.reviewTeam(ctx, exec, pr.TeamName, pr.Repository, author)
+	if err != nil {
+		return "", err
+	}
+	settings, err := s.store.TeamRepo().GetTeamSettings(ctx, exec, teamName)
+	if err != nil {
+		return "", fmt.Errorf("unable to get team settings: %v", err)
+	}
+	if len(pr.AssignedReviewers) > settings.MaxReviewers {
+		return "", nil
+	}
+
+	reviewers, err := s.selectAdditionalReviewer(ctx, exec, pr)
+	if err != nil {
+		return "", err
+	}
+	if len(reviewers) == 0 {
+		return "", nil
+	}
+
+	if err := s.store.PRRepo().AssignReviewer(ctx, exec, pr.ID, reviewers[0]); err != nil {
+		return "", fmt.Errorf("unable to assign reviewer: %v", err)
+	}
+	if err := s.recordEvents(ctx, exec, assignedEvents(ctx, pr.ID, reviewers, models.AssignmentReasonSLABreach)...); err != nil {
+		return "", err
+	}
+	return reviewers[0], nil
+}
+
+// silentReviewers returns reviewers to replace: the reviewer of breached review SLA
+// or all reviewers without submitted review for breached merge SLA
+func silentReviewers(pr *models.PullRequest, timer *models.SLATimer) []string {
+	if timer.Kind == models.SLAKindReview {
+		if isUserIDInReviewers(timer.ReviewerID, pr.AssignedReviewers) {
+			return []string{timer.ReviewerID}
+		}
+		return nil
+	}
+
+	res := make([]string, 0, len(pr.AssignedReviewers))
+	for _, reviewerID := range pr.AssignedReviewers {
+		reviewed := slices.ContainsFunc(pr.Reviewers, func(state models.ReviewerState) bool {
+			return state.UserID == reviewerID && state.SubmittedAt != nil
+		})
+		if !reviewed {
+			res = append(res, reviewerID)
+		}
+	}
+	return res
+}
@@ -0,0 +1,71 @@
+package prservice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Negat1v9/pr-review-service/config"
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	mock_store "github.com/Negat1v9/pr-review-service/internal/store/mock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEscalateSLABreach(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+	mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	selector, err := NewReviewerSelector(config.AssignmentConfig{Strategy: StrategyRoundRobin})
+	require.NoError(t, err)
+	service := NewPRService(mockStore, selector)
+
+	author := models.User{UserID: "u1", TeamName: "team-1", IsActive: true}
+	settings := models.TeamSettings{TeamName: "team-1", MaxReviewers: 2, UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer}
+	timer := models.SLATimer{Kind: models.SLAKindReview, PullRequestID: "pr-1", ReviewerID: "u2", TeamName: "team-1", Escalation: models.SLAEscalationAddReviewer}
+
+	t.Run("Add reviewer over team maximum", func(t *testing.T) {
+		pr := models.PullRequest{ID: "pr-1", AuthorID: "u1", Status: models.PullRequestStatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&pr, nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&author, nil).Times(2)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&settings, nil)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return([]models.ReviewCandidate{
+			{UserID: "u1", TeamName: "team-1", IsActive: true},
+			{UserID: "u2", TeamName: "team-1", IsActive: true},
+			{UserID: "u3", TeamName: "team-1", IsActive: true},
+			{UserID: "u4", TeamName: "team-1", IsActive: true},
+		}, nil)
+		mockPRRepo.EXPECT().AssignReviewer(gomock.Any(), gomock.Any(), "pr-1", "u4").Return(nil)
+
+		res, err := service.EscalateSLABreach(context.Background(), &db, &timer)
+		require.NoError(t, err)
+		require.Equal(t, "u4", res.AddedReviewer)
+	})
+
+	t.Run("Escalation reviewer is added once", func(t *testing.T) {
+		pr := models.PullRequest{ID: "pr-1", AuthorID: "u1", Status: models.PullRequestStatusOpen, AssignedReviewers: []string{"u2", "u3", "u4"}}
+		addedTimer := timer
+		addedTimer.ReviewerID = "u4"
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&pr, nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&author, nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&settings, nil)
+
+		res, err := service.EscalateSLABreach(context.Background(), &db, &addedTimer)
+		require.NoError(t, err)
+		require.Empty(t, res.AddedReviewer)
+	})
+}
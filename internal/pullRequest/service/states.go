@@ -0,0 +1,159 @@
+package prservice
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/Negat1v9/pr-review-service/pkg/utils"
+	"github.com/jmoiron/sqlx"
+)
+
+// MarkReadyForReview moves DRAFT PR to OPEN and assigns reviewers by the rules of CreatePR
+func (s *PRService) MarkReadyForReview(ctx context.Context, req *models.ReadyPullRequest) (*models.PullRequest, error) {
+	var selection *reviewersSelection
+	pr, err := s.changeStatus(ctx, models.PullRequestKey(req.Repository, req.ID), "mark ready for review", []models.PullRequestStatus{models.PullRequestStatusDraft}, models.PullRequestStatusOpen,
+		func(ctx context.Context, exec sqlx.ExtContext, pr *models.PullRequest) error {
+			var err error
+			selection, err = s.assignOpenedPR(ctx, exec, pr, req.ChangedFiles)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pr.Understaffed = selection.understaffed()
+	pr.MatchedLabels = selection.matchedLabels
+	return pr, nil
+}
+
+// ConvertToDraft moves OPEN PR back to DRAFT, reviewers are unassigned until it is ready again
+// and their submitted reviews are kept in the history
+func (s *PRService) ConvertToDraft(ctx context.Context, prID string) (*models.PullRequest, error) {
+	return s.changeStatus(ctx, prID, "convert to draft", []models.PullRequestStatus{models.PullRequestStatusOpen}, models.PullRequestStatusDraft,
+		func(ctx context.Context, exec sqlx.ExtContext, pr *models.PullRequest) error {
+			if err := s.store.PRRepo().DeleteAssignedByPullRequestID(ctx, exec, pr.ID); err != nil {
+				return fmt.Errorf("ConvertToDraft: unable to delete reviewers: %v", err)
+			}
+
+			events := make([]models.PullRequestEvent, 0, len(pr.AssignedReviewers))
+			for _, reviewerID := range pr.AssignedReviewers {
+				events = append(events, newEvent(ctx, pr.ID, models.PullRequestEventReviewerRemoved, "", models.ReviewerPayload{
+					ReviewerID: reviewerID,
+					Reason:     models.AssignmentReasonConvertedToDraft,
+				}))
+			}
+			return s.recordEvents(ctx, exec, events...)
+		},
+	)
+}
+
+// ClosePR closes DRAFT or OPEN PR without merge, reviewers stay assigned
+// but closed PR does not count in their open reviews
+func (s *PRService) ClosePR(ctx context.Context, prID string) (*models.PullRequest, error) {
+	return s.changeStatus(ctx, prID, "close", []models.PullRequestStatus{models.PullRequestStatusDraft, models.PullRequestStatusOpen}, models.PullRequestStatusClosed, nil)
+}
+
+// ReopenPR moves CLOSED PR to OPEN, PR closed as a draft has no reviewers and gets them as a new one
+func (s *PRService) ReopenPR(ctx context.Context, prID string) (*models.PullRequest, error) {
+	return s.changeStatus(ctx, prID, "reopen", []models.PullRequestStatus{models.PullRequestStatusClosed}, models.PullRequestStatusOpen,
+		func(ctx context.Context, exec sqlx.ExtContext, pr *models.PullRequest) error {
+			if len(pr.AssignedReviewers) > 0 {
+				return nil
+			}
+			_, err := s.assignOpenedPR(ctx, exec, pr, nil)
+			return err
+		},
+	)
+}
+
+// changeStatus moves PR from one of the from statuses to the status and runs apply in the same transaction,
+// action names the transition in error message
+func (s *PRService) changeStatus(
+	ctx context.Context,
+	prID, action string,
+	from []models.PullRequestStatus,
+	to models.PullRequestStatus,
+	apply func(ctx context.Context, exec sqlx.ExtContext, pr *models.PullRequest) error,
+) (*models.PullRequest, error) {
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		// opened PR gets reviewers, so transitions are serialized with other assignments
+		if err := s.store.PRRepo().LockAssignments(ctx, exec); err != nil {
+			return fmt.Errorf("unable to lock assignments: %v", err)
+		}
+
+		pr, err := s.store.PRRepo().GetPullRequestByID(ctx, exec, prID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return utils.NewNotFoundError("resource not found", nil)
+			}
+			return fmt.Errorf("unable to get PR: %v", err)
+		}
+		if !slices.Contains(from, pr.Status) {
+			return statusTransitionError(pr, action)
+		}
+
+		if err := s.store.PRRepo().UpdatePullRequestStatus(ctx, exec, prID, pr.Status, to); err != nil {
+			// status was changed by concurrent request
+			if err == sql.ErrNoRows {
+				return utils.NewError(409, utils.ErrInvalidTransition, "PR status was changed concurrently", nil)
+			}
+			return fmt.Errorf("unable to update PR status: %v", err)
+		}
+		if err := s.recordEvents(ctx, exec, statusChangedEvent(ctx, prID, pr.Status, to)); err != nil {
+			return err
+		}
+
+		if apply != nil {
+			return apply(ctx, exec, pr)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	updatedPR, err := s.store.PRRepo().GetPullRequestByID(ctx, s.store.DB(), prID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get updated PR: %v", err)
+	}
+	return updatedPR, nil
+}
+
+// assignOpenedPR assigns reviewers to PR which became OPEN,
+// FAIL understaffed policy of review team rejects the transition
+func (s *PRService) assignOpenedPR(ctx context.Context, exec sqlx.ExtContext, pr *models.PullRequest, changedFiles []string) (*reviewersSelection, error) {
+	selection, err := s.selectReviewers(ctx, exec, &models.CreatePullRequest{
+		ID:           pr.ID,
+		AuthorID:     pr.AuthorID,
+		Repository:   pr.Repository,
+		ChangedFiles: changedFiles,
+		TeamName:     pr.TeamName,
+	}, pr.Labels)
+	if err != nil {
+		return nil, err
+	}
+	if selection.understaffed() && selection.settings.UnderstaffedPolicy == models.UnderstaffedPolicyFail {
+		return nil, utils.NewError(409, utils.ErrNotEnoughReviewers, "not enough active reviewers in team", nil)
+	}
+
+	if err := s.store.PRRepo().AssignManyReviewers(ctx, exec, pr.ID, selection.reviewers); err != nil {
+		return nil, fmt.Errorf("unable to assign reviewers to PR: %v", err)
+	}
+	if err := s.recordEvents(ctx, exec, assignedEvents(ctx, pr.ID, selection.reviewers, models.AssignmentReasonAuto)...); err != nil {
+		return nil, err
+	}
+	return selection, nil
+}
+
+// statusTransitionError explains why action can not be applied to PR in its current status
+func statusTransitionError(pr *models.PullRequest, action string) error {
+	if pr.Status == models.PullRequestStatusMerged {
+		return utils.NewError(409, utils.ErrPrAlredyMerged, fmt.Sprintf("cannot %s merged PR", action), nil)
+	}
+	return utils.NewError(409, utils.ErrInvalidTransition, fmt.Sprintf("cannot %s PR in status %s", action, pr.Status), nil)
+}
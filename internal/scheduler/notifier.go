@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Negat1v9/pr-review-service/config"
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/Negat1v9/pr-review-service/pkg/logger"
+)
+
+const (
+	NotifierLog     = "log"
+	NotifierWebhook = "webhook"
+)
+
+// Notifier delivers reminders to reviewers
+type Notifier interface {
+	Notify(ctx context.Context, reminder *models.ReviewReminder) error
+}
+
+// NewNotifier returns notifier by its name from config, empty name means log
+func NewNotifier(cfg config.SchedulerConfig, log *logger.Logger) (Notifier, error) {
+	switch cfg.Notifier {
+	case "", NotifierLog:
+		return &logNotifier{log: log}, nil
+	case NotifierWebhook:
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("webhook notifier requires webhook url")
+		}
+		return &webhookNotifier{
+			url:    cfg.WebhookURL,
+			client: &http.Client{Timeout: time.Duration(cfg.WebhookTimeout) * time.Second},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", cfg.Notifier)
+	}
+}
+
+// logNotifier only writes reminders to the log
+type logNotifier struct {
+	log *logger.Logger
+}
+
+func (n *logNotifier) Notify(_ context.Context, reminder *models.ReviewReminder) error {
+	n.log.Infof("reminder: %s has not reviewed PR %s (%s) assigned at %s",
+		reminder.ReviewerID, reminder.PullRequestID, reminder.PullRequestName, reminder.AssignedAt.Format(time.RFC3339))
+	return nil
+}
+
+// webhookNotifier posts reminders as JSON, delivery to reviewer is up to the receiver
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, reminder *models.ReviewReminder) error {
+	body, err := json.Marshal(reminder)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/Negat1v9/pr-review-service/internal/store"
+	"github.com/Negat1v9/pr-review-service/pkg/clock"
+	"github.com/Negat1v9/pr-review-service/pkg/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+const JobStaleReviews = "stale_reviews"
+
+// pending reminder claimed earlier was left by a run which stopped before marking it, it is sent again
+const reminderClaimTimeout = time.Hour
+
+// StaleReviewsJob reminds reviewers of OPEN PRs they have not reviewed long after assignment.
+// Every assignment is reminded once, reviewers in quiet hours are reminded on the next run after them.
+type StaleReviewsJob struct {
+	store      store.Store
+	notifier   Notifier
+	clock      clock.Clock
+	log        *logger.Logger
+	staleAfter time.Duration
+}
+
+func NewStaleReviewsJob(store store.Store, notifier Notifier, clock clock.Clock, log *logger.Logger, staleAfterHours int) *StaleReviewsJob {
+	return &StaleReviewsJob{
+		store:      store,
+		notifier:   notifier,
+		clock:      clock,
+		log:        log,
+		staleAfter: time.Duration(staleAfterHours) * time.Hour,
+	}
+}
+
+func (j *StaleReviewsJob) Run(ctx context.Context) error {
+	_, err := j.Remind(ctx)
+	return err
+}
+
+// Remind sends due reminders and returns sent ones. Due reminders are claimed as pending in a transaction
+// which holds job lock, so replica which does not get the lock skips the run. They are delivered after the commit
+// and marked sent or failed, failed one is retried on the next run.
+func (j *StaleReviewsJob) Remind(ctx context.Context) ([]models.ReviewReminder, error) {
+	claimed := make([]models.ReviewReminder, 0)
+	err := j.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		locked, err := j.store.SchedulerRepo().TryLockJob(ctx, exec, JobStaleReviews)
+		if err != nil {
+			return fmt.Errorf("unable to lock job: %v", err)
+		}
+		if !locked {
+			return nil
+		}
+
+		now := j.clock.Now()
+		reminders, err := j.store.SchedulerRepo().GetStaleReviews(ctx, exec, now.Add(-j.staleAfter), now.Add(-reminderClaimTimeout))
+		if err != nil {
+			return fmt.Errorf("unable to get stale reviews: %v", err)
+		}
+
+		for _, reminder := range reminders {
+			if reminder.QuietHours != nil && reminder.QuietHours.Contains(now) {
+				continue
+			}
+
+			if err := j.store.SchedulerRepo().ClaimReminder(ctx, exec, &reminder); err != nil {
+				return fmt.Errorf("unable to claim reminder: %v", err)
+			}
+			claimed = append(claimed, reminder)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// slow notifier does not keep the transaction open
+	sent := make([]models.ReviewReminder, 0, len(claimed))
+	for _, reminder := range claimed {
+		status := models.ReminderStatusSent
+		if err := j.notifier.Notify(ctx, &reminder); err != nil {
+			j.log.Errorf("remind %s of PR %s: %v", reminder.ReviewerID, reminder.PullRequestID, err)
+			status = models.ReminderStatusFailed
+		}
+
+		// reminder left pending is sent again after the claim timeout
+		if err := j.store.SchedulerRepo().SetReminderStatus(ctx, j.store.DB(), &reminder, status); err != nil {
+			j.log.Errorf("mark reminder of %s of PR %s as %s: %v", reminder.ReviewerID, reminder.PullRequestID, status, err)
+		}
+		if status == models.ReminderStatusSent {
+			sent = append(sent, reminder)
+		}
+	}
+	return sent, nil
+}
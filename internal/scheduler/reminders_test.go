@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Negat1v9/pr-review-service/config"
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	mock_store "github.com/Negat1v9/pr-review-service/internal/store/mock"
+	"github.com/Negat1v9/pr-review-service/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+// recordingNotifier remembers reminders and fails for listed reviewers
+type recordingNotifier struct {
+	failFor  map[string]bool
+	notified []string
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, reminder *models.ReviewReminder) error {
+	if n.failFor[reminder.ReviewerID] {
+		return errors.New("unreachable")
+	}
+	n.notified = append(n.notified, reminder.ReviewerID)
+	return nil
+}
+
+func TestRemindStaleReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSchedulerRepo := mock_store.NewMockSchedulerRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().SchedulerRepo().Return(mockSchedulerRepo).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+
+	// 23:30 in UTC
+	now := time.Date(2026, 1, 10, 23, 30, 0, 0, time.UTC)
+	assignedAt := now.Add(-30 * time.Hour)
+
+	t.Run("Remind reviewers out of quiet hours", func(t *testing.T) {
+		reminders := []models.ReviewReminder{
+			{ReviewerID: "u1", PullRequestID: "pr-1", AssignedAt: assignedAt},
+			// quiet hours cross midnight
+			{ReviewerID: "u2", PullRequestID: "pr-1", AssignedAt: assignedAt, QuietHours: &models.QuietHours{From: "22:00", To: "08:00", Timezone: "UTC"}},
+			// 02:30 in Moscow is not quiet
+			{ReviewerID: "u3", PullRequestID: "pr-2", AssignedAt: assignedAt, QuietHours: &models.QuietHours{From: "22:00", To: "02:00", Timezone: "Europe/Moscow"}},
+			// delivery fails, retried on the next run
+			{ReviewerID: "u4", PullRequestID: "pr-2", AssignedAt: assignedAt},
+		}
+		mockSchedulerRepo.EXPECT().TryLockJob(gomock.Any(), gomock.Any(), JobStaleReviews).Return(true, nil)
+		mockSchedulerRepo.EXPECT().GetStaleReviews(gomock.Any(), gomock.Any(), now.Add(-24*time.Hour), now.Add(-time.Hour)).Return(reminders, nil)
+		mockSchedulerRepo.EXPECT().ClaimReminder(gomock.Any(), gomock.Any(), &reminders[0]).Return(nil)
+		mockSchedulerRepo.EXPECT().ClaimReminder(gomock.Any(), gomock.Any(), &reminders[2]).Return(nil)
+		lastClaim := mockSchedulerRepo.EXPECT().ClaimReminder(gomock.Any(), gomock.Any(), &reminders[3]).Return(nil)
+		// reminders are delivered and marked after all of them are claimed
+		mockSchedulerRepo.EXPECT().SetReminderStatus(gomock.Any(), gomock.Any(), &reminders[0], models.ReminderStatusSent).Return(nil).After(lastClaim)
+		mockSchedulerRepo.EXPECT().SetReminderStatus(gomock.Any(), gomock.Any(), &reminders[2], models.ReminderStatusSent).Return(nil)
+		mockSchedulerRepo.EXPECT().SetReminderStatus(gomock.Any(), gomock.Any(), &reminders[3], models.ReminderStatusFailed).Return(nil)
+
+		notifier := &recordingNotifier{failFor: map[string]bool{"u4": true}}
+		job := NewStaleReviewsJob(mockStore, notifier, fixedClock{now: now}, logger.NewLogger("local"), 24)
+
+		sent, err := job.Remind(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []string{"u1", "u3"}, notifier.notified)
+		require.Len(t, sent, 2)
+	})
+
+	t.Run("Claim fails before anything is sent", func(t *testing.T) {
+		reminders := []models.ReviewReminder{
+			{ReviewerID: "u1", PullRequestID: "pr-1", AssignedAt: assignedAt},
+			{ReviewerID: "u2", PullRequestID: "pr-2", AssignedAt: assignedAt},
+		}
+		mockSchedulerRepo.EXPECT().TryLockJob(gomock.Any(), gomock.Any(), JobStaleReviews).Return(true, nil)
+		mockSchedulerRepo.EXPECT().GetStaleReviews(gomock.Any(), gomock.Any(), now.Add(-24*time.Hour), now.Add(-time.Hour)).Return(reminders, nil)
+		mockSchedulerRepo.EXPECT().ClaimReminder(gomock.Any(), gomock.Any(), &reminders[0]).Return(nil)
+		mockSchedulerRepo.EXPECT().ClaimReminder(gomock.Any(), gomock.Any(), &reminders[1]).Return(errors.New("connection reset"))
+
+		notifier := &recordingNotifier{}
+		job := NewStaleReviewsJob(mockStore, notifier, fixedClock{now: now}, logger.NewLogger("local"), 24)
+
+		_, err := job.Remind(context.Background())
+		require.Error(t, err)
+		require.Empty(t, notifier.notified)
+	})
+
+	t.Run("Another replica holds the lock", func(t *testing.T) {
+		mockSchedulerRepo.EXPECT().TryLockJob(gomock.Any(), gomock.Any(), JobStaleReviews).Return(false, nil)
+
+		notifier := &recordingNotifier{}
+		job := NewStaleReviewsJob(mockStore, notifier, fixedClock{now: now}, logger.NewLogger("local"), 24)
+
+		sent, err := job.Remind(context.Background())
+		require.NoError(t, err)
+		require.Empty(t, sent)
+		require.Empty(t, notifier.notified)
+	})
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var received models.ReviewReminder
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		if received.ReviewerID == "gone" {
+			w.WriteHeader(http.StatusGone)
+		}
+	}))
+	defer webhook.Close()
+
+	_, err := NewNotifier(config.SchedulerConfig{Notifier: NotifierWebhook}, nil)
+	require.Error(t, err)
+	_, err = NewNotifier(config.SchedulerConfig{Notifier: "pigeon"}, nil)
+	require.Error(t, err)
+
+	notifier, err := NewNotifier(config.SchedulerConfig{Notifier: NotifierWebhook, WebhookURL: webhook.URL, WebhookTimeout: 5}, nil)
+	require.NoError(t, err)
+
+	reminder := models.ReviewReminder{ReviewerID: "u1", PullRequestID: "pr-1", PullRequestName: "fix", AssignedAt: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, notifier.Notify(context.Background(), &reminder))
+	require.Equal(t, reminder, received)
+
+	require.Error(t, notifier.Notify(context.Background(), &models.ReviewReminder{ReviewerID: "gone"}))
+}
+
+func TestNextRun(t *testing.T) {
+	s := New(fixedClock{}, logger.NewLogger("local"))
+	noop := func(context.Context) error { return nil }
+	require.NoError(t, s.Add("hourly", "0 * * * *", noop))
+	require.NoError(t, s.Add("quarter", "*/15 * * * *", noop))
+	require.NoError(t, s.Add("daily", "0 0 * * *", noop))
+	require.Error(t, s.Add("broken", "* * *", noop))
+
+	next, due := s.nextRun(time.Date(2026, 1, 10, 12, 20, 0, 0, time.UTC))
+	require.Equal(t, time.Date(2026, 1, 10, 12, 30, 0, 0, time.UTC), next)
+	require.Len(t, due, 1)
+
+	next, due = s.nextRun(time.Date(2026, 1, 10, 23, 50, 0, 0, time.UTC))
+	require.Equal(t, time.Date(2026, 1, 11, 0, 0, 0, 0, time.UTC), next)
+	require.Len(t, due, 3)
+}
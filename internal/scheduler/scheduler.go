@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Negat1v9/pr-review-service/pkg/clock"
+	"github.com/Negat1v9/pr-review-service/pkg/cron"
+	"github.com/Negat1v9/pr-review-service/pkg/logger"
+)
+
+// Job is a periodic task, it has to be safe to run on several replicas at once
+type Job struct {
+	Name     string
+	Schedule *cron.Schedule
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs jobs by their cron schedules in UTC, due jobs run one after another
+type Scheduler struct {
+	jobs  []Job
+	clock clock.Clock
+	log   *logger.Logger
+}
+
+func New(clock clock.Clock, log *logger.Logger) *Scheduler {
+	return &Scheduler{
+		clock: clock,
+		log:   log,
+	}
+}
+
+// Add registers job with cron expression of its schedule
+func (s *Scheduler) Add(name, expr string, run func(ctx context.Context) error) error {
+	schedule, err := cron.Parse(expr)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", name, err)
+	}
+
+	s.jobs = append(s.jobs, Job{Name: name, Schedule: schedule, Run: run})
+	return nil
+}
+
+// Run runs jobs until ctx is done
+func (s *Scheduler) Run(ctx context.Context) {
+	if len(s.jobs) == 0 {
+		s.log.Infof("scheduler has no jobs")
+		return
+	}
+
+	for {
+		now := s.clock.Now().UTC()
+		next, due := s.nextRun(now)
+		if next.IsZero() {
+			s.log.Warnf("scheduler jobs never run again")
+			return
+		}
+
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		for _, job := range due {
+			if err := job.Run(ctx); err != nil {
+				s.log.Errorf("job %s: %v", job.Name, err)
+			}
+		}
+	}
+}
+
+// nextRun returns the earliest run time after now and jobs which run at that time
+func (s *Scheduler) nextRun(now time.Time) (time.Time, []Job) {
+	var next time.Time
+	var due []Job
+	for _, job := range s.jobs {
+		jobNext := job.Schedule.Next(now)
+		switch {
+		case jobNext.IsZero():
+		case next.IsZero() || jobNext.Before(next):
+			next, due = jobNext, []Job{job}
+		case jobNext.Equal(next):
+			due = append(due, job)
+		}
+	}
+	return next, due
+}
@@ -3,7 +3,11 @@ package server
 import (
 	"net/http"
 
+	availabilityhttp "github.com/Negat1v9/pr-review-service/internal/availability/http"
+	availabilityservice "github.com/Negat1v9/pr-review-service/internal/availability/service"
 	"github.com/Negat1v9/pr-review-service/internal/middleware"
+	ownershiphttp "github.com/Negat1v9/pr-review-service/internal/ownership/http"
+	ownershipservice "github.com/Negat1v9/pr-review-service/internal/ownership/service"
 	prhttp "github.com/Negat1v9/pr-review-service/internal/pullRequest/http"
 	prservice "github.com/Negat1v9/pr-review-service/internal/pullRequest/service"
 	teamhttp "github.com/Negat1v9/pr-review-service/internal/team/http"
@@ -12,20 +16,26 @@ import (
 	userservice "github.com/Negat1v9/pr-review-service/internal/users/service"
 )
 
-func (s *Server) MapHandlers(teamService *teamservice.TeamService, userService *userservice.UserService, prService *prservice.PRService) {
+func (s *Server) MapHandlers(teamService *teamservice.TeamService, userService *userservice.UserService, prService *prservice.PRService, ownershipService *ownershipservice.OwnershipService, availabilityService *availabilityservice.AvailabilityService) {
 	router := http.NewServeMux()
 
 	teamHandler := teamhttp.NewTeamHanlder(s.log, teamService)
 	userHandler := userhttp.NewUserHandler(s.log, userService)
 	prHandler := prhttp.NewPRHanlder(s.log, prService)
+	ownershipHandler := ownershiphttp.NewOwnershipHandler(s.log, ownershipService)
+	availabilityHandler := availabilityhttp.NewAvailabilityHandler(s.log, availabilityService)
 
 	teamRouter := teamhttp.TeamRouter(teamHandler)
 	userRouter := userhttp.UserRouter(userHandler)
 	prRouter := prhttp.PRRouter(prHandler)
+	ownershipRouter := ownershiphttp.OwnershipRouter(ownershipHandler)
+	availabilityRouter := availabilityhttp.AvailabilityRouter(availabilityHandler)
 
 	router.Handle("/team/", http.StripPrefix("/team", teamRouter))
 	router.Handle("/users/", http.StripPrefix("/users", userRouter))
 	router.Handle("/pullRequest/", http.StripPrefix("/pullRequest", prRouter))
+	router.Handle("/ownership/", http.StripPrefix("/ownership", ownershipRouter))
+	router.Handle("/availability/", http.StripPrefix("/availability", availabilityRouter))
 
 	// middleware service
 	mw := middleware.New()
@@ -0,0 +1,163 @@
+package sla
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Negat1v9/pr-review-service/config"
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/Negat1v9/pr-review-service/internal/store"
+	"github.com/Negat1v9/pr-review-service/pkg/clock"
+	"github.com/Negat1v9/pr-review-service/pkg/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+// Escalator changes reviewers of PR which breached the SLA
+type Escalator interface {
+	EscalateSLABreach(ctx context.Context, exec sqlx.ExtContext, timer *models.SLATimer) (*models.SLAEscalationResult, error)
+}
+
+// Worker periodically finds OPEN PRs which breach or are about to breach SLA of author team,
+// reports every warning and breach once and escalates breaches by team policy.
+// Notices are deduplicated in the database, so workers of several replicas do not repeat them.
+type Worker struct {
+	cfg       config.SLAConfig
+	store     store.Store
+	escalator Escalator
+	clock     clock.Clock
+	log       *logger.Logger
+	client    *http.Client
+}
+
+func NewWorker(cfg config.SLAConfig, store store.Store, escalator Escalator, clock clock.Clock, log *logger.Logger) *Worker {
+	return &Worker{
+		cfg:       cfg,
+		store:     store,
+		escalator: escalator,
+		clock:     clock,
+		log:       log,
+		client:    &http.Client{Timeout: time.Duration(cfg.WebhookTimeout) * time.Second},
+	}
+}
+
+// Run checks SLAs every check interval until ctx is done
+func (w *Worker) Run(ctx context.Context) {
+	if w.cfg.CheckInterval <= 0 {
+		w.log.Infof("SLA worker is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(w.cfg.CheckInterval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := w.Check(ctx); err != nil {
+				w.log.Errorf("SLA check: %v", err)
+			}
+		}
+	}
+}
+
+// Check reports due SLA timers once and returns new notices,
+// failure of one timer is logged and does not stop the others
+func (w *Worker) Check(ctx context.Context) ([]models.SLANotice, error) {
+	now := w.clock.Now()
+	dueBy := now.Add(time.Duration(w.cfg.WarnBefore) * time.Minute)
+
+	timers, err := w.store.PRRepo().GetDueSLATimers(ctx, w.store.DB(), dueBy)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get SLA timers: %v", err)
+	}
+
+	notices := make([]models.SLANotice, 0, len(timers))
+	for _, timer := range timers {
+		notice, err := w.report(ctx, timer, now)
+		if err != nil {
+			w.log.Errorf("SLA %s of PR %s: %v", timer.Kind, timer.PullRequestID, err)
+			continue
+		}
+		if notice == nil {
+			continue
+		}
+
+		notices = append(notices, *notice)
+		w.notify(ctx, notice)
+	}
+	return notices, nil
+}
+
+// report saves notice of the timer and escalates breach in one transaction,
+// returns nil notice if it was already reported
+func (w *Worker) report(ctx context.Context, timer models.SLATimer, now time.Time) (*models.SLANotice, error) {
+	notice := &models.SLANotice{
+		SLATimer: timer,
+		Level:    models.SLALevelWarning,
+		Deadline: timer.Deadline(),
+	}
+	if !now.Before(notice.Deadline) {
+		notice.Level = models.SLALevelBreach
+	}
+
+	var created bool
+	err := w.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		var err error
+		created, err = w.store.PRRepo().CreateSLANotice(ctx, exec, notice)
+		if err != nil {
+			return fmt.Errorf("unable to save notice: %v", err)
+		}
+		if !created || notice.Level != models.SLALevelBreach || timer.Escalation == models.SLAEscalationWebhook {
+			return nil
+		}
+
+		notice.Escalated, err = w.escalator.EscalateSLABreach(ctx, exec, &timer)
+		return err
+	})
+	if err != nil || !created {
+		return nil, err
+	}
+	return notice, nil
+}
+
+// notify posts the notice to the webhook, without webhook it is only logged
+func (w *Worker) notify(ctx context.Context, notice *models.SLANotice) {
+	w.log.Warnf("SLA %s %s: PR %s, reviewer %q, team %s, deadline %s",
+		notice.Kind, notice.Level, notice.PullRequestID, notice.ReviewerID, notice.TeamName, notice.Deadline.Format(time.RFC3339))
+	if w.cfg.WebhookURL == "" {
+		return
+	}
+
+	if err := w.postWebhook(ctx, notice); err != nil {
+		w.log.Errorf("SLA webhook for PR %s: %v", notice.PullRequestID, err)
+	}
+}
+
+func (w *Worker) postWebhook(ctx context.Context, notice *models.SLANotice) error {
+	body, err := json.Marshal(notice)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
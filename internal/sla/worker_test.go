@@ -0,0 +1,161 @@
+package sla
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Negat1v9/pr-review-service/config"
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	prservice "github.com/Negat1v9/pr-review-service/internal/pullRequest/service"
+	mock_store "github.com/Negat1v9/pr-review-service/internal/store/mock"
+	"github.com/Negat1v9/pr-review-service/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+func TestCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	author := models.User{UserID: "author", TeamName: "team-1", IsActive: true}
+	candidates := []models.ReviewCandidate{
+		{UserID: "author", TeamName: "team-1", IsActive: true},
+		{UserID: "u1", TeamName: "team-1", IsActive: true},
+		{UserID: "u2", TeamName: "team-1", IsActive: true},
+	}
+	db := sqlx.DB{}
+
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+	mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "author").Return(&author, nil).AnyTimes()
+	mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return(candidates, nil).AnyTimes()
+
+	var mu sync.Mutex
+	var posted []models.SLANotice
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var notice models.SLANotice
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&notice))
+		mu.Lock()
+		posted = append(posted, notice)
+		mu.Unlock()
+	}))
+	defer webhook.Close()
+
+	selector, err := prservice.NewReviewerSelector(config.AssignmentConfig{Strategy: prservice.StrategyRoundRobin})
+	require.NoError(t, err)
+	cfg := config.SLAConfig{CheckInterval: 60, WarnBefore: 60, WebhookURL: webhook.URL, WebhookTimeout: 5}
+	worker := NewWorker(cfg, mockStore, prservice.NewPRService(mockStore, selector), fixedClock{now: now}, logger.NewLogger("local"))
+
+	t.Run("Warnings and breaches are reported once and escalated", func(t *testing.T) {
+		posted = nil
+		timers := []models.SLATimer{
+			// deadline is now, silent reviewer is replaced
+			{Kind: models.SLAKindReview, PullRequestID: "pr-1", ReviewerID: "u1", TeamName: "team-1", StartedAt: now.Add(-2 * time.Hour), Hours: 2, Escalation: models.SLAEscalationReassign},
+			// 30 minutes left
+			{Kind: models.SLAKindMerge, PullRequestID: "pr-2", TeamName: "team-1", StartedAt: now.Add(-23*time.Hour - 30*time.Minute), Hours: 24, Escalation: models.SLAEscalationReassign},
+			// breach is only reported
+			{Kind: models.SLAKindReview, PullRequestID: "pr-3", ReviewerID: "u2", TeamName: "team-1", StartedAt: now.Add(-5 * time.Hour), Hours: 4, Escalation: models.SLAEscalationWebhook},
+			// reported by previous check or another replica
+			{Kind: models.SLAKindReview, PullRequestID: "pr-4", ReviewerID: "u2", TeamName: "team-1", StartedAt: now.Add(-5 * time.Hour), Hours: 4, Escalation: models.SLAEscalationReassign},
+		}
+		mockPRRepo.EXPECT().GetDueSLATimers(gomock.Any(), gomock.Any(), now.Add(time.Hour)).Return(timers, nil)
+
+		mockPRRepo.EXPECT().CreateSLANotice(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ sqlx.ExtContext, notice *models.SLANotice) (bool, error) {
+				return notice.PullRequestID != "pr-4", nil
+			},
+		).Times(4)
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&models.PullRequest{
+			ID: "pr-1", AuthorID: "author", Status: models.PullRequestStatusOpen, AssignedReviewers: []string{"u1"},
+		}, nil)
+		mockPRRepo.EXPECT().DeleteAssignedReviewer(gomock.Any(), gomock.Any(), "pr-1", "u1").Return(nil)
+		mockPRRepo.EXPECT().AssignReviewer(gomock.Any(), gomock.Any(), "pr-1", "u2").Return(nil)
+
+		notices, err := worker.Check(context.Background())
+		require.NoError(t, err)
+		require.Len(t, notices, 3)
+
+		require.Equal(t, models.SLALevelBreach, notices[0].Level)
+		require.Equal(t, now, notices[0].Deadline)
+		require.Equal(t, &models.SLAEscalationResult{Reassigned: []models.ReviewerReplacement{
+			{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2"},
+		}}, notices[0].Escalated)
+
+		require.Equal(t, models.SLALevelWarning, notices[1].Level)
+		require.Nil(t, notices[1].Escalated)
+
+		require.Equal(t, models.SLALevelBreach, notices[2].Level)
+		require.Nil(t, notices[2].Escalated)
+
+		require.Len(t, posted, 3)
+		require.Equal(t, "pr-1", posted[0].PullRequestID)
+		require.Equal(t, "u2", posted[0].Escalated.Reassigned[0].NewReviewerID)
+	})
+
+	t.Run("Merge breach adds reviewer", func(t *testing.T) {
+		posted = nil
+		timers := []models.SLATimer{
+			{Kind: models.SLAKindMerge, PullRequestID: "pr-5", TeamName: "team-1", StartedAt: now.Add(-48 * time.Hour), Hours: 24, Escalation: models.SLAEscalationAddReviewer},
+		}
+		mockPRRepo.EXPECT().GetDueSLATimers(gomock.Any(), gomock.Any(), now.Add(time.Hour)).Return(timers, nil)
+		mockPRRepo.EXPECT().CreateSLANotice(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-5").Return(&models.PullRequest{
+			ID: "pr-5", AuthorID: "author", Status: models.PullRequestStatusOpen, AssignedReviewers: []string{"u2"},
+		}, nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&models.TeamSettings{TeamName: "team-1", MaxReviewers: 1}, nil)
+		mockPRRepo.EXPECT().AssignReviewer(gomock.Any(), gomock.Any(), "pr-5", "u1").Return(nil)
+
+		notices, err := worker.Check(context.Background())
+		require.NoError(t, err)
+		require.Len(t, notices, 1)
+		require.Equal(t, &models.SLAEscalationResult{AddedReviewer: "u1"}, notices[0].Escalated)
+		require.Len(t, posted, 1)
+	})
+
+	t.Run("PR closed before escalation", func(t *testing.T) {
+		posted = nil
+		timers := []models.SLATimer{
+			{Kind: models.SLAKindReview, PullRequestID: "pr-6", ReviewerID: "u1", TeamName: "team-1", StartedAt: now.Add(-3 * time.Hour), Hours: 2, Escalation: models.SLAEscalationReassign},
+		}
+		mockPRRepo.EXPECT().GetDueSLATimers(gomock.Any(), gomock.Any(), now.Add(time.Hour)).Return(timers, nil)
+		mockPRRepo.EXPECT().CreateSLANotice(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-6").Return(&models.PullRequest{
+			ID: "pr-6", AuthorID: "author", Status: models.PullRequestStatusClosed, AssignedReviewers: []string{"u1"},
+		}, nil)
+
+		notices, err := worker.Check(context.Background())
+		require.NoError(t, err)
+		require.Len(t, notices, 1)
+		require.Equal(t, &models.SLAEscalationResult{}, notices[0].Escalated)
+	})
+}
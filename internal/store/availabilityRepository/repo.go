@@ -0,0 +1,101 @@
+package availabilityrepository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type availabilityRepository struct{}
+
+func NewAvailabilityRepository() *availabilityRepository {
+	return &availabilityRepository{}
+}
+
+func (r *availabilityRepository) CreatePeriod(ctx context.Context, exec sqlx.ExtContext, period *models.UnavailabilityPeriod) (*models.UnavailabilityPeriod, error) {
+	var created models.UnavailabilityPeriod
+	err := exec.QueryRowxContext(ctx, createPeriodQuery, period.UserID, period.StartsAt, period.EndsAt, period.Reason).
+		StructScan(&created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (r *availabilityRepository) GetUserPeriods(ctx context.Context, exec sqlx.ExtContext, userID string) ([]models.UnavailabilityPeriod, error) {
+	periods := make([]models.UnavailabilityPeriod, 0)
+	if err := sqlx.SelectContext(ctx, exec, &periods, getUserPeriodsQuery, userID); err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+func (r *availabilityRepository) DeletePeriod(ctx context.Context, exec sqlx.ExtContext, userID string, periodID int64) error {
+	res, err := exec.ExecContext(ctx, deletePeriodQuery, periodID, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *availabilityRepository) GetWorkingSchedule(ctx context.Context, exec sqlx.ExtContext, userID string) (*models.WorkingSchedule, error) {
+	var schedule models.WorkingSchedule
+	var days pq.Int64Array
+	if err := exec.QueryRowxContext(ctx, getWorkingScheduleQuery, userID).Scan(&schedule.UserID, &days, &schedule.Timezone); err != nil {
+		return nil, err
+	}
+
+	schedule.WorkingDays = make([]int, 0, len(days))
+	for _, day := range days {
+		schedule.WorkingDays = append(schedule.WorkingDays, int(day))
+	}
+	return &schedule, nil
+}
+
+func (r *availabilityRepository) UpsertWorkingSchedule(ctx context.Context, exec sqlx.ExtContext, schedule *models.WorkingSchedule) error {
+	_, err := exec.ExecContext(ctx, upsertWorkingScheduleQuery, schedule.UserID, pq.Array(schedule.WorkingDays), schedule.Timezone)
+	return err
+}
+
+func (r *availabilityRepository) TimezoneExists(ctx context.Context, exec sqlx.ExtContext, timezone string) (bool, error) {
+	var exists bool
+	if err := exec.QueryRowxContext(ctx, timezoneExistsQuery, timezone).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *availabilityRepository) DeleteWorkingSchedule(ctx context.Context, exec sqlx.ExtContext, userID string) error {
+	_, err := exec.ExecContext(ctx, deleteWorkingScheduleQuery, userID)
+	return err
+}
+
+func (r *availabilityRepository) GetQuietHours(ctx context.Context, exec sqlx.ExtContext, userID string) (*models.QuietHours, error) {
+	var quietHours models.QuietHours
+	if err := exec.QueryRowxContext(ctx, getQuietHoursQuery, userID).StructScan(&quietHours); err != nil {
+		return nil, err
+	}
+	return &quietHours, nil
+}
+
+func (r *availabilityRepository) UpsertQuietHours(ctx context.Context, exec sqlx.ExtContext, quietHours *models.QuietHours) error {
+	_, err := exec.ExecContext(ctx, upsertQuietHoursQuery, quietHours.UserID, quietHours.From, quietHours.To, quietHours.Timezone)
+	return err
+}
+
+func (r *availabilityRepository) DeleteQuietHours(ctx context.Context, exec sqlx.ExtContext, userID string) error {
+	_, err := exec.ExecContext(ctx, deleteQuietHoursQuery, userID)
+	return err
+}
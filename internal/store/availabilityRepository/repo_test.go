@@ -0,0 +1,173 @@
+package availabilityrepository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePeriod(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	availabilityRepo := NewAvailabilityRepository()
+
+	t.Run("Create", func(t *testing.T) {
+		period := models.UnavailabilityPeriod{
+			UserID:   "u1",
+			StartsAt: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			EndsAt:   time.Date(2025, 7, 14, 0, 0, 0, 0, time.UTC),
+			Reason:   "vacation",
+		}
+		rows := sqlmock.NewRows([]string{"period_id", "user_id", "starts_at", "ends_at", "reason"}).
+			AddRow(1, period.UserID, period.StartsAt, period.EndsAt, period.Reason)
+
+		mock.ExpectQuery(createPeriodQuery).
+			WithArgs(period.UserID, period.StartsAt, period.EndsAt, period.Reason).WillReturnRows(rows)
+
+		created, err := availabilityRepo.CreatePeriod(context.Background(), sqlxDB, &period)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), created.ID)
+	})
+}
+
+func TestGetUserPeriods(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	availabilityRepo := NewAvailabilityRepository()
+
+	t.Run("Get", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"period_id", "user_id", "starts_at", "ends_at", "reason"}).
+			AddRow(1, "u1", time.Now(), time.Now().Add(time.Hour), "").
+			AddRow(2, "u1", time.Now().Add(time.Hour*24), time.Now().Add(time.Hour*48), "conference")
+
+		mock.ExpectQuery(getUserPeriodsQuery).WithArgs("u1").WillReturnRows(rows)
+
+		periods, err := availabilityRepo.GetUserPeriods(context.Background(), sqlxDB, "u1")
+		require.NoError(t, err)
+		require.Equal(t, 2, len(periods))
+		require.Equal(t, "conference", periods[1].Reason)
+	})
+}
+
+func TestDeletePeriod(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	availabilityRepo := NewAvailabilityRepository()
+
+	t.Run("Delete", func(t *testing.T) {
+		mock.ExpectExec(deletePeriodQuery).WithArgs(int64(1), "u1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := availabilityRepo.DeletePeriod(context.Background(), sqlxDB, "u1", 1)
+		require.NoError(t, err)
+	})
+
+	t.Run("Delete period of other user", func(t *testing.T) {
+		mock.ExpectExec(deletePeriodQuery).WithArgs(int64(1), "u2").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := availabilityRepo.DeletePeriod(context.Background(), sqlxDB, "u2", 1)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestWorkingSchedule(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	availabilityRepo := NewAvailabilityRepository()
+
+	t.Run("Upsert", func(t *testing.T) {
+		schedule := models.WorkingSchedule{UserID: "u1", WorkingDays: []int{1, 2, 3}, Timezone: "Europe/Moscow"}
+		mock.ExpectExec(upsertWorkingScheduleQuery).
+			WithArgs("u1", pq.Array(schedule.WorkingDays), "Europe/Moscow").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := availabilityRepo.UpsertWorkingSchedule(context.Background(), sqlxDB, &schedule)
+		require.NoError(t, err)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"user_id", "working_days", "timezone"}).AddRow("u1", "{1,2,3}", "Europe/Moscow")
+		mock.ExpectQuery(getWorkingScheduleQuery).WithArgs("u1").WillReturnRows(rows)
+
+		schedule, err := availabilityRepo.GetWorkingSchedule(context.Background(), sqlxDB, "u1")
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2, 3}, schedule.WorkingDays)
+	})
+
+	t.Run("Get not set", func(t *testing.T) {
+		mock.ExpectQuery(getWorkingScheduleQuery).WithArgs("u2").WillReturnError(sql.ErrNoRows)
+
+		_, err := availabilityRepo.GetWorkingSchedule(context.Background(), sqlxDB, "u2")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("Unknown timezone", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"exists"}).AddRow(false)
+		mock.ExpectQuery(timezoneExistsQuery).WithArgs("Local").WillReturnRows(rows)
+
+		exists, err := availabilityRepo.TimezoneExists(context.Background(), sqlxDB, "Local")
+		require.NoError(t, err)
+		require.False(t, exists)
+	})
+}
+
+func TestQuietHours(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	availabilityRepo := NewAvailabilityRepository()
+	quietHours := models.QuietHours{UserID: "u1", From: "22:00", To: "08:00", Timezone: "Europe/Moscow"}
+
+	t.Run("Upsert", func(t *testing.T) {
+		mock.ExpectExec(upsertQuietHoursQuery).WithArgs("u1", "22:00", "08:00", "Europe/Moscow").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := availabilityRepo.UpsertQuietHours(context.Background(), sqlxDB, &quietHours)
+		require.NoError(t, err)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"user_id", "starts_at", "ends_at", "timezone"}).AddRow("u1", "22:00", "08:00", "Europe/Moscow")
+		mock.ExpectQuery(getQuietHoursQuery).WithArgs("u1").WillReturnRows(rows)
+
+		got, err := availabilityRepo.GetQuietHours(context.Background(), sqlxDB, "u1")
+		require.NoError(t, err)
+		require.Equal(t, &quietHours, got)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		mock.ExpectExec(deleteQuietHoursQuery).WithArgs("u1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := availabilityRepo.DeleteQuietHours(context.Background(), sqlxDB, "u1")
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,62 @@
+package availabilityrepository
+
+const (
+	createPeriodQuery = `
+		INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason)
+			VALUES ($1, $2, $3, $4)
+		RETURNING period_id, user_id, starts_at, ends_at, reason
+	`
+
+	getUserPeriodsQuery = `
+		SELECT period_id, user_id, starts_at, ends_at, reason
+			FROM user_unavailability
+		WHERE user_id = $1
+		ORDER BY starts_at
+	`
+
+	deletePeriodQuery = `
+		DELETE FROM user_unavailability
+		WHERE period_id = $1 AND user_id = $2
+	`
+
+	getWorkingScheduleQuery = `
+		SELECT user_id, working_days, timezone
+			FROM user_schedules
+		WHERE user_id = $1
+	`
+
+	upsertWorkingScheduleQuery = `
+		INSERT INTO user_schedules (user_id, working_days, timezone)
+			VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+			SET working_days = EXCLUDED.working_days,
+			timezone = EXCLUDED.timezone
+	`
+
+	timezoneExistsQuery = `
+		SELECT EXISTS (SELECT 1 FROM pg_timezone_names WHERE name = $1)
+	`
+
+	deleteWorkingScheduleQuery = `
+		DELETE FROM user_schedules WHERE user_id = $1
+	`
+
+	getQuietHoursQuery = `
+		SELECT user_id, to_char(starts_at, 'HH24:MI') AS starts_at, to_char(ends_at, 'HH24:MI') AS ends_at, timezone
+			FROM user_quiet_hours
+		WHERE user_id = $1
+	`
+
+	upsertQuietHoursQuery = `
+		INSERT INTO user_quiet_hours (user_id, starts_at, ends_at, timezone)
+			VALUES ($1, $2::time, $3::time, $4)
+		ON CONFLICT (user_id) DO UPDATE
+			SET starts_at = EXCLUDED.starts_at,
+			ends_at = EXCLUDED.ends_at,
+			timezone = EXCLUDED.timezone
+	`
+
+	deleteQuietHoursQuery = `
+		DELETE FROM user_quiet_hours WHERE user_id = $1
+	`
+)
@@ -2,9 +2,13 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/Negat1v9/pr-review-service/internal/models"
+	availabilityrepository "github.com/Negat1v9/pr-review-service/internal/store/availabilityRepository"
+	ownershiprepository "github.com/Negat1v9/pr-review-service/internal/store/ownershipRepository"
 	pullrequestrepository "github.com/Negat1v9/pr-review-service/internal/store/pullRequestRepository"
+	schedulerrepository "github.com/Negat1v9/pr-review-service/internal/store/schedulerRepository"
 	teamrepository "github.com/Negat1v9/pr-review-service/internal/store/teamRepository"
 	userrepository "github.com/Negat1v9/pr-review-service/internal/store/userRepository"
 	"github.com/jmoiron/sqlx"
@@ -12,35 +16,190 @@ import (
 
 type UserRepository interface {
 	CreateUser(ctx context.Context, exec sqlx.ExtContext, teamName string, user *models.User) error
+	// creates users with the primary team, existing users are not changed
 	CreateManyUsers(ctx context.Context, exec sqlx.ExtContext, teamName string, users []models.User) error
+	// creates users with the primary team, name and status are updated only for existing users of that primary team.
+	// Returns ids of created and updated users, other listed users are members of the team only
+	UpsertUsers(ctx context.Context, exec sqlx.ExtContext, teamName string, users []models.User) ([]string, error)
+	GetUserByID(ctx context.Context, exec sqlx.ExtContext, userID string) (*models.User, error)
+	// returns users by the filter ordered by user_id, at most filter.Limit of them
+	ListUsers(ctx context.Context, exec sqlx.ExtContext, filter *models.UserFilter) ([]models.User, error)
+	// updates not nil profile fields, returns sql.ErrNoRows if user does not exist
+	UpdateUserProfile(ctx context.Context, exec sqlx.ExtContext, req *models.UpdateUserRequest) (*models.User, error)
+	// returns user id -> forge -> login, users without logins are not included
+	GetUsersForgeLogins(ctx context.Context, exec sqlx.ExtContext, userIDs []string) (map[string]map[string]string, error)
+	// returns forges where the logins belong to other users
+	GetTakenForgeLogins(ctx context.Context, exec sqlx.ExtContext, userID string, logins map[string]string) ([]string, error)
+	// replaces all forge logins of the user
+	SetUserForgeLogins(ctx context.Context, exec sqlx.ExtContext, userID string, logins map[string]string) error
 	GetUserReviews(ctx context.Context, exec sqlx.ExtContext, userID string) (*models.UserReviews, error)
 	UpdateUserStatus(ctx context.Context, exec sqlx.ExtContext, userID string, isActive bool) (*models.User, error)
+	// deactivates listed members of the team, returns only users which belong to it.
+	// Users whose primary team it is are deactivated everywhere, other members only in the team
+	DeactivateTeamUsers(ctx context.Context, exec sqlx.ExtContext, teamName string, userIDs []string) ([]models.User, error)
+	// nil maxOpenReviews removes the limit
+	UpdateUserReviewLimit(ctx context.Context, exec sqlx.ExtContext, userID string, maxOpenReviews *int) (*models.User, error)
+	GetUserSkills(ctx context.Context, exec sqlx.ExtContext, userID string) ([]string, error)
+	// replaces all skills of the user
+	SetUserSkills(ctx context.Context, exec sqlx.ExtContext, userID string, skills []string) error
 }
 
 type TeamRepository interface {
 	CreateTeam(ctx context.Context, exec sqlx.ExtContext, teamName string) error
+	// sets archive time of the team, returns sql.ErrNoRows if team does not exist or is already archived
+	ArchiveTeam(ctx context.Context, exec sqlx.ExtContext, teamName string) (time.Time, error)
+	// returns members with their roles in the team, sql.ErrNoRows if team has no members
 	GetTeamWithMembers(ctx context.Context, exec sqlx.ExtContext, teamName string) (*models.Team, error)
-	// returns all userIDs from user team
-	GetUsersIDFromUserTeam(ctx context.Context, exec sqlx.ExtContext, userID string, limit int) ([]string, error)
-	// return active usersID from userID team without exceptions users
-	// if there are no active users or the user himself, it returns sql.ErrNoRows error
-	GetActiveUsersTeamWithException(ctx context.Context, exec sqlx.ExtContext, userID string, exceptions []string, limit int) ([]string, error)
+	// adds users to the team, existing memberships are kept
+	CreateMemberships(ctx context.Context, exec sqlx.ExtContext, teamName string, members []models.User) error
+	// adds users to the team, role and flag of existing memberships are updated
+	UpsertMemberships(ctx context.Context, exec sqlx.ExtContext, teamName string, members []models.User) error
+	// returns sql.ErrNoRows if user is not a member of the team
+	DeleteMembership(ctx context.Context, exec sqlx.ExtContext, teamName, userID string) error
+	// returns sql.ErrNoRows if user is not a member of the team
+	GetMembership(ctx context.Context, exec sqlx.ExtContext, teamName, userID string) (*models.TeamMembership, error)
+	// updates role and flag of membership, returns sql.ErrNoRows if user is not a member of the team
+	UpdateMembership(ctx context.Context, exec sqlx.ExtContext, membership *models.TeamMembership) (*models.TeamMembership, error)
+	// sets parent of the team, empty parent makes the team a root, returns sql.ErrNoRows if team does not exist
+	SetParentTeam(ctx context.Context, exec sqlx.ExtContext, teamName, parentTeam string) error
+	// reports whether ancestor is the team itself or one of its ancestors
+	HasAncestor(ctx context.Context, exec sqlx.ExtContext, teamName, ancestor string) (bool, error)
+	// takes exclusive transaction level lock of team hierarchy changes
+	LockHierarchy(ctx context.Context, exec sqlx.ExtContext) error
+	// returns the team and its descendants ordered by name with their members, sql.ErrNoRows if team does not exist
+	GetSubtree(ctx context.Context, exec sqlx.ExtContext, teamName string) ([]models.Team, error)
+	// returns team settings with ordered fallback teams
+	GetTeamSettings(ctx context.Context, exec sqlx.ExtContext, teamName string) (*models.TeamSettings, error)
+	// updates settings and replaces fallback teams, returns sql.ErrNoRows if team does not exist
+	UpdateTeamSettings(ctx context.Context, exec sqlx.ExtContext, settings *models.TeamSettings) error
+	// returns sql.ErrNoRows if team does not exist
+	GetMergePolicy(ctx context.Context, exec sqlx.ExtContext, teamName string) (*models.MergePolicy, error)
+	// updates policy and replaces required approvers, returns sql.ErrNoRows if team does not exist
+	UpdateMergePolicy(ctx context.Context, exec sqlx.ExtContext, policy *models.MergePolicy) error
+	// returns sql.ErrNoRows if team does not exist
+	GetSLAPolicy(ctx context.Context, exec sqlx.ExtContext, teamName string) (*models.SLAPolicy, error)
+	// returns sql.ErrNoRows if team does not exist
+	UpdateSLAPolicy(ctx context.Context, exec sqlx.ExtContext, policy *models.SLAPolicy) error
+	// returns all team members with quantity of OPEN PRs they review, their limits and skills,
+	// member with inactive membership is inactive, archived team has no candidates
+	GetTeamReviewCandidates(ctx context.Context, exec sqlx.ExtContext, teamName string) ([]models.ReviewCandidate, error)
+	// same as GetTeamReviewCandidates for members of all teams of the subtree of the team
+	GetSubtreeReviewCandidates(ctx context.Context, exec sqlx.ExtContext, teamName string) ([]models.ReviewCandidate, error)
+	// same as GetTeamReviewCandidates for the listed users in their primary teams, unknown users are skipped
+	GetReviewCandidatesByUserIDs(ctx context.Context, exec sqlx.ExtContext, userIDs []string) ([]models.ReviewCandidate, error)
 }
 
 type PullRequestRepository interface {
 	CreatePullRequest(ctx context.Context, exec sqlx.ExtContext, pr *models.PullRequest) error
 	GetPullRequestByID(ctx context.Context, exec sqlx.ExtContext, prID string) (*models.PullRequest, error)
-	MergePullRequest(ctx context.Context, exec sqlx.ExtContext, prID string) error
+	// returns page of PRs by the filter with reviewers and labels, filter limit is the page size
+	ListPullRequests(ctx context.Context, exec sqlx.ExtContext, filter *models.PullRequestFilter) ([]models.PullRequest, error)
+	// override marks PR merged regardless of the merge policy, returns sql.ErrNoRows if PR is not OPEN
+	MergePullRequest(ctx context.Context, exec sqlx.ExtContext, prID string, override bool) error
+	// moves PR from one status to another, returns sql.ErrNoRows if PR is not in the from status
+	UpdatePullRequestStatus(ctx context.Context, exec sqlx.ExtContext, prID string, from, to models.PullRequestStatus) error
 	AssignReviewer(ctx context.Context, exec sqlx.ExtContext, prID, reviewerID string) error
 	AssignManyReviewers(ctx context.Context, exec sqlx.ExtContext, prID string, reviewerIDs []string) error
 	DeleteAssignedByReviewerID(ctx context.Context, exec sqlx.ExtContext, reviewerID string) error
-	GetQuantityPRReviewers(ctx context.Context, exec sqlx.ExtContext) ([]models.PullRequestQuantityReviewers, error)
+	// removes all reviewers of the PR
+	DeleteAssignedByPullRequestID(ctx context.Context, exec sqlx.ExtContext, prID string) error
+	// removes reviewer from the PR only, returns sql.ErrNoRows if reviewer is not assigned to it
+	DeleteAssignedReviewer(ctx context.Context, exec sqlx.ExtContext, prID, reviewerID string) error
+	GetOpenPullRequestIDsByReviewer(ctx context.Context, exec sqlx.ExtContext, reviewerID string) ([]string, error)
+	// counts PRs reviewed by each of the teams, teams without PRs are skipped, empty repository counts all PRs
+	GetTeamsPullRequestCounts(ctx context.Context, exec sqlx.ExtContext, teamNames []string, repository string) ([]models.TeamPullRequestCounts, error)
+	// returns DRAFT and OPEN PRs reviewed by the team
+	GetActivePullRequestIDsByTeam(ctx context.Context, exec sqlx.ExtContext, teamName string) ([]string, error)
+	// sets team chosen for the PRs, the team reviews them
+	SetPullRequestsTeam(ctx context.Context, exec sqlx.ExtContext, prIDs []string, teamName string) error
+	// returns assignments of the reviewers to OPEN PRs with PR reviewers, labels and review team,
+	// not empty teamName keeps only PRs reviewed by the team
+	GetOpenAssignmentsByReviewers(ctx context.Context, exec sqlx.ExtContext, reviewerIDs []string, teamName string) ([]models.OpenAssignment, error)
+	// replaces old reviewers with new ones in one statement
+	ReplaceManyReviewers(ctx context.Context, exec sqlx.ExtContext, replacements []models.ReviewerReplacement) error
+	// takes exclusive transaction level lock of reviewer assignments. Every change of PR reviewers takes it
+	// before reading PR and reviewer loads, so concurrent changes of the PR or max_open_reviews checks are not lost,
+	// operations which make users unavailable and merge take it to see committed reviews
+	LockAssignments(ctx context.Context, exec sqlx.ExtContext) error
+	// empty repository counts PRs of all repositories
+	GetQuantityPRReviewers(ctx context.Context, exec sqlx.ExtContext, repository string) ([]models.PullRequestQuantityReviewers, error)
+	// replaces all labels of the PR
+	SetPullRequestLabels(ctx context.Context, exec sqlx.ExtContext, prID string, labels []string) error
+	CreateReview(ctx context.Context, exec sqlx.ExtContext, review *models.Review) (*models.Review, error)
+	// returns review history of the PR in submission order
+	GetPullRequestReviews(ctx context.Context, exec sqlx.ExtContext, prID string) ([]models.Review, error)
+	// appends events to PR timelines in the given order
+	CreateEvents(ctx context.Context, exec sqlx.ExtContext, events []models.PullRequestEvent) error
+	// returns PR timeline in order of events
+	GetPullRequestEvents(ctx context.Context, exec sqlx.ExtContext, prID string) ([]models.PullRequestEvent, error)
+	// replaces dependencies of the PR
+	SetPullRequestDependencies(ctx context.Context, exec sqlx.ExtContext, prID string, dependsOn []string) error
+	// reports whether toID is one of fromIDs or is reachable from them by dependencies
+	HasDependencyPath(ctx context.Context, exec sqlx.ExtContext, fromIDs []string, toID string) (bool, error)
+	// returns direct and transitive dependencies of the PR as edges with status of PR depended on
+	GetDependencyGraph(ctx context.Context, exec sqlx.ExtContext, prID string) ([]models.PullRequestDependency, error)
+	// returns direct dependencies of the PR in DRAFT or OPEN status
+	GetUnmergedDependencies(ctx context.Context, exec sqlx.ExtContext, prID string) ([]string, error)
+	// returns DRAFT and OPEN PRs which depend on the PR directly or transitively
+	GetBlockedPullRequests(ctx context.Context, exec sqlx.ExtContext, prID string) ([]models.PullRequest, error)
+	// takes exclusive transaction level lock of dependency graph changes
+	LockDependencies(ctx context.Context, exec sqlx.ExtContext) error
+	// returns running SLAs of OPEN PRs which deadline is not later than dueBy and which breach is not reported yet
+	GetDueSLATimers(ctx context.Context, exec sqlx.ExtContext, dueBy time.Time) ([]models.SLATimer, error)
+	// returns false if the same notice was already reported
+	CreateSLANotice(ctx context.Context, exec sqlx.ExtContext, notice *models.SLANotice) (bool, error)
+}
+
+type OwnershipRepository interface {
+	// creates or replaces CODEOWNERS content of the repository
+	UpsertCodeowners(ctx context.Context, exec sqlx.ExtContext, repository, content string) (*models.Codeowners, error)
+	GetCodeowners(ctx context.Context, exec sqlx.ExtContext, repository string) (*models.Codeowners, error)
+	// registers the repository without owner team, existing repository is kept
+	CreateRepository(ctx context.Context, exec sqlx.ExtContext, repository string) error
+	// creates the repository or replaces its owner team, empty team name removes it
+	UpsertRepository(ctx context.Context, exec sqlx.ExtContext, repository *models.Repository) (*models.Repository, error)
+	// returns sql.ErrNoRows if repository does not exist
+	GetRepository(ctx context.Context, exec sqlx.ExtContext, repository string) (*models.Repository, error)
+	// moves repositories owned by one team to another, empty team name removes the owner
+	TransferRepositories(ctx context.Context, exec sqlx.ExtContext, fromTeam, toTeam string) error
+}
+
+type AvailabilityRepository interface {
+	CreatePeriod(ctx context.Context, exec sqlx.ExtContext, period *models.UnavailabilityPeriod) (*models.UnavailabilityPeriod, error)
+	GetUserPeriods(ctx context.Context, exec sqlx.ExtContext, userID string) ([]models.UnavailabilityPeriod, error)
+	// returns sql.ErrNoRows if user has no such period
+	DeletePeriod(ctx context.Context, exec sqlx.ExtContext, userID string, periodID int64) error
+	// returns sql.ErrNoRows if user works every day
+	GetWorkingSchedule(ctx context.Context, exec sqlx.ExtContext, userID string) (*models.WorkingSchedule, error)
+	UpsertWorkingSchedule(ctx context.Context, exec sqlx.ExtContext, schedule *models.WorkingSchedule) error
+	// reports whether database knows the timezone, working days are computed in it by candidate queries
+	TimezoneExists(ctx context.Context, exec sqlx.ExtContext, timezone string) (bool, error)
+	DeleteWorkingSchedule(ctx context.Context, exec sqlx.ExtContext, userID string) error
+	// returns sql.ErrNoRows if user has no quiet hours
+	GetQuietHours(ctx context.Context, exec sqlx.ExtContext, userID string) (*models.QuietHours, error)
+	UpsertQuietHours(ctx context.Context, exec sqlx.ExtContext, quietHours *models.QuietHours) error
+	DeleteQuietHours(ctx context.Context, exec sqlx.ExtContext, userID string) error
+}
+
+type SchedulerRepository interface {
+	// takes transaction level lock of the job, returns false without waiting if another replica holds it
+	TryLockJob(ctx context.Context, exec sqlx.ExtContext, job string) (bool, error)
+	// returns not reminded assignments of active reviewers to OPEN PRs made before assignedBefore
+	// and not reviewed since, with quiet hours of reviewers. Failed reminders and pending ones claimed before claimedBefore are returned again
+	GetStaleReviews(ctx context.Context, exec sqlx.ExtContext, assignedBefore, claimedBefore time.Time) ([]models.ReviewReminder, error)
+	// saves reminder as PENDING, other runs do not pick it until it is claimed long ago
+	ClaimReminder(ctx context.Context, exec sqlx.ExtContext, reminder *models.ReviewReminder) error
+	// marks claimed reminder as SENT or FAILED, returns sql.ErrNoRows if it is not claimed
+	SetReminderStatus(ctx context.Context, exec sqlx.ExtContext, reminder *models.ReviewReminder, status models.ReminderStatus) error
 }
 
 type Store interface {
 	TeamRepo() TeamRepository
 	UserRepo() UserRepository
 	PRRepo() PullRequestRepository
+	OwnershipRepo() OwnershipRepository
+	AvailabilityRepo() AvailabilityRepository
+	SchedulerRepo() SchedulerRepository
 	DB() *sqlx.DB
 
 	DoTx(ctx context.Context, fn func(ctx context.Context, exec sqlx.ExtContext) error) error
@@ -51,6 +210,10 @@ type store struct {
 	teamRepo TeamRepository
 	userRepo UserRepository
 	prRepo   PullRequestRepository
+
+	ownershipRepo    OwnershipRepository
+	availabilityRepo AvailabilityRepository
+	schedulerRepo    SchedulerRepository
 }
 
 func NewStore(db *sqlx.DB) Store {
@@ -84,6 +247,27 @@ func (s *store) PRRepo() PullRequestRepository {
 	return s.prRepo
 }
 
+func (s *store) OwnershipRepo() OwnershipRepository {
+	if s.ownershipRepo == nil {
+		s.ownershipRepo = ownershiprepository.NewOwnershipRepository()
+	}
+	return s.ownershipRepo
+}
+
+func (s *store) AvailabilityRepo() AvailabilityRepository {
+	if s.availabilityRepo == nil {
+		s.availabilityRepo = availabilityrepository.NewAvailabilityRepository()
+	}
+	return s.availabilityRepo
+}
+
+func (s *store) SchedulerRepo() SchedulerRepository {
+	if s.schedulerRepo == nil {
+		s.schedulerRepo = schedulerrepository.NewSchedulerRepository()
+	}
+	return s.schedulerRepo
+}
+
 func (s *store) DoTx(ctx context.Context, fn func(ctx context.Context, exec sqlx.ExtContext) error) error {
 	tx, err := s.db.BeginTxx(ctx, nil)
 	if err != nil {
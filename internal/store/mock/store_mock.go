@@ -12,6 +12,7 @@ package mock_store
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/Negat1v9/pr-review-service/internal/models"
 	store "github.com/Negat1v9/pr-review-service/internal/store"
@@ -71,6 +72,51 @@ func (mr *MockUserRepositoryMockRecorder) CreateUser(ctx, exec, teamName, user a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserRepository)(nil).CreateUser), ctx, exec, teamName, user)
 }
 
+// DeactivateTeamUsers mocks base method.
+func (m *MockUserRepository) DeactivateTeamUsers(ctx context.Context, exec sqlx.ExtContext, teamName string, userIDs []string) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateTeamUsers", ctx, exec, teamName, userIDs)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateTeamUsers indicates an expected call of DeactivateTeamUsers.
+func (mr *MockUserRepositoryMockRecorder) DeactivateTeamUsers(ctx, exec, teamName, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateTeamUsers", reflect.TypeOf((*MockUserRepository)(nil).DeactivateTeamUsers), ctx, exec, teamName, userIDs)
+}
+
+// GetTakenForgeLogins mocks base method.
+func (m *MockUserRepository) GetTakenForgeLogins(ctx context.Context, exec sqlx.ExtContext, userID string, logins map[string]string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTakenForgeLogins", ctx, exec, userID, logins)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTakenForgeLogins indicates an expected call of GetTakenForgeLogins.
+func (mr *MockUserRepositoryMockRecorder) GetTakenForgeLogins(ctx, exec, userID, logins any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTakenForgeLogins", reflect.TypeOf((*MockUserRepository)(nil).GetTakenForgeLogins), ctx, exec, userID, logins)
+}
+
+// GetUserByID mocks base method.
+func (m *MockUserRepository) GetUserByID(ctx context.Context, exec sqlx.ExtContext, userID string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, exec, userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockUserRepositoryMockRecorder) GetUserByID(ctx, exec, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserRepository)(nil).GetUserByID), ctx, exec, userID)
+}
+
 // GetUserReviews mocks base method.
 func (m *MockUserRepository) GetUserReviews(ctx context.Context, exec sqlx.ExtContext, userID string) (*models.UserReviews, error) {
 	m.ctrl.T.Helper()
@@ -80,232 +126,1310 @@ func (m *MockUserRepository) GetUserReviews(ctx context.Context, exec sqlx.ExtCo
 	return ret0, ret1
 }
 
-// GetUserReviews indicates an expected call of GetUserReviews.
-func (mr *MockUserRepositoryMockRecorder) GetUserReviews(ctx, exec, userID any) *gomock.Call {
+// GetUserReviews indicates an expected call of GetUserReviews.
+func (mr *MockUserRepositoryMockRecorder) GetUserReviews(ctx, exec, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReviews", reflect.TypeOf((*MockUserRepository)(nil).GetUserReviews), ctx, exec, userID)
+}
+
+// GetUserSkills mocks base method.
+func (m *MockUserRepository) GetUserSkills(ctx context.Context, exec sqlx.ExtContext, userID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSkills", ctx, exec, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSkills indicates an expected call of GetUserSkills.
+func (mr *MockUserRepositoryMockRecorder) GetUserSkills(ctx, exec, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSkills", reflect.TypeOf((*MockUserRepository)(nil).GetUserSkills), ctx, exec, userID)
+}
+
+// GetUsersForgeLogins mocks base method.
+func (m *MockUserRepository) GetUsersForgeLogins(ctx context.Context, exec sqlx.ExtContext, userIDs []string) (map[string]map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersForgeLogins", ctx, exec, userIDs)
+	ret0, _ := ret[0].(map[string]map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersForgeLogins indicates an expected call of GetUsersForgeLogins.
+func (mr *MockUserRepositoryMockRecorder) GetUsersForgeLogins(ctx, exec, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersForgeLogins", reflect.TypeOf((*MockUserRepository)(nil).GetUsersForgeLogins), ctx, exec, userIDs)
+}
+
+// ListUsers mocks base method.
+func (m *MockUserRepository) ListUsers(ctx context.Context, exec sqlx.ExtContext, filter *models.UserFilter) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, exec, filter)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserRepositoryMockRecorder) ListUsers(ctx, exec, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserRepository)(nil).ListUsers), ctx, exec, filter)
+}
+
+// SetUserForgeLogins mocks base method.
+func (m *MockUserRepository) SetUserForgeLogins(ctx context.Context, exec sqlx.ExtContext, userID string, logins map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserForgeLogins", ctx, exec, userID, logins)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserForgeLogins indicates an expected call of SetUserForgeLogins.
+func (mr *MockUserRepositoryMockRecorder) SetUserForgeLogins(ctx, exec, userID, logins any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserForgeLogins", reflect.TypeOf((*MockUserRepository)(nil).SetUserForgeLogins), ctx, exec, userID, logins)
+}
+
+// SetUserSkills mocks base method.
+func (m *MockUserRepository) SetUserSkills(ctx context.Context, exec sqlx.ExtContext, userID string, skills []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserSkills", ctx, exec, userID, skills)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserSkills indicates an expected call of SetUserSkills.
+func (mr *MockUserRepositoryMockRecorder) SetUserSkills(ctx, exec, userID, skills any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserSkills", reflect.TypeOf((*MockUserRepository)(nil).SetUserSkills), ctx, exec, userID, skills)
+}
+
+// UpdateUserProfile mocks base method.
+func (m *MockUserRepository) UpdateUserProfile(ctx context.Context, exec sqlx.ExtContext, req *models.UpdateUserRequest) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserProfile", ctx, exec, req)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserProfile indicates an expected call of UpdateUserProfile.
+func (mr *MockUserRepositoryMockRecorder) UpdateUserProfile(ctx, exec, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserProfile), ctx, exec, req)
+}
+
+// UpdateUserReviewLimit mocks base method.
+func (m *MockUserRepository) UpdateUserReviewLimit(ctx context.Context, exec sqlx.ExtContext, userID string, maxOpenReviews *int) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserReviewLimit", ctx, exec, userID, maxOpenReviews)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserReviewLimit indicates an expected call of UpdateUserReviewLimit.
+func (mr *MockUserRepositoryMockRecorder) UpdateUserReviewLimit(ctx, exec, userID, maxOpenReviews any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserReviewLimit", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserReviewLimit), ctx, exec, userID, maxOpenReviews)
+}
+
+// UpdateUserStatus mocks base method.
+func (m *MockUserRepository) UpdateUserStatus(ctx context.Context, exec sqlx.ExtContext, userID string, isActive bool) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserStatus", ctx, exec, userID, isActive)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserStatus indicates an expected call of UpdateUserStatus.
+func (mr *MockUserRepositoryMockRecorder) UpdateUserStatus(ctx, exec, userID, isActive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserStatus", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserStatus), ctx, exec, userID, isActive)
+}
+
+// UpsertUsers mocks base method.
+func (m *MockUserRepository) UpsertUsers(ctx context.Context, exec sqlx.ExtContext, teamName string, users []models.User) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUsers", ctx, exec, teamName, users)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertUsers indicates an expected call of UpsertUsers.
+func (mr *MockUserRepositoryMockRecorder) UpsertUsers(ctx, exec, teamName, users any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUsers", reflect.TypeOf((*MockUserRepository)(nil).UpsertUsers), ctx, exec, teamName, users)
+}
+
+// MockTeamRepository is a mock of TeamRepository interface.
+type MockTeamRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTeamRepositoryMockRecorder is the mock recorder for MockTeamRepository.
+type MockTeamRepositoryMockRecorder struct {
+	mock *MockTeamRepository
+}
+
+// NewMockTeamRepository creates a new mock instance.
+func NewMockTeamRepository(ctrl *gomock.Controller) *MockTeamRepository {
+	mock := &MockTeamRepository{ctrl: ctrl}
+	mock.recorder = &MockTeamRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamRepository) EXPECT() *MockTeamRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ArchiveTeam mocks base method.
+func (m *MockTeamRepository) ArchiveTeam(ctx context.Context, exec sqlx.ExtContext, teamName string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveTeam", ctx, exec, teamName)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveTeam indicates an expected call of ArchiveTeam.
+func (mr *MockTeamRepositoryMockRecorder) ArchiveTeam(ctx, exec, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveTeam", reflect.TypeOf((*MockTeamRepository)(nil).ArchiveTeam), ctx, exec, teamName)
+}
+
+// CreateMemberships mocks base method.
+func (m *MockTeamRepository) CreateMemberships(ctx context.Context, exec sqlx.ExtContext, teamName string, members []models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMemberships", ctx, exec, teamName, members)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMemberships indicates an expected call of CreateMemberships.
+func (mr *MockTeamRepositoryMockRecorder) CreateMemberships(ctx, exec, teamName, members any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMemberships", reflect.TypeOf((*MockTeamRepository)(nil).CreateMemberships), ctx, exec, teamName, members)
+}
+
+// CreateTeam mocks base method.
+func (m *MockTeamRepository) CreateTeam(ctx context.Context, exec sqlx.ExtContext, teamName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTeam", ctx, exec, teamName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTeam indicates an expected call of CreateTeam.
+func (mr *MockTeamRepositoryMockRecorder) CreateTeam(ctx, exec, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*MockTeamRepository)(nil).CreateTeam), ctx, exec, teamName)
+}
+
+// DeleteMembership mocks base method.
+func (m *MockTeamRepository) DeleteMembership(ctx context.Context, exec sqlx.ExtContext, teamName, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMembership", ctx, exec, teamName, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMembership indicates an expected call of DeleteMembership.
+func (mr *MockTeamRepositoryMockRecorder) DeleteMembership(ctx, exec, teamName, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMembership", reflect.TypeOf((*MockTeamRepository)(nil).DeleteMembership), ctx, exec, teamName, userID)
+}
+
+// GetMembership mocks base method.
+func (m *MockTeamRepository) GetMembership(ctx context.Context, exec sqlx.ExtContext, teamName, userID string) (*models.TeamMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembership", ctx, exec, teamName, userID)
+	ret0, _ := ret[0].(*models.TeamMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembership indicates an expected call of GetMembership.
+func (mr *MockTeamRepositoryMockRecorder) GetMembership(ctx, exec, teamName, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembership", reflect.TypeOf((*MockTeamRepository)(nil).GetMembership), ctx, exec, teamName, userID)
+}
+
+// GetMergePolicy mocks base method.
+func (m *MockTeamRepository) GetMergePolicy(ctx context.Context, exec sqlx.ExtContext, teamName string) (*models.MergePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMergePolicy", ctx, exec, teamName)
+	ret0, _ := ret[0].(*models.MergePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMergePolicy indicates an expected call of GetMergePolicy.
+func (mr *MockTeamRepositoryMockRecorder) GetMergePolicy(ctx, exec, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMergePolicy", reflect.TypeOf((*MockTeamRepository)(nil).GetMergePolicy), ctx, exec, teamName)
+}
+
+// GetReviewCandidatesByUserIDs mocks base method.
+func (m *MockTeamRepository) GetReviewCandidatesByUserIDs(ctx context.Context, exec sqlx.ExtContext, userIDs []string) ([]models.ReviewCandidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewCandidatesByUserIDs", ctx, exec, userIDs)
+	ret0, _ := ret[0].([]models.ReviewCandidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewCandidatesByUserIDs indicates an expected call of GetReviewCandidatesByUserIDs.
+func (mr *MockTeamRepositoryMockRecorder) GetReviewCandidatesByUserIDs(ctx, exec, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewCandidatesByUserIDs", reflect.TypeOf((*MockTeamRepository)(nil).GetReviewCandidatesByUserIDs), ctx, exec, userIDs)
+}
+
+// GetSLAPolicy mocks base method.
+func (m *MockTeamRepository) GetSLAPolicy(ctx context.Context, exec sqlx.ExtContext, teamName string) (*models.SLAPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSLAPolicy", ctx, exec, teamName)
+	ret0, _ := ret[0].(*models.SLAPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSLAPolicy indicates an expected call of GetSLAPolicy.
+func (mr *MockTeamRepositoryMockRecorder) GetSLAPolicy(ctx, exec, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSLAPolicy", reflect.TypeOf((*MockTeamRepository)(nil).GetSLAPolicy), ctx, exec, teamName)
+}
+
+// GetSubtree mocks base method.
+func (m *MockTeamRepository) GetSubtree(ctx context.Context, exec sqlx.ExtContext, teamName string) ([]models.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtree", ctx, exec, teamName)
+	ret0, _ := ret[0].([]models.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtree indicates an expected call of GetSubtree.
+func (mr *MockTeamRepositoryMockRecorder) GetSubtree(ctx, exec, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtree", reflect.TypeOf((*MockTeamRepository)(nil).GetSubtree), ctx, exec, teamName)
+}
+
+// GetSubtreeReviewCandidates mocks base method.
+func (m *MockTeamRepository) GetSubtreeReviewCandidates(ctx context.Context, exec sqlx.ExtContext, teamName string) ([]models.ReviewCandidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtreeReviewCandidates", ctx, exec, teamName)
+	ret0, _ := ret[0].([]models.ReviewCandidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtreeReviewCandidates indicates an expected call of GetSubtreeReviewCandidates.
+func (mr *MockTeamRepositoryMockRecorder) GetSubtreeReviewCandidates(ctx, exec, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtreeReviewCandidates", reflect.TypeOf((*MockTeamRepository)(nil).GetSubtreeReviewCandidates), ctx, exec, teamName)
+}
+
+// GetTeamReviewCandidates mocks base method.
+func (m *MockTeamRepository) GetTeamReviewCandidates(ctx context.Context, exec sqlx.ExtContext, teamName string) ([]models.ReviewCandidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamReviewCandidates", ctx, exec, teamName)
+	ret0, _ := ret[0].([]models.ReviewCandidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamReviewCandidates indicates an expected call of GetTeamReviewCandidates.
+func (mr *MockTeamRepositoryMockRecorder) GetTeamReviewCandidates(ctx, exec, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamReviewCandidates", reflect.TypeOf((*MockTeamRepository)(nil).GetTeamReviewCandidates), ctx, exec, teamName)
+}
+
+// GetTeamSettings mocks base method.
+func (m *MockTeamRepository) GetTeamSettings(ctx context.Context, exec sqlx.ExtContext, teamName string) (*models.TeamSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamSettings", ctx, exec, teamName)
+	ret0, _ := ret[0].(*models.TeamSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamSettings indicates an expected call of GetTeamSettings.
+func (mr *MockTeamRepositoryMockRecorder) GetTeamSettings(ctx, exec, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamSettings", reflect.TypeOf((*MockTeamRepository)(nil).GetTeamSettings), ctx, exec, teamName)
+}
+
+// GetTeamWithMembers mocks base method.
+func (m *MockTeamRepository) GetTeamWithMembers(ctx context.Context, exec sqlx.ExtContext, teamName string) (*models.Team, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamWithMembers", ctx, exec, teamName)
+	ret0, _ := ret[0].(*models.Team)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamWithMembers indicates an expected call of GetTeamWithMembers.
+func (mr *MockTeamRepositoryMockRecorder) GetTeamWithMembers(ctx, exec, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamWithMembers", reflect.TypeOf((*MockTeamRepository)(nil).GetTeamWithMembers), ctx, exec, teamName)
+}
+
+// HasAncestor mocks base method.
+func (m *MockTeamRepository) HasAncestor(ctx context.Context, exec sqlx.ExtContext, teamName, ancestor string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasAncestor", ctx, exec, teamName, ancestor)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasAncestor indicates an expected call of HasAncestor.
+func (mr *MockTeamRepositoryMockRecorder) HasAncestor(ctx, exec, teamName, ancestor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasAncestor", reflect.TypeOf((*MockTeamRepository)(nil).HasAncestor), ctx, exec, teamName, ancestor)
+}
+
+// LockHierarchy mocks base method.
+func (m *MockTeamRepository) LockHierarchy(ctx context.Context, exec sqlx.ExtContext) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockHierarchy", ctx, exec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockHierarchy indicates an expected call of LockHierarchy.
+func (mr *MockTeamRepositoryMockRecorder) LockHierarchy(ctx, exec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockHierarchy", reflect.TypeOf((*MockTeamRepository)(nil).LockHierarchy), ctx, exec)
+}
+
+// SetParentTeam mocks base method.
+func (m *MockTeamRepository) SetParentTeam(ctx context.Context, exec sqlx.ExtContext, teamName, parentTeam string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetParentTeam", ctx, exec, teamName, parentTeam)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetParentTeam indicates an expected call of SetParentTeam.
+func (mr *MockTeamRepositoryMockRecorder) SetParentTeam(ctx, exec, teamName, parentTeam any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetParentTeam", reflect.TypeOf((*MockTeamRepository)(nil).SetParentTeam), ctx, exec, teamName, parentTeam)
+}
+
+// UpdateMembership mocks base method.
+func (m *MockTeamRepository) UpdateMembership(ctx context.Context, exec sqlx.ExtContext, membership *models.TeamMembership) (*models.TeamMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMembership", ctx, exec, membership)
+	ret0, _ := ret[0].(*models.TeamMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMembership indicates an expected call of UpdateMembership.
+func (mr *MockTeamRepositoryMockRecorder) UpdateMembership(ctx, exec, membership any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMembership", reflect.TypeOf((*MockTeamRepository)(nil).UpdateMembership), ctx, exec, membership)
+}
+
+// UpdateMergePolicy mocks base method.
+func (m *MockTeamRepository) UpdateMergePolicy(ctx context.Context, exec sqlx.ExtContext, policy *models.MergePolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMergePolicy", ctx, exec, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMergePolicy indicates an expected call of UpdateMergePolicy.
+func (mr *MockTeamRepositoryMockRecorder) UpdateMergePolicy(ctx, exec, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMergePolicy", reflect.TypeOf((*MockTeamRepository)(nil).UpdateMergePolicy), ctx, exec, policy)
+}
+
+// UpdateSLAPolicy mocks base method.
+func (m *MockTeamRepository) UpdateSLAPolicy(ctx context.Context, exec sqlx.ExtContext, policy *models.SLAPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSLAPolicy", ctx, exec, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSLAPolicy indicates an expected call of UpdateSLAPolicy.
+func (mr *MockTeamRepositoryMockRecorder) UpdateSLAPolicy(ctx, exec, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSLAPolicy", reflect.TypeOf((*MockTeamRepository)(nil).UpdateSLAPolicy), ctx, exec, policy)
+}
+
+// UpdateTeamSettings mocks base method.
+func (m *MockTeamRepository) UpdateTeamSettings(ctx context.Context, exec sqlx.ExtContext, settings *models.TeamSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTeamSettings", ctx, exec, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTeamSettings indicates an expected call of UpdateTeamSettings.
+func (mr *MockTeamRepositoryMockRecorder) UpdateTeamSettings(ctx, exec, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeamSettings", reflect.TypeOf((*MockTeamRepository)(nil).UpdateTeamSettings), ctx, exec, settings)
+}
+
+// UpsertMemberships mocks base method.
+func (m *MockTeamRepository) UpsertMemberships(ctx context.Context, exec sqlx.ExtContext, teamName string, members []models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMemberships", ctx, exec, teamName, members)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertMemberships indicates an expected call of UpsertMemberships.
+func (mr *MockTeamRepositoryMockRecorder) UpsertMemberships(ctx, exec, teamName, members any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMemberships", reflect.TypeOf((*MockTeamRepository)(nil).UpsertMemberships), ctx, exec, teamName, members)
+}
+
+// MockPullRequestRepository is a mock of PullRequestRepository interface.
+type MockPullRequestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPullRequestRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPullRequestRepositoryMockRecorder is the mock recorder for MockPullRequestRepository.
+type MockPullRequestRepositoryMockRecorder struct {
+	mock *MockPullRequestRepository
+}
+
+// NewMockPullRequestRepository creates a new mock instance.
+func NewMockPullRequestRepository(ctrl *gomock.Controller) *MockPullRequestRepository {
+	mock := &MockPullRequestRepository{ctrl: ctrl}
+	mock.recorder = &MockPullRequestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPullRequestRepository) EXPECT() *MockPullRequestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AssignManyReviewers mocks base method.
+func (m *MockPullRequestRepository) AssignManyReviewers(ctx context.Context, exec sqlx.ExtContext, prID string, reviewerIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignManyReviewers", ctx, exec, prID, reviewerIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignManyReviewers indicates an expected call of AssignManyReviewers.
+func (mr *MockPullRequestRepositoryMockRecorder) AssignManyReviewers(ctx, exec, prID, reviewerIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignManyReviewers", reflect.TypeOf((*MockPullRequestRepository)(nil).AssignManyReviewers), ctx, exec, prID, reviewerIDs)
+}
+
+// AssignReviewer mocks base method.
+func (m *MockPullRequestRepository) AssignReviewer(ctx context.Context, exec sqlx.ExtContext, prID, reviewerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignReviewer", ctx, exec, prID, reviewerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignReviewer indicates an expected call of AssignReviewer.
+func (mr *MockPullRequestRepositoryMockRecorder) AssignReviewer(ctx, exec, prID, reviewerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignReviewer", reflect.TypeOf((*MockPullRequestRepository)(nil).AssignReviewer), ctx, exec, prID, reviewerID)
+}
+
+// CreateEvents mocks base method.
+func (m *MockPullRequestRepository) CreateEvents(ctx context.Context, exec sqlx.ExtContext, events []models.PullRequestEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvents", ctx, exec, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEvents indicates an expected call of CreateEvents.
+func (mr *MockPullRequestRepositoryMockRecorder) CreateEvents(ctx, exec, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvents", reflect.TypeOf((*MockPullRequestRepository)(nil).CreateEvents), ctx, exec, events)
+}
+
+// CreatePullRequest mocks base method.
+func (m *MockPullRequestRepository) CreatePullRequest(ctx context.Context, exec sqlx.ExtContext, pr *models.PullRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePullRequest", ctx, exec, pr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePullRequest indicates an expected call of CreatePullRequest.
+func (mr *MockPullRequestRepositoryMockRecorder) CreatePullRequest(ctx, exec, pr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockPullRequestRepository)(nil).CreatePullRequest), ctx, exec, pr)
+}
+
+// CreateReview mocks base method.
+func (m *MockPullRequestRepository) CreateReview(ctx context.Context, exec sqlx.ExtContext, review *models.Review) (*models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", ctx, exec, review)
+	ret0, _ := ret[0].(*models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockPullRequestRepositoryMockRecorder) CreateReview(ctx, exec, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockPullRequestRepository)(nil).CreateReview), ctx, exec, review)
+}
+
+// CreateSLANotice mocks base method.
+func (m *MockPullRequestRepository) CreateSLANotice(ctx context.Context, exec sqlx.ExtContext, notice *models.SLANotice) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSLANotice", ctx, exec, notice)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSLANotice indicates an expected call of CreateSLANotice.
+func (mr *MockPullRequestRepositoryMockRecorder) CreateSLANotice(ctx, exec, notice any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSLANotice", reflect.TypeOf((*MockPullRequestRepository)(nil).CreateSLANotice), ctx, exec, notice)
+}
+
+// DeleteAssignedByPullRequestID mocks base method.
+func (m *MockPullRequestRepository) DeleteAssignedByPullRequestID(ctx context.Context, exec sqlx.ExtContext, prID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAssignedByPullRequestID", ctx, exec, prID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAssignedByPullRequestID indicates an expected call of DeleteAssignedByPullRequestID.
+func (mr *MockPullRequestRepositoryMockRecorder) DeleteAssignedByPullRequestID(ctx, exec, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAssignedByPullRequestID", reflect.TypeOf((*MockPullRequestRepository)(nil).DeleteAssignedByPullRequestID), ctx, exec, prID)
+}
+
+// DeleteAssignedByReviewerID mocks base method.
+func (m *MockPullRequestRepository) DeleteAssignedByReviewerID(ctx context.Context, exec sqlx.ExtContext, reviewerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAssignedByReviewerID", ctx, exec, reviewerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAssignedByReviewerID indicates an expected call of DeleteAssignedByReviewerID.
+func (mr *MockPullRequestRepositoryMockRecorder) DeleteAssignedByReviewerID(ctx, exec, reviewerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAssignedByReviewerID", reflect.TypeOf((*MockPullRequestRepository)(nil).DeleteAssignedByReviewerID), ctx, exec, reviewerID)
+}
+
+// DeleteAssignedReviewer mocks base method.
+func (m *MockPullRequestRepository) DeleteAssignedReviewer(ctx context.Context, exec sqlx.ExtContext, prID, reviewerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAssignedReviewer", ctx, exec, prID, reviewerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAssignedReviewer indicates an expected call of DeleteAssignedReviewer.
+func (mr *MockPullRequestRepositoryMockRecorder) DeleteAssignedReviewer(ctx, exec, prID, reviewerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAssignedReviewer", reflect.TypeOf((*MockPullRequestRepository)(nil).DeleteAssignedReviewer), ctx, exec, prID, reviewerID)
+}
+
+// GetActivePullRequestIDsByTeam mocks base method.
+func (m *MockPullRequestRepository) GetActivePullRequestIDsByTeam(ctx context.Context, exec sqlx.ExtContext, teamName string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivePullRequestIDsByTeam", ctx, exec, teamName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivePullRequestIDsByTeam indicates an expected call of GetActivePullRequestIDsByTeam.
+func (mr *MockPullRequestRepositoryMockRecorder) GetActivePullRequestIDsByTeam(ctx, exec, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivePullRequestIDsByTeam", reflect.TypeOf((*MockPullRequestRepository)(nil).GetActivePullRequestIDsByTeam), ctx, exec, teamName)
+}
+
+// GetBlockedPullRequests mocks base method.
+func (m *MockPullRequestRepository) GetBlockedPullRequests(ctx context.Context, exec sqlx.ExtContext, prID string) ([]models.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockedPullRequests", ctx, exec, prID)
+	ret0, _ := ret[0].([]models.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockedPullRequests indicates an expected call of GetBlockedPullRequests.
+func (mr *MockPullRequestRepositoryMockRecorder) GetBlockedPullRequests(ctx, exec, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockedPullRequests", reflect.TypeOf((*MockPullRequestRepository)(nil).GetBlockedPullRequests), ctx, exec, prID)
+}
+
+// GetDependencyGraph mocks base method.
+func (m *MockPullRequestRepository) GetDependencyGraph(ctx context.Context, exec sqlx.ExtContext, prID string) ([]models.PullRequestDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDependencyGraph", ctx, exec, prID)
+	ret0, _ := ret[0].([]models.PullRequestDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDependencyGraph indicates an expected call of GetDependencyGraph.
+func (mr *MockPullRequestRepositoryMockRecorder) GetDependencyGraph(ctx, exec, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDependencyGraph", reflect.TypeOf((*MockPullRequestRepository)(nil).GetDependencyGraph), ctx, exec, prID)
+}
+
+// GetDueSLATimers mocks base method.
+func (m *MockPullRequestRepository) GetDueSLATimers(ctx context.Context, exec sqlx.ExtContext, dueBy time.Time) ([]models.SLATimer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueSLATimers", ctx, exec, dueBy)
+	ret0, _ := ret[0].([]models.SLATimer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueSLATimers indicates an expected call of GetDueSLATimers.
+func (mr *MockPullRequestRepositoryMockRecorder) GetDueSLATimers(ctx, exec, dueBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueSLATimers", reflect.TypeOf((*MockPullRequestRepository)(nil).GetDueSLATimers), ctx, exec, dueBy)
+}
+
+// GetOpenAssignmentsByReviewers mocks base method.
+func (m *MockPullRequestRepository) GetOpenAssignmentsByReviewers(ctx context.Context, exec sqlx.ExtContext, reviewerIDs []string, teamName string) ([]models.OpenAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenAssignmentsByReviewers", ctx, exec, reviewerIDs, teamName)
+	ret0, _ := ret[0].([]models.OpenAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenAssignmentsByReviewers indicates an expected call of GetOpenAssignmentsByReviewers.
+func (mr *MockPullRequestRepositoryMockRecorder) GetOpenAssignmentsByReviewers(ctx, exec, reviewerIDs, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenAssignmentsByReviewers", reflect.TypeOf((*MockPullRequestRepository)(nil).GetOpenAssignmentsByReviewers), ctx, exec, reviewerIDs, teamName)
+}
+
+// GetOpenPullRequestIDsByReviewer mocks base method.
+func (m *MockPullRequestRepository) GetOpenPullRequestIDsByReviewer(ctx context.Context, exec sqlx.ExtContext, reviewerID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenPullRequestIDsByReviewer", ctx, exec, reviewerID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenPullRequestIDsByReviewer indicates an expected call of GetOpenPullRequestIDsByReviewer.
+func (mr *MockPullRequestRepositoryMockRecorder) GetOpenPullRequestIDsByReviewer(ctx, exec, reviewerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenPullRequestIDsByReviewer", reflect.TypeOf((*MockPullRequestRepository)(nil).GetOpenPullRequestIDsByReviewer), ctx, exec, reviewerID)
+}
+
+// GetPullRequestByID mocks base method.
+func (m *MockPullRequestRepository) GetPullRequestByID(ctx context.Context, exec sqlx.ExtContext, prID string) (*models.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequestByID", ctx, exec, prID)
+	ret0, _ := ret[0].(*models.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequestByID indicates an expected call of GetPullRequestByID.
+func (mr *MockPullRequestRepositoryMockRecorder) GetPullRequestByID(ctx, exec, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequestByID", reflect.TypeOf((*MockPullRequestRepository)(nil).GetPullRequestByID), ctx, exec, prID)
+}
+
+// GetPullRequestEvents mocks base method.
+func (m *MockPullRequestRepository) GetPullRequestEvents(ctx context.Context, exec sqlx.ExtContext, prID string) ([]models.PullRequestEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequestEvents", ctx, exec, prID)
+	ret0, _ := ret[0].([]models.PullRequestEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequestEvents indicates an expected call of GetPullRequestEvents.
+func (mr *MockPullRequestRepositoryMockRecorder) GetPullRequestEvents(ctx, exec, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequestEvents", reflect.TypeOf((*MockPullRequestRepository)(nil).GetPullRequestEvents), ctx, exec, prID)
+}
+
+// GetPullRequestReviews mocks base method.
+func (m *MockPullRequestRepository) GetPullRequestReviews(ctx context.Context, exec sqlx.ExtContext, prID string) ([]models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequestReviews", ctx, exec, prID)
+	ret0, _ := ret[0].([]models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequestReviews indicates an expected call of GetPullRequestReviews.
+func (mr *MockPullRequestRepositoryMockRecorder) GetPullRequestReviews(ctx, exec, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequestReviews", reflect.TypeOf((*MockPullRequestRepository)(nil).GetPullRequestReviews), ctx, exec, prID)
+}
+
+// GetQuantityPRReviewers mocks base method.
+func (m *MockPullRequestRepository) GetQuantityPRReviewers(ctx context.Context, exec sqlx.ExtContext, repository string) ([]models.PullRequestQuantityReviewers, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuantityPRReviewers", ctx, exec, repository)
+	ret0, _ := ret[0].([]models.PullRequestQuantityReviewers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuantityPRReviewers indicates an expected call of GetQuantityPRReviewers.
+func (mr *MockPullRequestRepositoryMockRecorder) GetQuantityPRReviewers(ctx, exec, repository any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuantityPRReviewers", reflect.TypeOf((*MockPullRequestRepository)(nil).GetQuantityPRReviewers), ctx, exec, repository)
+}
+
+// GetTeamsPullRequestCounts mocks base method.
+func (m *MockPullRequestRepository) GetTeamsPullRequestCounts(ctx context.Context, exec sqlx.ExtContext, teamNames []string, repository string) ([]models.TeamPullRequestCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamsPullRequestCounts", ctx, exec, teamNames, repository)
+	ret0, _ := ret[0].([]models.TeamPullRequestCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamsPullRequestCounts indicates an expected call of GetTeamsPullRequestCounts.
+func (mr *MockPullRequestRepositoryMockRecorder) GetTeamsPullRequestCounts(ctx, exec, teamNames, repository any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamsPullRequestCounts", reflect.TypeOf((*MockPullRequestRepository)(nil).GetTeamsPullRequestCounts), ctx, exec, teamNames, repository)
+}
+
+// GetUnmergedDependencies mocks base method.
+func (m *MockPullRequestRepository) GetUnmergedDependencies(ctx context.Context, exec sqlx.ExtContext, prID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnmergedDependencies", ctx, exec, prID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnmergedDependencies indicates an expected call of GetUnmergedDependencies.
+func (mr *MockPullRequestRepositoryMockRecorder) GetUnmergedDependencies(ctx, exec, prID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnmergedDependencies", reflect.TypeOf((*MockPullRequestRepository)(nil).GetUnmergedDependencies), ctx, exec, prID)
+}
+
+// HasDependencyPath mocks base method.
+func (m *MockPullRequestRepository) HasDependencyPath(ctx context.Context, exec sqlx.ExtContext, fromIDs []string, toID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasDependencyPath", ctx, exec, fromIDs, toID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasDependencyPath indicates an expected call of HasDependencyPath.
+func (mr *MockPullRequestRepositoryMockRecorder) HasDependencyPath(ctx, exec, fromIDs, toID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasDependencyPath", reflect.TypeOf((*MockPullRequestRepository)(nil).HasDependencyPath), ctx, exec, fromIDs, toID)
+}
+
+// ListPullRequests mocks base method.
+func (m *MockPullRequestRepository) ListPullRequests(ctx context.Context, exec sqlx.ExtContext, filter *models.PullRequestFilter) ([]models.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPullRequests", ctx, exec, filter)
+	ret0, _ := ret[0].([]models.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPullRequests indicates an expected call of ListPullRequests.
+func (mr *MockPullRequestRepositoryMockRecorder) ListPullRequests(ctx, exec, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReviews", reflect.TypeOf((*MockUserRepository)(nil).GetUserReviews), ctx, exec, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPullRequests", reflect.TypeOf((*MockPullRequestRepository)(nil).ListPullRequests), ctx, exec, filter)
 }
 
-// UpdateUserStatus mocks base method.
-func (m *MockUserRepository) UpdateUserStatus(ctx context.Context, exec sqlx.ExtContext, userID string, isActive bool) (*models.User, error) {
+// LockAssignments mocks base method.
+func (m *MockPullRequestRepository) LockAssignments(ctx context.Context, exec sqlx.ExtContext) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserStatus", ctx, exec, userID, isActive)
-	ret0, _ := ret[0].(*models.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "LockAssignments", ctx, exec)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// UpdateUserStatus indicates an expected call of UpdateUserStatus.
-func (mr *MockUserRepositoryMockRecorder) UpdateUserStatus(ctx, exec, userID, isActive any) *gomock.Call {
+// LockAssignments indicates an expected call of LockAssignments.
+func (mr *MockPullRequestRepositoryMockRecorder) LockAssignments(ctx, exec any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserStatus", reflect.TypeOf((*MockUserRepository)(nil).UpdateUserStatus), ctx, exec, userID, isActive)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAssignments", reflect.TypeOf((*MockPullRequestRepository)(nil).LockAssignments), ctx, exec)
 }
 
-// MockTeamRepository is a mock of TeamRepository interface.
-type MockTeamRepository struct {
+// LockDependencies mocks base method.
+func (m *MockPullRequestRepository) LockDependencies(ctx context.Context, exec sqlx.ExtContext) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockDependencies", ctx, exec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockDependencies indicates an expected call of LockDependencies.
+func (mr *MockPullRequestRepositoryMockRecorder) LockDependencies(ctx, exec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockDependencies", reflect.TypeOf((*MockPullRequestRepository)(nil).LockDependencies), ctx, exec)
+}
+
+// MergePullRequest mocks base method.
+func (m *MockPullRequestRepository) MergePullRequest(ctx context.Context, exec sqlx.ExtContext, prID string, override bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergePullRequest", ctx, exec, prID, override)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergePullRequest indicates an expected call of MergePullRequest.
+func (mr *MockPullRequestRepositoryMockRecorder) MergePullRequest(ctx, exec, prID, override any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePullRequest", reflect.TypeOf((*MockPullRequestRepository)(nil).MergePullRequest), ctx, exec, prID, override)
+}
+
+// ReplaceManyReviewers mocks base method.
+func (m *MockPullRequestRepository) ReplaceManyReviewers(ctx context.Context, exec sqlx.ExtContext, replacements []models.ReviewerReplacement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceManyReviewers", ctx, exec, replacements)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceManyReviewers indicates an expected call of ReplaceManyReviewers.
+func (mr *MockPullRequestRepositoryMockRecorder) ReplaceManyReviewers(ctx, exec, replacements any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceManyReviewers", reflect.TypeOf((*MockPullRequestRepository)(nil).ReplaceManyReviewers), ctx, exec, replacements)
+}
+
+// SetPullRequestDependencies mocks base method.
+func (m *MockPullRequestRepository) SetPullRequestDependencies(ctx context.Context, exec sqlx.ExtContext, prID string, dependsOn []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPullRequestDependencies", ctx, exec, prID, dependsOn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPullRequestDependencies indicates an expected call of SetPullRequestDependencies.
+func (mr *MockPullRequestRepositoryMockRecorder) SetPullRequestDependencies(ctx, exec, prID, dependsOn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPullRequestDependencies", reflect.TypeOf((*MockPullRequestRepository)(nil).SetPullRequestDependencies), ctx, exec, prID, dependsOn)
+}
+
+// SetPullRequestLabels mocks base method.
+func (m *MockPullRequestRepository) SetPullRequestLabels(ctx context.Context, exec sqlx.ExtContext, prID string, labels []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPullRequestLabels", ctx, exec, prID, labels)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPullRequestLabels indicates an expected call of SetPullRequestLabels.
+func (mr *MockPullRequestRepositoryMockRecorder) SetPullRequestLabels(ctx, exec, prID, labels any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPullRequestLabels", reflect.TypeOf((*MockPullRequestRepository)(nil).SetPullRequestLabels), ctx, exec, prID, labels)
+}
+
+// SetPullRequestsTeam mocks base method.
+func (m *MockPullRequestRepository) SetPullRequestsTeam(ctx context.Context, exec sqlx.ExtContext, prIDs []string, teamName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPullRequestsTeam", ctx, exec, prIDs, teamName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPullRequestsTeam indicates an expected call of SetPullRequestsTeam.
+func (mr *MockPullRequestRepositoryMockRecorder) SetPullRequestsTeam(ctx, exec, prIDs, teamName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPullRequestsTeam", reflect.TypeOf((*MockPullRequestRepository)(nil).SetPullRequestsTeam), ctx, exec, prIDs, teamName)
+}
+
+// UpdatePullRequestStatus mocks base method.
+func (m *MockPullRequestRepository) UpdatePullRequestStatus(ctx context.Context, exec sqlx.ExtContext, prID string, from, to models.PullRequestStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePullRequestStatus", ctx, exec, prID, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePullRequestStatus indicates an expected call of UpdatePullRequestStatus.
+func (mr *MockPullRequestRepositoryMockRecorder) UpdatePullRequestStatus(ctx, exec, prID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePullRequestStatus", reflect.TypeOf((*MockPullRequestRepository)(nil).UpdatePullRequestStatus), ctx, exec, prID, from, to)
+}
+
+// MockOwnershipRepository is a mock of OwnershipRepository interface.
+type MockOwnershipRepository struct {
 	ctrl     *gomock.Controller
-	recorder *MockTeamRepositoryMockRecorder
+	recorder *MockOwnershipRepositoryMockRecorder
 	isgomock struct{}
 }
 
-// MockTeamRepositoryMockRecorder is the mock recorder for MockTeamRepository.
-type MockTeamRepositoryMockRecorder struct {
-	mock *MockTeamRepository
+// MockOwnershipRepositoryMockRecorder is the mock recorder for MockOwnershipRepository.
+type MockOwnershipRepositoryMockRecorder struct {
+	mock *MockOwnershipRepository
 }
 
-// NewMockTeamRepository creates a new mock instance.
-func NewMockTeamRepository(ctrl *gomock.Controller) *MockTeamRepository {
-	mock := &MockTeamRepository{ctrl: ctrl}
-	mock.recorder = &MockTeamRepositoryMockRecorder{mock}
+// NewMockOwnershipRepository creates a new mock instance.
+func NewMockOwnershipRepository(ctrl *gomock.Controller) *MockOwnershipRepository {
+	mock := &MockOwnershipRepository{ctrl: ctrl}
+	mock.recorder = &MockOwnershipRepositoryMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockTeamRepository) EXPECT() *MockTeamRepositoryMockRecorder {
+func (m *MockOwnershipRepository) EXPECT() *MockOwnershipRepositoryMockRecorder {
 	return m.recorder
 }
 
-// CreateTeam mocks base method.
-func (m *MockTeamRepository) CreateTeam(ctx context.Context, exec sqlx.ExtContext, teamName string) error {
+// CreateRepository mocks base method.
+func (m *MockOwnershipRepository) CreateRepository(ctx context.Context, exec sqlx.ExtContext, repository string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTeam", ctx, exec, teamName)
+	ret := m.ctrl.Call(m, "CreateRepository", ctx, exec, repository)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateTeam indicates an expected call of CreateTeam.
-func (mr *MockTeamRepositoryMockRecorder) CreateTeam(ctx, exec, teamName any) *gomock.Call {
+// CreateRepository indicates an expected call of CreateRepository.
+func (mr *MockOwnershipRepositoryMockRecorder) CreateRepository(ctx, exec, repository any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTeam", reflect.TypeOf((*MockTeamRepository)(nil).CreateTeam), ctx, exec, teamName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRepository", reflect.TypeOf((*MockOwnershipRepository)(nil).CreateRepository), ctx, exec, repository)
 }
 
-// GetActiveUsersTeamWithException mocks base method.
-func (m *MockTeamRepository) GetActiveUsersTeamWithException(ctx context.Context, exec sqlx.ExtContext, userID string, exceptions []string, limit int) ([]string, error) {
+// GetCodeowners mocks base method.
+func (m *MockOwnershipRepository) GetCodeowners(ctx context.Context, exec sqlx.ExtContext, repository string) (*models.Codeowners, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActiveUsersTeamWithException", ctx, exec, userID, exceptions, limit)
-	ret0, _ := ret[0].([]string)
+	ret := m.ctrl.Call(m, "GetCodeowners", ctx, exec, repository)
+	ret0, _ := ret[0].(*models.Codeowners)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetActiveUsersTeamWithException indicates an expected call of GetActiveUsersTeamWithException.
-func (mr *MockTeamRepositoryMockRecorder) GetActiveUsersTeamWithException(ctx, exec, userID, exceptions, limit any) *gomock.Call {
+// GetCodeowners indicates an expected call of GetCodeowners.
+func (mr *MockOwnershipRepositoryMockRecorder) GetCodeowners(ctx, exec, repository any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUsersTeamWithException", reflect.TypeOf((*MockTeamRepository)(nil).GetActiveUsersTeamWithException), ctx, exec, userID, exceptions, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCodeowners", reflect.TypeOf((*MockOwnershipRepository)(nil).GetCodeowners), ctx, exec, repository)
 }
 
-// GetTeamWithMembers mocks base method.
-func (m *MockTeamRepository) GetTeamWithMembers(ctx context.Context, exec sqlx.ExtContext, teamName string) (*models.Team, error) {
+// GetRepository mocks base method.
+func (m *MockOwnershipRepository) GetRepository(ctx context.Context, exec sqlx.ExtContext, repository string) (*models.Repository, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTeamWithMembers", ctx, exec, teamName)
-	ret0, _ := ret[0].(*models.Team)
+	ret := m.ctrl.Call(m, "GetRepository", ctx, exec, repository)
+	ret0, _ := ret[0].(*models.Repository)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTeamWithMembers indicates an expected call of GetTeamWithMembers.
-func (mr *MockTeamRepositoryMockRecorder) GetTeamWithMembers(ctx, exec, teamName any) *gomock.Call {
+// GetRepository indicates an expected call of GetRepository.
+func (mr *MockOwnershipRepositoryMockRecorder) GetRepository(ctx, exec, repository any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamWithMembers", reflect.TypeOf((*MockTeamRepository)(nil).GetTeamWithMembers), ctx, exec, teamName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepository", reflect.TypeOf((*MockOwnershipRepository)(nil).GetRepository), ctx, exec, repository)
 }
 
-// GetUsersIDFromUserTeam mocks base method.
-func (m *MockTeamRepository) GetUsersIDFromUserTeam(ctx context.Context, exec sqlx.ExtContext, userID string, limit int) ([]string, error) {
+// TransferRepositories mocks base method.
+func (m *MockOwnershipRepository) TransferRepositories(ctx context.Context, exec sqlx.ExtContext, fromTeam, toTeam string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUsersIDFromUserTeam", ctx, exec, userID, limit)
-	ret0, _ := ret[0].([]string)
+	ret := m.ctrl.Call(m, "TransferRepositories", ctx, exec, fromTeam, toTeam)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferRepositories indicates an expected call of TransferRepositories.
+func (mr *MockOwnershipRepositoryMockRecorder) TransferRepositories(ctx, exec, fromTeam, toTeam any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferRepositories", reflect.TypeOf((*MockOwnershipRepository)(nil).TransferRepositories), ctx, exec, fromTeam, toTeam)
+}
+
+// UpsertCodeowners mocks base method.
+func (m *MockOwnershipRepository) UpsertCodeowners(ctx context.Context, exec sqlx.ExtContext, repository, content string) (*models.Codeowners, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCodeowners", ctx, exec, repository, content)
+	ret0, _ := ret[0].(*models.Codeowners)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUsersIDFromUserTeam indicates an expected call of GetUsersIDFromUserTeam.
-func (mr *MockTeamRepositoryMockRecorder) GetUsersIDFromUserTeam(ctx, exec, userID, limit any) *gomock.Call {
+// UpsertCodeowners indicates an expected call of UpsertCodeowners.
+func (mr *MockOwnershipRepositoryMockRecorder) UpsertCodeowners(ctx, exec, repository, content any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersIDFromUserTeam", reflect.TypeOf((*MockTeamRepository)(nil).GetUsersIDFromUserTeam), ctx, exec, userID, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCodeowners", reflect.TypeOf((*MockOwnershipRepository)(nil).UpsertCodeowners), ctx, exec, repository, content)
 }
 
-// MockPullRequestRepository is a mock of PullRequestRepository interface.
-type MockPullRequestRepository struct {
+// UpsertRepository mocks base method.
+func (m *MockOwnershipRepository) UpsertRepository(ctx context.Context, exec sqlx.ExtContext, repository *models.Repository) (*models.Repository, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRepository", ctx, exec, repository)
+	ret0, _ := ret[0].(*models.Repository)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertRepository indicates an expected call of UpsertRepository.
+func (mr *MockOwnershipRepositoryMockRecorder) UpsertRepository(ctx, exec, repository any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRepository", reflect.TypeOf((*MockOwnershipRepository)(nil).UpsertRepository), ctx, exec, repository)
+}
+
+// MockAvailabilityRepository is a mock of AvailabilityRepository interface.
+type MockAvailabilityRepository struct {
 	ctrl     *gomock.Controller
-	recorder *MockPullRequestRepositoryMockRecorder
+	recorder *MockAvailabilityRepositoryMockRecorder
 	isgomock struct{}
 }
 
-// MockPullRequestRepositoryMockRecorder is the mock recorder for MockPullRequestRepository.
-type MockPullRequestRepositoryMockRecorder struct {
-	mock *MockPullRequestRepository
+// MockAvailabilityRepositoryMockRecorder is the mock recorder for MockAvailabilityRepository.
+type MockAvailabilityRepositoryMockRecorder struct {
+	mock *MockAvailabilityRepository
 }
 
-// NewMockPullRequestRepository creates a new mock instance.
-func NewMockPullRequestRepository(ctrl *gomock.Controller) *MockPullRequestRepository {
-	mock := &MockPullRequestRepository{ctrl: ctrl}
-	mock.recorder = &MockPullRequestRepositoryMockRecorder{mock}
+// NewMockAvailabilityRepository creates a new mock instance.
+func NewMockAvailabilityRepository(ctrl *gomock.Controller) *MockAvailabilityRepository {
+	mock := &MockAvailabilityRepository{ctrl: ctrl}
+	mock.recorder = &MockAvailabilityRepositoryMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockPullRequestRepository) EXPECT() *MockPullRequestRepositoryMockRecorder {
+func (m *MockAvailabilityRepository) EXPECT() *MockAvailabilityRepositoryMockRecorder {
 	return m.recorder
 }
 
-// AssignManyReviewers mocks base method.
-func (m *MockPullRequestRepository) AssignManyReviewers(ctx context.Context, exec sqlx.ExtContext, prID string, reviewerIDs []string) error {
+// CreatePeriod mocks base method.
+func (m *MockAvailabilityRepository) CreatePeriod(ctx context.Context, exec sqlx.ExtContext, period *models.UnavailabilityPeriod) (*models.UnavailabilityPeriod, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignManyReviewers", ctx, exec, prID, reviewerIDs)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreatePeriod", ctx, exec, period)
+	ret0, _ := ret[0].(*models.UnavailabilityPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// AssignManyReviewers indicates an expected call of AssignManyReviewers.
-func (mr *MockPullRequestRepositoryMockRecorder) AssignManyReviewers(ctx, exec, prID, reviewerIDs any) *gomock.Call {
+// CreatePeriod indicates an expected call of CreatePeriod.
+func (mr *MockAvailabilityRepositoryMockRecorder) CreatePeriod(ctx, exec, period any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignManyReviewers", reflect.TypeOf((*MockPullRequestRepository)(nil).AssignManyReviewers), ctx, exec, prID, reviewerIDs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePeriod", reflect.TypeOf((*MockAvailabilityRepository)(nil).CreatePeriod), ctx, exec, period)
 }
 
-// AssignReviewer mocks base method.
-func (m *MockPullRequestRepository) AssignReviewer(ctx context.Context, exec sqlx.ExtContext, prID, reviewerID string) error {
+// DeletePeriod mocks base method.
+func (m *MockAvailabilityRepository) DeletePeriod(ctx context.Context, exec sqlx.ExtContext, userID string, periodID int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignReviewer", ctx, exec, prID, reviewerID)
+	ret := m.ctrl.Call(m, "DeletePeriod", ctx, exec, userID, periodID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AssignReviewer indicates an expected call of AssignReviewer.
-func (mr *MockPullRequestRepositoryMockRecorder) AssignReviewer(ctx, exec, prID, reviewerID any) *gomock.Call {
+// DeletePeriod indicates an expected call of DeletePeriod.
+func (mr *MockAvailabilityRepositoryMockRecorder) DeletePeriod(ctx, exec, userID, periodID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignReviewer", reflect.TypeOf((*MockPullRequestRepository)(nil).AssignReviewer), ctx, exec, prID, reviewerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePeriod", reflect.TypeOf((*MockAvailabilityRepository)(nil).DeletePeriod), ctx, exec, userID, periodID)
 }
 
-// CreatePullRequest mocks base method.
-func (m *MockPullRequestRepository) CreatePullRequest(ctx context.Context, exec sqlx.ExtContext, pr *models.PullRequest) error {
+// DeleteQuietHours mocks base method.
+func (m *MockAvailabilityRepository) DeleteQuietHours(ctx context.Context, exec sqlx.ExtContext, userID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePullRequest", ctx, exec, pr)
+	ret := m.ctrl.Call(m, "DeleteQuietHours", ctx, exec, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreatePullRequest indicates an expected call of CreatePullRequest.
-func (mr *MockPullRequestRepositoryMockRecorder) CreatePullRequest(ctx, exec, pr any) *gomock.Call {
+// DeleteQuietHours indicates an expected call of DeleteQuietHours.
+func (mr *MockAvailabilityRepositoryMockRecorder) DeleteQuietHours(ctx, exec, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockPullRequestRepository)(nil).CreatePullRequest), ctx, exec, pr)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuietHours", reflect.TypeOf((*MockAvailabilityRepository)(nil).DeleteQuietHours), ctx, exec, userID)
 }
 
-// DeleteAssignedByReviewerID mocks base method.
-func (m *MockPullRequestRepository) DeleteAssignedByReviewerID(ctx context.Context, exec sqlx.ExtContext, reviewerID string) error {
+// DeleteWorkingSchedule mocks base method.
+func (m *MockAvailabilityRepository) DeleteWorkingSchedule(ctx context.Context, exec sqlx.ExtContext, userID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAssignedByReviewerID", ctx, exec, reviewerID)
+	ret := m.ctrl.Call(m, "DeleteWorkingSchedule", ctx, exec, userID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteAssignedByReviewerID indicates an expected call of DeleteAssignedByReviewerID.
-func (mr *MockPullRequestRepositoryMockRecorder) DeleteAssignedByReviewerID(ctx, exec, reviewerID any) *gomock.Call {
+// DeleteWorkingSchedule indicates an expected call of DeleteWorkingSchedule.
+func (mr *MockAvailabilityRepositoryMockRecorder) DeleteWorkingSchedule(ctx, exec, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAssignedByReviewerID", reflect.TypeOf((*MockPullRequestRepository)(nil).DeleteAssignedByReviewerID), ctx, exec, reviewerID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkingSchedule", reflect.TypeOf((*MockAvailabilityRepository)(nil).DeleteWorkingSchedule), ctx, exec, userID)
 }
 
-// GetPullRequestByID mocks base method.
-func (m *MockPullRequestRepository) GetPullRequestByID(ctx context.Context, exec sqlx.ExtContext, prID string) (*models.PullRequest, error) {
+// GetQuietHours mocks base method.
+func (m *MockAvailabilityRepository) GetQuietHours(ctx context.Context, exec sqlx.ExtContext, userID string) (*models.QuietHours, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPullRequestByID", ctx, exec, prID)
-	ret0, _ := ret[0].(*models.PullRequest)
+	ret := m.ctrl.Call(m, "GetQuietHours", ctx, exec, userID)
+	ret0, _ := ret[0].(*models.QuietHours)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPullRequestByID indicates an expected call of GetPullRequestByID.
-func (mr *MockPullRequestRepositoryMockRecorder) GetPullRequestByID(ctx, exec, prID any) *gomock.Call {
+// GetQuietHours indicates an expected call of GetQuietHours.
+func (mr *MockAvailabilityRepositoryMockRecorder) GetQuietHours(ctx, exec, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequestByID", reflect.TypeOf((*MockPullRequestRepository)(nil).GetPullRequestByID), ctx, exec, prID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuietHours", reflect.TypeOf((*MockAvailabilityRepository)(nil).GetQuietHours), ctx, exec, userID)
 }
 
-// GetQuantityPRReviewers mocks base method.
-func (m *MockPullRequestRepository) GetQuantityPRReviewers(ctx context.Context, exec sqlx.ExtContext) ([]models.PullRequestQuantityReviewers, error) {
+// GetUserPeriods mocks base method.
+func (m *MockAvailabilityRepository) GetUserPeriods(ctx context.Context, exec sqlx.ExtContext, userID string) ([]models.UnavailabilityPeriod, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetQuantityPRReviewers", ctx, exec)
-	ret0, _ := ret[0].([]models.PullRequestQuantityReviewers)
+	ret := m.ctrl.Call(m, "GetUserPeriods", ctx, exec, userID)
+	ret0, _ := ret[0].([]models.UnavailabilityPeriod)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetQuantityPRReviewers indicates an expected call of GetQuantityPRReviewers.
-func (mr *MockPullRequestRepositoryMockRecorder) GetQuantityPRReviewers(ctx, exec any) *gomock.Call {
+// GetUserPeriods indicates an expected call of GetUserPeriods.
+func (mr *MockAvailabilityRepositoryMockRecorder) GetUserPeriods(ctx, exec, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuantityPRReviewers", reflect.TypeOf((*MockPullRequestRepository)(nil).GetQuantityPRReviewers), ctx, exec)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPeriods", reflect.TypeOf((*MockAvailabilityRepository)(nil).GetUserPeriods), ctx, exec, userID)
 }
 
-// MergePullRequest mocks base method.
-func (m *MockPullRequestRepository) MergePullRequest(ctx context.Context, exec sqlx.ExtContext, prID string) error {
+// GetWorkingSchedule mocks base method.
+func (m *MockAvailabilityRepository) GetWorkingSchedule(ctx context.Context, exec sqlx.ExtContext, userID string) (*models.WorkingSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkingSchedule", ctx, exec, userID)
+	ret0, _ := ret[0].(*models.WorkingSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkingSchedule indicates an expected call of GetWorkingSchedule.
+func (mr *MockAvailabilityRepositoryMockRecorder) GetWorkingSchedule(ctx, exec, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkingSchedule", reflect.TypeOf((*MockAvailabilityRepository)(nil).GetWorkingSchedule), ctx, exec, userID)
+}
+
+// TimezoneExists mocks base method.
+func (m *MockAvailabilityRepository) TimezoneExists(ctx context.Context, exec sqlx.ExtContext, timezone string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TimezoneExists", ctx, exec, timezone)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TimezoneExists indicates an expected call of TimezoneExists.
+func (mr *MockAvailabilityRepositoryMockRecorder) TimezoneExists(ctx, exec, timezone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimezoneExists", reflect.TypeOf((*MockAvailabilityRepository)(nil).TimezoneExists), ctx, exec, timezone)
+}
+
+// UpsertQuietHours mocks base method.
+func (m *MockAvailabilityRepository) UpsertQuietHours(ctx context.Context, exec sqlx.ExtContext, quietHours *models.QuietHours) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MergePullRequest", ctx, exec, prID)
+	ret := m.ctrl.Call(m, "UpsertQuietHours", ctx, exec, quietHours)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// MergePullRequest indicates an expected call of MergePullRequest.
-func (mr *MockPullRequestRepositoryMockRecorder) MergePullRequest(ctx, exec, prID any) *gomock.Call {
+// UpsertQuietHours indicates an expected call of UpsertQuietHours.
+func (mr *MockAvailabilityRepositoryMockRecorder) UpsertQuietHours(ctx, exec, quietHours any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertQuietHours", reflect.TypeOf((*MockAvailabilityRepository)(nil).UpsertQuietHours), ctx, exec, quietHours)
+}
+
+// UpsertWorkingSchedule mocks base method.
+func (m *MockAvailabilityRepository) UpsertWorkingSchedule(ctx context.Context, exec sqlx.ExtContext, schedule *models.WorkingSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertWorkingSchedule", ctx, exec, schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertWorkingSchedule indicates an expected call of UpsertWorkingSchedule.
+func (mr *MockAvailabilityRepositoryMockRecorder) UpsertWorkingSchedule(ctx, exec, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertWorkingSchedule", reflect.TypeOf((*MockAvailabilityRepository)(nil).UpsertWorkingSchedule), ctx, exec, schedule)
+}
+
+// MockSchedulerRepository is a mock of SchedulerRepository interface.
+type MockSchedulerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulerRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockSchedulerRepositoryMockRecorder is the mock recorder for MockSchedulerRepository.
+type MockSchedulerRepositoryMockRecorder struct {
+	mock *MockSchedulerRepository
+}
+
+// NewMockSchedulerRepository creates a new mock instance.
+func NewMockSchedulerRepository(ctrl *gomock.Controller) *MockSchedulerRepository {
+	mock := &MockSchedulerRepository{ctrl: ctrl}
+	mock.recorder = &MockSchedulerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchedulerRepository) EXPECT() *MockSchedulerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimReminder mocks base method.
+func (m *MockSchedulerRepository) ClaimReminder(ctx context.Context, exec sqlx.ExtContext, reminder *models.ReviewReminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimReminder", ctx, exec, reminder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClaimReminder indicates an expected call of ClaimReminder.
+func (mr *MockSchedulerRepositoryMockRecorder) ClaimReminder(ctx, exec, reminder any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimReminder", reflect.TypeOf((*MockSchedulerRepository)(nil).ClaimReminder), ctx, exec, reminder)
+}
+
+// GetStaleReviews mocks base method.
+func (m *MockSchedulerRepository) GetStaleReviews(ctx context.Context, exec sqlx.ExtContext, assignedBefore, claimedBefore time.Time) ([]models.ReviewReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStaleReviews", ctx, exec, assignedBefore, claimedBefore)
+	ret0, _ := ret[0].([]models.ReviewReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStaleReviews indicates an expected call of GetStaleReviews.
+func (mr *MockSchedulerRepositoryMockRecorder) GetStaleReviews(ctx, exec, assignedBefore, claimedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStaleReviews", reflect.TypeOf((*MockSchedulerRepository)(nil).GetStaleReviews), ctx, exec, assignedBefore, claimedBefore)
+}
+
+// SetReminderStatus mocks base method.
+func (m *MockSchedulerRepository) SetReminderStatus(ctx context.Context, exec sqlx.ExtContext, reminder *models.ReviewReminder, status models.ReminderStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReminderStatus", ctx, exec, reminder, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReminderStatus indicates an expected call of SetReminderStatus.
+func (mr *MockSchedulerRepositoryMockRecorder) SetReminderStatus(ctx, exec, reminder, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReminderStatus", reflect.TypeOf((*MockSchedulerRepository)(nil).SetReminderStatus), ctx, exec, reminder, status)
+}
+
+// TryLockJob mocks base method.
+func (m *MockSchedulerRepository) TryLockJob(ctx context.Context, exec sqlx.ExtContext, job string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryLockJob", ctx, exec, job)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryLockJob indicates an expected call of TryLockJob.
+func (mr *MockSchedulerRepositoryMockRecorder) TryLockJob(ctx, exec, job any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePullRequest", reflect.TypeOf((*MockPullRequestRepository)(nil).MergePullRequest), ctx, exec, prID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryLockJob", reflect.TypeOf((*MockSchedulerRepository)(nil).TryLockJob), ctx, exec, job)
 }
 
 // MockStore is a mock of Store interface.
@@ -332,6 +1456,20 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AvailabilityRepo mocks base method.
+func (m *MockStore) AvailabilityRepo() store.AvailabilityRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AvailabilityRepo")
+	ret0, _ := ret[0].(store.AvailabilityRepository)
+	return ret0
+}
+
+// AvailabilityRepo indicates an expected call of AvailabilityRepo.
+func (mr *MockStoreMockRecorder) AvailabilityRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AvailabilityRepo", reflect.TypeOf((*MockStore)(nil).AvailabilityRepo))
+}
+
 // DB mocks base method.
 func (m *MockStore) DB() *sqlx.DB {
 	m.ctrl.T.Helper()
@@ -360,6 +1498,20 @@ func (mr *MockStoreMockRecorder) DoTx(ctx, fn any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoTx", reflect.TypeOf((*MockStore)(nil).DoTx), ctx, fn)
 }
 
+// OwnershipRepo mocks base method.
+func (m *MockStore) OwnershipRepo() store.OwnershipRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OwnershipRepo")
+	ret0, _ := ret[0].(store.OwnershipRepository)
+	return ret0
+}
+
+// OwnershipRepo indicates an expected call of OwnershipRepo.
+func (mr *MockStoreMockRecorder) OwnershipRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OwnershipRepo", reflect.TypeOf((*MockStore)(nil).OwnershipRepo))
+}
+
 // PRRepo mocks base method.
 func (m *MockStore) PRRepo() store.PullRequestRepository {
 	m.ctrl.T.Helper()
@@ -374,6 +1526,20 @@ func (mr *MockStoreMockRecorder) PRRepo() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PRRepo", reflect.TypeOf((*MockStore)(nil).PRRepo))
 }
 
+// SchedulerRepo mocks base method.
+func (m *MockStore) SchedulerRepo() store.SchedulerRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulerRepo")
+	ret0, _ := ret[0].(store.SchedulerRepository)
+	return ret0
+}
+
+// SchedulerRepo indicates an expected call of SchedulerRepo.
+func (mr *MockStoreMockRecorder) SchedulerRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulerRepo", reflect.TypeOf((*MockStore)(nil).SchedulerRepo))
+}
+
 // TeamRepo mocks base method.
 func (m *MockStore) TeamRepo() store.TeamRepository {
 	m.ctrl.T.Helper()
@@ -0,0 +1,59 @@
+package ownershiprepository
+
+import (
+	"context"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type ownershipRepository struct{}
+
+func NewOwnershipRepository() *ownershipRepository {
+	return &ownershipRepository{}
+}
+
+// creates or replaces CODEOWNERS of the repository
+func (r *ownershipRepository) UpsertCodeowners(ctx context.Context, exec sqlx.ExtContext, repository, content string) (*models.Codeowners, error) {
+	var codeowners models.Codeowners
+	if err := exec.QueryRowxContext(ctx, upsertCodeownersQuery, repository, content).StructScan(&codeowners); err != nil {
+		return nil, err
+	}
+	return &codeowners, nil
+}
+
+func (r *ownershipRepository) GetCodeowners(ctx context.Context, exec sqlx.ExtContext, repository string) (*models.Codeowners, error) {
+	var codeowners models.Codeowners
+	if err := exec.QueryRowxContext(ctx, getCodeownersQuery, repository).StructScan(&codeowners); err != nil {
+		return nil, err
+	}
+	return &codeowners, nil
+}
+
+// registers the repository without owner team, existing repository is kept
+func (r *ownershipRepository) CreateRepository(ctx context.Context, exec sqlx.ExtContext, repository string) error {
+	_, err := exec.ExecContext(ctx, createRepositoryQuery, repository)
+	return err
+}
+
+// creates the repository or replaces its owner team
+func (r *ownershipRepository) UpsertRepository(ctx context.Context, exec sqlx.ExtContext, repository *models.Repository) (*models.Repository, error) {
+	var saved models.Repository
+	if err := exec.QueryRowxContext(ctx, upsertRepositoryQuery, repository.Name, repository.TeamName).StructScan(&saved); err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (r *ownershipRepository) GetRepository(ctx context.Context, exec sqlx.ExtContext, repository string) (*models.Repository, error) {
+	var saved models.Repository
+	if err := exec.QueryRowxContext(ctx, getRepositoryQuery, repository).StructScan(&saved); err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (r *ownershipRepository) TransferRepositories(ctx context.Context, exec sqlx.ExtContext, fromTeam, toTeam string) error {
+	_, err := exec.ExecContext(ctx, transferRepositoriesQuery, fromTeam, toTeam)
+	return err
+}
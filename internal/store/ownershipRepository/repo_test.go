@@ -0,0 +1,110 @@
+package ownershiprepository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertCodeowners(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	ownershipRepo := NewOwnershipRepository()
+
+	t.Run("Upsert", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"repository", "content", "updated_at"}).
+			AddRow("backend", "* @org/backend", time.Now())
+
+		mock.ExpectQuery(upsertCodeownersQuery).WithArgs("backend", "* @org/backend").WillReturnRows(rows)
+
+		codeowners, err := ownershipRepo.UpsertCodeowners(context.Background(), sqlxDB, "backend", "* @org/backend")
+		require.NoError(t, err)
+		require.Equal(t, "backend", codeowners.Repository)
+	})
+}
+
+func TestGetCodeowners(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	ownershipRepo := NewOwnershipRepository()
+
+	t.Run("Get", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"repository", "content", "updated_at"}).
+			AddRow("backend", "*.sql @dba", time.Now())
+
+		mock.ExpectQuery(getCodeownersQuery).WithArgs("backend").WillReturnRows(rows)
+
+		codeowners, err := ownershipRepo.GetCodeowners(context.Background(), sqlxDB, "backend")
+		require.NoError(t, err)
+		require.Equal(t, "*.sql @dba", codeowners.Content)
+	})
+
+	t.Run("Get not found", func(t *testing.T) {
+		mock.ExpectQuery(getCodeownersQuery).WithArgs("unknown").WillReturnError(sql.ErrNoRows)
+
+		codeowners, err := ownershipRepo.GetCodeowners(context.Background(), sqlxDB, "unknown")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		require.Nil(t, codeowners)
+	})
+}
+
+func TestRepository(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	ownershipRepo := NewOwnershipRepository()
+
+	t.Run("Create", func(t *testing.T) {
+		mock.ExpectExec(createRepositoryQuery).WithArgs("search").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := ownershipRepo.CreateRepository(context.Background(), sqlxDB, "search")
+		require.NoError(t, err)
+	})
+
+	t.Run("Upsert", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"repository", "team_name", "created_at"}).
+			AddRow("search", "backend", time.Now())
+		mock.ExpectQuery(upsertRepositoryQuery).WithArgs("search", "backend").WillReturnRows(rows)
+
+		repo, err := ownershipRepo.UpsertRepository(context.Background(), sqlxDB, &models.Repository{Name: "search", TeamName: "backend"})
+		require.NoError(t, err)
+		require.Equal(t, "backend", repo.TeamName)
+	})
+
+	t.Run("Get not found", func(t *testing.T) {
+		mock.ExpectQuery(getRepositoryQuery).WithArgs("unknown").WillReturnError(sql.ErrNoRows)
+
+		repo, err := ownershipRepo.GetRepository(context.Background(), sqlxDB, "unknown")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		require.Nil(t, repo)
+	})
+
+	t.Run("Transfer to successor", func(t *testing.T) {
+		mock.ExpectExec(transferRepositoriesQuery).WithArgs("legacy", "backend").WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := ownershipRepo.TransferRepositories(context.Background(), sqlxDB, "legacy", "backend")
+		require.NoError(t, err)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
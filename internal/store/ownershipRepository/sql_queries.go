@@ -0,0 +1,44 @@
+package ownershiprepository
+
+const (
+	upsertCodeownersQuery = `
+		INSERT INTO codeowners (repository, content)
+			VALUES ($1, $2)
+		ON CONFLICT (repository) DO UPDATE
+			SET content = EXCLUDED.content,
+			updated_at = now()
+		RETURNING repository, content, updated_at
+	`
+
+	getCodeownersQuery = `
+		SELECT repository, content, updated_at
+			FROM codeowners
+		WHERE repository = $1
+	`
+
+	createRepositoryQuery = `
+		INSERT INTO repositories (repository)
+			VALUES ($1)
+		ON CONFLICT (repository) DO NOTHING
+	`
+
+	upsertRepositoryQuery = `
+		INSERT INTO repositories (repository, team_name)
+			VALUES ($1, NULLIF($2, ''))
+		ON CONFLICT (repository) DO UPDATE
+			SET team_name = EXCLUDED.team_name
+		RETURNING repository, COALESCE(team_name, '') AS team_name, created_at
+	`
+
+	transferRepositoriesQuery = `
+		UPDATE repositories
+			SET team_name = NULLIF($2, '')
+		WHERE team_name = $1
+	`
+
+	getRepositoryQuery = `
+		SELECT repository, COALESCE(team_name, '') AS team_name, created_at
+			FROM repositories
+		WHERE repository = $1
+	`
+)
@@ -5,11 +5,19 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Negat1v9/pr-review-service/internal/models"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
+// assignmentsLockKey is a key of postgres advisory lock which guards reviewers assignment
+const assignmentsLockKey int64 = 7_301_001
+
+// dependenciesLockKey guards dependency graph changes, so concurrent changes can not create a cycle
+const dependenciesLockKey int64 = 7_301_002
+
 type pullRequestRepository struct{}
 
 func NewPullRequestRepository() *pullRequestRepository {
@@ -17,7 +25,7 @@ func NewPullRequestRepository() *pullRequestRepository {
 }
 
 func (r *pullRequestRepository) CreatePullRequest(ctx context.Context, exec sqlx.ExtContext, pr *models.PullRequest) error {
-	_, err := exec.ExecContext(ctx, createPullRequestQuery, &pr.ID, &pr.Name, &pr.AuthorID)
+	_, err := exec.ExecContext(ctx, createPullRequestQuery, &pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.Repository, &pr.Number, &pr.TeamName)
 	return err
 }
 
@@ -29,7 +37,7 @@ func (r *pullRequestRepository) GetPullRequestByID(ctx context.Context, exec sql
 		return nil, err
 	}
 
-	// then recieve reviewers
+	// then recieve reviewers with their latest review state
 	rows, err := exec.QueryxContext(ctx, getPullRequestReviewersQuery, prID)
 	if err != nil {
 		return nil, err
@@ -37,12 +45,14 @@ func (r *pullRequestRepository) GetPullRequestByID(ctx context.Context, exec sql
 	defer rows.Close()
 
 	reviewers := make([]string, 0)
+	states := make([]models.ReviewerState, 0)
 	for rows.Next() {
-		var reviewerID string
-		if err := rows.Scan(&reviewerID); err != nil {
+		var state models.ReviewerState
+		if err := rows.StructScan(&state); err != nil {
 			return nil, err
 		}
-		reviewers = append(reviewers, reviewerID)
+		reviewers = append(reviewers, state.UserID)
+		states = append(states, state)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -50,11 +60,117 @@ func (r *pullRequestRepository) GetPullRequestByID(ctx context.Context, exec sql
 	}
 
 	pr.AssignedReviewers = reviewers
+	pr.Reviewers = states
+
+	labels := make([]string, 0)
+	if err := sqlx.SelectContext(ctx, exec, &labels, getPullRequestLabelsQuery, prID); err != nil {
+		return nil, err
+	}
+	pr.Labels = labels
+
 	return &pr, nil
 }
 
-func (r *pullRequestRepository) MergePullRequest(ctx context.Context, exec sqlx.ExtContext, prID string) error {
-	res, err := exec.ExecContext(ctx, mergePullRequestQuery, prID)
+// sortColumnTypes are types of sortable columns to compare them with cursor value
+var sortColumnTypes = map[models.PullRequestSort]string{
+	models.PullRequestSortCreatedAt: "timestamptz",
+	models.PullRequestSortID:        "text",
+	models.PullRequestSortName:      "text",
+}
+
+func (r *pullRequestRepository) ListPullRequests(ctx context.Context, exec sqlx.ExtContext, filter *models.PullRequestFilter) ([]models.PullRequest, error) {
+	sortBy := filter.SortBy
+	if sortBy == "" {
+		sortBy = models.PullRequestSortCreatedAt
+	}
+	columnType, ok := sortColumnTypes[sortBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort column: %s", sortBy)
+	}
+
+	conditions := make([]string, 0)
+	args := make([]any, 0)
+	where := func(condition string, values ...any) {
+		placeholders := make([]any, 0, len(values))
+		for _, value := range values {
+			args = append(args, value)
+			placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+		}
+		conditions = append(conditions, fmt.Sprintf(condition, placeholders...))
+	}
+
+	if filter.Repository != "" {
+		where("pr.repository = %s", filter.Repository)
+	}
+	if filter.Status != "" {
+		where("pr.status = %s", filter.Status)
+	}
+	if filter.AuthorID != "" {
+		where("pr.author_id = %s", filter.AuthorID)
+	}
+	if filter.TeamName != "" {
+		where("COALESCE(pr.team_name, u.team_name) = %s", filter.TeamName)
+	}
+	if filter.ReviewerID != "" {
+		where("EXISTS (SELECT 1 FROM assigned_reviewers r WHERE r.pull_request_id = pr.pull_request_id AND r.reviewer_user_id = %s)", filter.ReviewerID)
+	}
+	if filter.CreatedFrom != nil {
+		where("pr.created_at >= %s", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		where("pr.created_at < %s", *filter.CreatedTo)
+	}
+	if filter.MergedFrom != nil {
+		where("pr.merged_at >= %s", *filter.MergedFrom)
+	}
+	if filter.MergedTo != nil {
+		where("pr.merged_at < %s", *filter.MergedTo)
+	}
+
+	direction, compare := "ASC", ">"
+	if filter.Desc {
+		direction, compare = "DESC", "<"
+	}
+	if filter.After != nil {
+		where(fmt.Sprintf("(pr.%s, pr.pull_request_id) %s (%%s::%s, %%s)", sortBy, compare, columnType), filter.After.Value, filter.After.ID)
+	}
+	if len(conditions) == 0 {
+		conditions = append(conditions, "TRUE")
+	}
+
+	args = append(args, filter.Limit)
+	query := fmt.Sprintf(listPullRequestsQuery, strings.Join(conditions, " AND "), sortBy, direction, fmt.Sprintf("$%d", len(args)))
+
+	rows, err := exec.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prs := make([]models.PullRequest, 0)
+	for rows.Next() {
+		var row struct {
+			models.PullRequest
+			AssignedReviewers pq.StringArray `db:"assigned_reviewers"`
+			Labels            pq.StringArray `db:"labels"`
+		}
+		if err := rows.StructScan(&row); err != nil {
+			return nil, err
+		}
+		row.PullRequest.AssignedReviewers = row.AssignedReviewers
+		row.PullRequest.Labels = row.Labels
+		prs = append(prs, row.PullRequest)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return prs, nil
+}
+
+func (r *pullRequestRepository) MergePullRequest(ctx context.Context, exec sqlx.ExtContext, prID string, override bool) error {
+	res, err := exec.ExecContext(ctx, mergePullRequestQuery, prID, override)
 	if err != nil {
 		return err
 	}
@@ -71,8 +187,26 @@ func (r *pullRequestRepository) MergePullRequest(ctx context.Context, exec sqlx.
 	return nil
 }
 
-func (r *pullRequestRepository) GetQuantityPRReviewers(ctx context.Context, exec sqlx.ExtContext) ([]models.PullRequestQuantityReviewers, error) {
-	rows, err := exec.QueryContext(ctx, getPullRequestsQuantityAssignedReviewers)
+func (r *pullRequestRepository) UpdatePullRequestStatus(ctx context.Context, exec sqlx.ExtContext, prID string, from, to models.PullRequestStatus) error {
+	res, err := exec.ExecContext(ctx, updatePullRequestStatusQuery, prID, from, to)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *pullRequestRepository) GetQuantityPRReviewers(ctx context.Context, exec sqlx.ExtContext, repository string) ([]models.PullRequestQuantityReviewers, error) {
+	rows, err := exec.QueryContext(ctx, getPullRequestsQuantityAssignedReviewers, repository)
 	if err != nil {
 		return nil, err
 	}
@@ -132,3 +266,239 @@ func (r *pullRequestRepository) DeleteAssignedByReviewerID(ctx context.Context,
 	}
 	return nil
 }
+
+func (r *pullRequestRepository) SetPullRequestLabels(ctx context.Context, exec sqlx.ExtContext, prID string, labels []string) error {
+	if _, err := exec.ExecContext(ctx, deletePullRequestLabelsQuery, prID); err != nil {
+		return err
+	}
+
+	if len(labels) == 0 {
+		return nil
+	}
+
+	_, err := exec.ExecContext(ctx, createPullRequestLabelsQuery, prID, pq.Array(labels))
+	return err
+}
+
+func (r *pullRequestRepository) DeleteAssignedByPullRequestID(ctx context.Context, exec sqlx.ExtContext, prID string) error {
+	_, err := exec.ExecContext(ctx, deleteAssignedByPullRequestIDQuery, prID)
+	return err
+}
+
+func (r *pullRequestRepository) DeleteAssignedReviewer(ctx context.Context, exec sqlx.ExtContext, prID, reviewerID string) error {
+	res, err := exec.ExecContext(ctx, deleteAssignedReviewerQuery, prID, reviewerID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *pullRequestRepository) GetOpenPullRequestIDsByReviewer(ctx context.Context, exec sqlx.ExtContext, reviewerID string) ([]string, error) {
+	prIDs := make([]string, 0)
+	if err := sqlx.SelectContext(ctx, exec, &prIDs, getOpenPullRequestIDsByReviewerQuery, reviewerID); err != nil {
+		return nil, err
+	}
+	return prIDs, nil
+}
+
+func (r *pullRequestRepository) GetActivePullRequestIDsByTeam(ctx context.Context, exec sqlx.ExtContext, teamName string) ([]string, error) {
+	prIDs := make([]string, 0)
+	if err := sqlx.SelectContext(ctx, exec, &prIDs, getActivePullRequestIDsByTeamQuery, teamName); err != nil {
+		return nil, err
+	}
+	return prIDs, nil
+}
+
+func (r *pullRequestRepository) GetTeamsPullRequestCounts(ctx context.Context, exec sqlx.ExtContext, teamNames []string, repository string) ([]models.TeamPullRequestCounts, error) {
+	counts := make([]models.TeamPullRequestCounts, 0)
+	if err := sqlx.SelectContext(ctx, exec, &counts, getTeamsPullRequestCountsQuery, pq.Array(teamNames), repository); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *pullRequestRepository) SetPullRequestsTeam(ctx context.Context, exec sqlx.ExtContext, prIDs []string, teamName string) error {
+	_, err := exec.ExecContext(ctx, setPullRequestsTeamQuery, pq.Array(prIDs), teamName)
+	return err
+}
+
+func (r *pullRequestRepository) LockAssignments(ctx context.Context, exec sqlx.ExtContext) error {
+	_, err := exec.ExecContext(ctx, lockAssignmentsQuery, assignmentsLockKey)
+	return err
+}
+
+func (r *pullRequestRepository) GetOpenAssignmentsByReviewers(ctx context.Context, exec sqlx.ExtContext, reviewerIDs []string, teamName string) ([]models.OpenAssignment, error) {
+	rows, err := exec.QueryxContext(ctx, getOpenAssignmentsByReviewersQuery, pq.Array(reviewerIDs), teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignments := make([]models.OpenAssignment, 0)
+	for rows.Next() {
+		var row struct {
+			models.OpenAssignment
+			AssignedReviewers pq.StringArray `db:"assigned_reviewers"`
+			Labels            pq.StringArray `db:"labels"`
+		}
+		if err := rows.StructScan(&row); err != nil {
+			return nil, err
+		}
+		row.OpenAssignment.AssignedReviewers = row.AssignedReviewers
+		row.OpenAssignment.Labels = row.Labels
+		assignments = append(assignments, row.OpenAssignment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+func (r *pullRequestRepository) ReplaceManyReviewers(ctx context.Context, exec sqlx.ExtContext, replacements []models.ReviewerReplacement) error {
+	if len(replacements) == 0 {
+		return nil
+	}
+
+	prIDs := make([]string, 0, len(replacements))
+	oldReviewerIDs := make([]string, 0, len(replacements))
+	newReviewerIDs := make([]string, 0, len(replacements))
+	for _, replacement := range replacements {
+		prIDs = append(prIDs, replacement.PullRequestID)
+		oldReviewerIDs = append(oldReviewerIDs, replacement.OldReviewerID)
+		newReviewerIDs = append(newReviewerIDs, replacement.NewReviewerID)
+	}
+
+	_, err := exec.ExecContext(ctx, replaceManyReviewersQuery, pq.Array(prIDs), pq.Array(oldReviewerIDs), pq.Array(newReviewerIDs))
+	return err
+}
+
+func (r *pullRequestRepository) CreateReview(ctx context.Context, exec sqlx.ExtContext, review *models.Review) (*models.Review, error) {
+	var created models.Review
+	if err := exec.QueryRowxContext(ctx, createReviewQuery, review.PullRequestID, review.ReviewerID, review.State, review.Body).
+		StructScan(&created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (r *pullRequestRepository) GetPullRequestReviews(ctx context.Context, exec sqlx.ExtContext, prID string) ([]models.Review, error) {
+	reviews := make([]models.Review, 0)
+	if err := sqlx.SelectContext(ctx, exec, &reviews, getPullRequestReviewsQuery, prID); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func (r *pullRequestRepository) CreateEvents(ctx context.Context, exec sqlx.ExtContext, events []models.PullRequestEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	prIDs := make([]string, 0, len(events))
+	eventTypes := make([]string, 0, len(events))
+	actors := make([]string, 0, len(events))
+	payloads := make([]string, 0, len(events))
+	for _, event := range events {
+		prIDs = append(prIDs, event.PullRequestID)
+		eventTypes = append(eventTypes, string(event.Type))
+		actors = append(actors, event.Actor)
+		payloads = append(payloads, event.Payload.String())
+	}
+
+	_, err := exec.ExecContext(ctx, createEventsQuery, pq.Array(prIDs), pq.Array(eventTypes), pq.Array(actors), pq.Array(payloads))
+	return err
+}
+
+func (r *pullRequestRepository) GetPullRequestEvents(ctx context.Context, exec sqlx.ExtContext, prID string) ([]models.PullRequestEvent, error) {
+	events := make([]models.PullRequestEvent, 0)
+	if err := sqlx.SelectContext(ctx, exec, &events, getPullRequestEventsQuery, prID); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// breached timers are returned until their breach is reported
+func (r *pullRequestRepository) GetDueSLATimers(ctx context.Context, exec sqlx.ExtContext, dueBy time.Time) ([]models.SLATimer, error) {
+	timers := make([]models.SLATimer, 0)
+	if err := sqlx.SelectContext(ctx, exec, &timers, getDueSLATimersQuery, dueBy); err != nil {
+		return nil, err
+	}
+	return timers, nil
+}
+
+// returns false if the notice was already reported
+func (r *pullRequestRepository) CreateSLANotice(ctx context.Context, exec sqlx.ExtContext, notice *models.SLANotice) (bool, error) {
+	res, err := exec.ExecContext(ctx, createSLANoticeQuery, notice.PullRequestID, notice.Kind, notice.ReviewerID, notice.StartedAt, notice.Level)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// replaces dependencies of the PR
+func (r *pullRequestRepository) SetPullRequestDependencies(ctx context.Context, exec sqlx.ExtContext, prID string, dependsOn []string) error {
+	if _, err := exec.ExecContext(ctx, deleteDependenciesQuery, prID); err != nil {
+		return err
+	}
+
+	if len(dependsOn) == 0 {
+		return nil
+	}
+
+	_, err := exec.ExecContext(ctx, createDependenciesQuery, prID, pq.Array(dependsOn))
+	return err
+}
+
+// reports whether toID is one of fromIDs or any of them depends on it directly or transitively
+func (r *pullRequestRepository) HasDependencyPath(ctx context.Context, exec sqlx.ExtContext, fromIDs []string, toID string) (bool, error) {
+	var exists bool
+	if err := exec.QueryRowxContext(ctx, hasDependencyPathQuery, pq.Array(fromIDs), toID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *pullRequestRepository) GetDependencyGraph(ctx context.Context, exec sqlx.ExtContext, prID string) ([]models.PullRequestDependency, error) {
+	edges := make([]models.PullRequestDependency, 0)
+	if err := sqlx.SelectContext(ctx, exec, &edges, getDependencyGraphQuery, prID); err != nil {
+		return nil, err
+	}
+	return edges, nil
+}
+
+func (r *pullRequestRepository) GetUnmergedDependencies(ctx context.Context, exec sqlx.ExtContext, prID string) ([]string, error) {
+	ids := make([]string, 0)
+	if err := sqlx.SelectContext(ctx, exec, &ids, getUnmergedDependenciesQuery, prID); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *pullRequestRepository) GetBlockedPullRequests(ctx context.Context, exec sqlx.ExtContext, prID string) ([]models.PullRequest, error) {
+	prs := make([]models.PullRequest, 0)
+	if err := sqlx.SelectContext(ctx, exec, &prs, getBlockedPullRequestsQuery, prID); err != nil {
+		return nil, err
+	}
+	return prs, nil
+}
+
+func (r *pullRequestRepository) LockDependencies(ctx context.Context, exec sqlx.ExtContext) error {
+	_, err := exec.ExecContext(ctx, lockDependenciesQuery, dependenciesLockKey)
+	return err
+}
@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Negat1v9/pr-review-service/internal/models"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -25,12 +27,16 @@ func TestCreatePullRequest(t *testing.T) {
 
 	t.Run("Create", func(t *testing.T) {
 		pr := models.PullRequest{
-			ID:       "pr-111",
-			Name:     "Author",
-			AuthorID: "user-123",
+			ID:         "pr-111",
+			Name:       "Author",
+			AuthorID:   "user-123",
+			Status:     models.PullRequestStatusDraft,
+			Repository: "search",
+			Number:     "111",
+			TeamName:   "go-guild",
 		}
 
-		mock.ExpectExec(createPullRequestQuery).WithArgs(&pr.ID, &pr.Name, &pr.AuthorID).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(createPullRequestQuery).WithArgs(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.Repository, &pr.Number, &pr.TeamName).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		err = prRepo.CreatePullRequest(context.Background(), sqlxDB, &pr)
 
@@ -52,7 +58,11 @@ func TestGetPullRequestByID(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status"}).
 			AddRow("pr-1000", "payment", "u1", "OPEN")
 
-		rowsReviewers := sqlmock.NewRows([]string{"reviewer_user_id"}).AddRow("u2").AddRow("u3")
+		submittedAt := time.Now()
+		rowsReviewers := sqlmock.NewRows([]string{"reviewer_user_id", "state", "submitted_at"}).
+			AddRow("u2", "APPROVED", submittedAt).
+			AddRow("u3", "PENDING", nil)
+		rowsLabels := sqlmock.NewRows([]string{"label"}).AddRow("sql")
 		pr := models.PullRequest{
 			ID:       "pr-1000",
 			Name:     "payment",
@@ -62,12 +72,18 @@ func TestGetPullRequestByID(t *testing.T) {
 
 		mock.ExpectQuery(getPullRequestByIDQuery).WithArgs(&pr.ID).WillReturnRows(rows)
 		mock.ExpectQuery(getPullRequestReviewersQuery).WithArgs(&pr.ID).WillReturnRows(rowsReviewers)
+		mock.ExpectQuery(getPullRequestLabelsQuery).WithArgs(&pr.ID).WillReturnRows(rowsLabels)
 
 		pullRequest, err := prRepo.GetPullRequestByID(context.Background(), sqlxDB, "pr-1000")
 
 		require.NoError(t, err)
 		require.NotNil(t, pullRequest)
-		require.Equal(t, 2, len(pullRequest.AssignedReviewers))
+		require.Equal(t, []string{"u2", "u3"}, pullRequest.AssignedReviewers)
+		require.Equal(t, []models.ReviewerState{
+			{UserID: "u2", State: models.ReviewStateApproved, SubmittedAt: &submittedAt},
+			{UserID: "u3", State: models.ReviewStatePending},
+		}, pullRequest.Reviewers)
+		require.Equal(t, []string{"sql"}, pullRequest.Labels)
 	})
 
 	t.Run("GetNotFound", func(t *testing.T) {
@@ -80,6 +96,158 @@ func TestGetPullRequestByID(t *testing.T) {
 	})
 }
 
+func TestListPullRequests(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	prRepo := NewPullRequestRepository()
+	columns := []string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "assigned_reviewers", "labels"}
+	createdAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("First page", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow("pr-2", "search", "u1", "OPEN", createdAt, "{u2,u3}", "{sql}").
+			AddRow("pr-1", "payment", "u1", "OPEN", createdAt, "{}", "{}")
+		query := fmt.Sprintf(listPullRequestsQuery, "TRUE", "created_at", "DESC", "$1")
+		mock.ExpectQuery(query).WithArgs(3).WillReturnRows(rows)
+
+		prs, err := prRepo.ListPullRequests(context.Background(), sqlxDB, &models.PullRequestFilter{Desc: true, Limit: 3})
+
+		require.NoError(t, err)
+		require.Len(t, prs, 2)
+		require.Equal(t, []string{"u2", "u3"}, prs[0].AssignedReviewers)
+		require.Equal(t, []string{"sql"}, prs[0].Labels)
+		require.Equal(t, []string{}, prs[1].AssignedReviewers)
+	})
+
+	t.Run("Filtered page after cursor", func(t *testing.T) {
+		from := createdAt.Add(-time.Hour)
+		filter := &models.PullRequestFilter{
+			Status:      models.PullRequestStatusMerged,
+			TeamName:    "backend",
+			ReviewerID:  "u2",
+			CreatedFrom: &from,
+			SortBy:      models.PullRequestSortName,
+			Limit:       2,
+			After:       &models.PullRequestCursor{SortBy: models.PullRequestSortName, Value: "payment", ID: "pr-1"},
+		}
+		conditions := strings.Join([]string{
+			"pr.status = $1",
+			"COALESCE(pr.team_name, u.team_name) = $2",
+			"EXISTS (SELECT 1 FROM assigned_reviewers r WHERE r.pull_request_id = pr.pull_request_id AND r.reviewer_user_id = $3)",
+			"pr.created_at >= $4",
+			"(pr.pull_request_name, pr.pull_request_id) > ($5::text, $6)",
+		}, " AND ")
+		query := fmt.Sprintf(listPullRequestsQuery, conditions, "pull_request_name", "ASC", "$7")
+		mock.ExpectQuery(query).
+			WithArgs(models.PullRequestStatusMerged, "backend", "u2", from, "payment", "pr-1", 2).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		prs, err := prRepo.ListPullRequests(context.Background(), sqlxDB, filter)
+
+		require.NoError(t, err)
+		require.Empty(t, prs)
+	})
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSLATimers(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	prRepo := NewPullRequestRepository()
+	dueBy := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	startedAt := dueBy.Add(-3 * time.Hour)
+
+	t.Run("Get due timers", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"sla_kind", "pull_request_id", "reviewer_user_id", "team_name", "started_at", "sla_hours", "sla_escalation"}).
+			AddRow("REVIEW", "pr-1", "u1", "team-1", startedAt, 2, "REASSIGN").
+			AddRow("MERGE", "pr-1", "", "team-1", startedAt, 3, "REASSIGN")
+		mock.ExpectQuery(getDueSLATimersQuery).WithArgs(dueBy).WillReturnRows(rows)
+
+		timers, err := prRepo.GetDueSLATimers(context.Background(), sqlxDB, dueBy)
+		require.NoError(t, err)
+		require.Equal(t, []models.SLATimer{
+			{Kind: models.SLAKindReview, PullRequestID: "pr-1", ReviewerID: "u1", TeamName: "team-1", StartedAt: startedAt, Hours: 2, Escalation: models.SLAEscalationReassign},
+			{Kind: models.SLAKindMerge, PullRequestID: "pr-1", TeamName: "team-1", StartedAt: startedAt, Hours: 3, Escalation: models.SLAEscalationReassign},
+		}, timers)
+	})
+
+	t.Run("Create notice once", func(t *testing.T) {
+		notice := &models.SLANotice{
+			SLATimer: models.SLATimer{Kind: models.SLAKindReview, PullRequestID: "pr-1", ReviewerID: "u1", StartedAt: startedAt},
+			Level:    models.SLALevelBreach,
+		}
+		mock.ExpectExec(createSLANoticeQuery).WithArgs("pr-1", models.SLAKindReview, "u1", startedAt, models.SLALevelBreach).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(createSLANoticeQuery).WithArgs("pr-1", models.SLAKindReview, "u1", startedAt, models.SLALevelBreach).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		created, err := prRepo.CreateSLANotice(context.Background(), sqlxDB, notice)
+		require.NoError(t, err)
+		require.True(t, created)
+
+		created, err = prRepo.CreateSLANotice(context.Background(), sqlxDB, notice)
+		require.NoError(t, err)
+		require.False(t, created)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestEvents(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	prRepo := NewPullRequestRepository()
+
+	t.Run("Create", func(t *testing.T) {
+		events := []models.PullRequestEvent{
+			{PullRequestID: "pr-1", Type: models.PullRequestEventCreated, Actor: "u1", Payload: []byte(`{"author_id":"u1"}`)},
+			{PullRequestID: "pr-1", Type: models.PullRequestEventReviewerAssigned, Actor: "system", Payload: []byte(`{"reviewer_id":"u2"}`)},
+		}
+		mock.ExpectExec(createEventsQuery).
+			WithArgs(
+				pq.Array([]string{"pr-1", "pr-1"}),
+				pq.Array([]string{"PR_CREATED", "REVIEWER_ASSIGNED"}),
+				pq.Array([]string{"u1", "system"}),
+				pq.Array([]string{`{"author_id":"u1"}`, `{"reviewer_id":"u2"}`}),
+			).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := prRepo.CreateEvents(context.Background(), sqlxDB, events)
+		require.NoError(t, err)
+	})
+
+	t.Run("Create nothing", func(t *testing.T) {
+		err := prRepo.CreateEvents(context.Background(), sqlxDB, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		createdAt := time.Now()
+		rows := sqlmock.NewRows([]string{"event_id", "pull_request_id", "event_type", "actor", "payload", "created_at"}).
+			AddRow(1, "pr-1", "PR_CREATED", "u1", []byte(`{"author_id":"u1"}`), createdAt)
+		mock.ExpectQuery(getPullRequestEventsQuery).WithArgs("pr-1").WillReturnRows(rows)
+
+		events, err := prRepo.GetPullRequestEvents(context.Background(), sqlxDB, "pr-1")
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, models.PullRequestEventCreated, events[0].Type)
+		require.JSONEq(t, `{"author_id":"u1"}`, events[0].Payload.String())
+	})
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestMergePullRequest(t *testing.T) {
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
@@ -91,20 +259,52 @@ func TestMergePullRequest(t *testing.T) {
 	prRepo := NewPullRequestRepository()
 
 	t.Run("Merge", func(t *testing.T) {
-		mock.ExpectExec(mergePullRequestQuery).WithArgs("pr-123").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(mergePullRequestQuery).WithArgs("pr-123", false).WillReturnResult(sqlmock.NewResult(1, 1))
 
-		err = prRepo.MergePullRequest(context.Background(), sqlxDB, "pr-123")
+		err = prRepo.MergePullRequest(context.Background(), sqlxDB, "pr-123", false)
 
 		require.NoError(t, err)
 	})
 
 	t.Run("MergeNotFound", func(t *testing.T) {
-		mock.ExpectExec(mergePullRequestQuery).WithArgs("nonexistent").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(mergePullRequestQuery).WithArgs("nonexistent", true).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = prRepo.MergePullRequest(context.Background(), sqlxDB, "nonexistent", true)
+
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestUpdatePullRequestStatus(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	prRepo := NewPullRequestRepository()
+
+	t.Run("Close", func(t *testing.T) {
+		mock.ExpectExec(updatePullRequestStatusQuery).
+			WithArgs("pr-123", models.PullRequestStatusOpen, models.PullRequestStatusClosed).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = prRepo.UpdatePullRequestStatus(context.Background(), sqlxDB, "pr-123", models.PullRequestStatusOpen, models.PullRequestStatusClosed)
 
-		err = prRepo.MergePullRequest(context.Background(), sqlxDB, "nonexistent")
+		require.NoError(t, err)
+	})
+
+	t.Run("Status changed", func(t *testing.T) {
+		mock.ExpectExec(updatePullRequestStatusQuery).
+			WithArgs("pr-123", models.PullRequestStatusDraft, models.PullRequestStatusOpen).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = prRepo.UpdatePullRequestStatus(context.Background(), sqlxDB, "pr-123", models.PullRequestStatusDraft, models.PullRequestStatusOpen)
 
 		require.ErrorIs(t, err, sql.ErrNoRows)
 	})
+	require.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestGetQuantityPRReviewers(t *testing.T) {
@@ -122,18 +322,18 @@ func TestGetQuantityPRReviewers(t *testing.T) {
 			AddRow("pr-2", 1).
 			AddRow("pr-3", 0)
 
-		mock.ExpectQuery(getPullRequestsQuantityAssignedReviewers).WillReturnRows(rows)
+		mock.ExpectQuery(getPullRequestsQuantityAssignedReviewers).WithArgs("").WillReturnRows(rows)
 
-		prInfo, err := prRepo.GetQuantityPRReviewers(context.Background(), sqlxDB)
+		prInfo, err := prRepo.GetQuantityPRReviewers(context.Background(), sqlxDB, "")
 		require.NoError(t, err)
 		require.NotNil(t, prInfo)
 	})
 	t.Run("GetQuantityReviewers no one", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"pull_request_id", "quantity_reviewers"})
 
-		mock.ExpectQuery(getPullRequestsQuantityAssignedReviewers).WillReturnRows(rows)
+		mock.ExpectQuery(getPullRequestsQuantityAssignedReviewers).WithArgs("search").WillReturnRows(rows)
 
-		prInfo, err := prRepo.GetQuantityPRReviewers(context.Background(), sqlxDB)
+		prInfo, err := prRepo.GetQuantityPRReviewers(context.Background(), sqlxDB, "search")
 		require.NoError(t, err)
 		require.Equal(t, 0, len(prInfo))
 	})
@@ -213,3 +413,334 @@ func TestDeleteAssignedByReviewerID(t *testing.T) {
 		require.ErrorIs(t, err, sql.ErrNoRows)
 	})
 }
+
+func TestSetPullRequestLabels(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	prRepo := NewPullRequestRepository()
+
+	t.Run("Set labels", func(t *testing.T) {
+		labels := []string{"go", "sql"}
+		mock.ExpectExec(deletePullRequestLabelsQuery).WithArgs("pr-1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(createPullRequestLabelsQuery).WithArgs("pr-1", pq.Array(labels)).WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err = prRepo.SetPullRequestLabels(context.Background(), sqlxDB, "pr-1", labels)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Clear labels", func(t *testing.T) {
+		mock.ExpectExec(deletePullRequestLabelsQuery).WithArgs("pr-1").WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err = prRepo.SetPullRequestLabels(context.Background(), sqlxDB, "pr-1", nil)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDependencies(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	prRepo := NewPullRequestRepository()
+
+	t.Run("Set dependencies", func(t *testing.T) {
+		dependsOn := []string{"pr-1", "pr-2"}
+		mock.ExpectExec(deleteDependenciesQuery).WithArgs("pr-3").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(createDependenciesQuery).WithArgs("pr-3", pq.Array(dependsOn)).WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err = prRepo.SetPullRequestDependencies(context.Background(), sqlxDB, "pr-3", dependsOn)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Clear dependencies", func(t *testing.T) {
+		mock.ExpectExec(deleteDependenciesQuery).WithArgs("pr-3").WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err = prRepo.SetPullRequestDependencies(context.Background(), sqlxDB, "pr-3", nil)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Dependency path", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"exists"}).AddRow(true)
+		mock.ExpectQuery(hasDependencyPathQuery).WithArgs(pq.Array([]string{"pr-2"}), "pr-1").WillReturnRows(rows)
+
+		exists, err := prRepo.HasDependencyPath(context.Background(), sqlxDB, []string{"pr-2"}, "pr-1")
+
+		require.NoError(t, err)
+		require.True(t, exists)
+	})
+
+	t.Run("Dependency graph", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"pull_request_id", "depends_on_id", "status"}).
+			AddRow("pr-3", "pr-2", "OPEN").
+			AddRow("pr-2", "pr-1", "MERGED")
+		mock.ExpectQuery(getDependencyGraphQuery).WithArgs("pr-3").WillReturnRows(rows)
+
+		edges, err := prRepo.GetDependencyGraph(context.Background(), sqlxDB, "pr-3")
+
+		require.NoError(t, err)
+		require.Equal(t, []models.PullRequestDependency{
+			{PullRequestID: "pr-3", DependsOnID: "pr-2", Status: models.PullRequestStatusOpen},
+			{PullRequestID: "pr-2", DependsOnID: "pr-1", Status: models.PullRequestStatusMerged},
+		}, edges)
+	})
+
+	t.Run("Unmerged dependencies", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"depends_on_id"}).AddRow("pr-2")
+		mock.ExpectQuery(getUnmergedDependenciesQuery).WithArgs("pr-3").WillReturnRows(rows)
+
+		ids, err := prRepo.GetUnmergedDependencies(context.Background(), sqlxDB, "pr-3")
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"pr-2"}, ids)
+	})
+
+	t.Run("Blocked pull requests", func(t *testing.T) {
+		createdAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+		rows := sqlmock.NewRows([]string{"pull_request_id", "pull_request_name", "author_id", "status", "created_at"}).
+			AddRow("pr-2", "second", "u1", "OPEN", createdAt).
+			AddRow("pr-3", "third", "u1", "DRAFT", createdAt)
+		mock.ExpectQuery(getBlockedPullRequestsQuery).WithArgs("pr-1").WillReturnRows(rows)
+
+		prs, err := prRepo.GetBlockedPullRequests(context.Background(), sqlxDB, "pr-1")
+
+		require.NoError(t, err)
+		require.Len(t, prs, 2)
+		require.Equal(t, "pr-3", prs[1].ID)
+		require.Equal(t, models.PullRequestStatusDraft, prs[1].Status)
+	})
+
+	t.Run("Lock", func(t *testing.T) {
+		mock.ExpectExec(lockDependenciesQuery).WithArgs(dependenciesLockKey).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = prRepo.LockDependencies(context.Background(), sqlxDB)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteAssignedReviewer(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	prRepo := NewPullRequestRepository()
+
+	t.Run("Delete", func(t *testing.T) {
+		mock.ExpectExec(deleteAssignedReviewerQuery).WithArgs("pr-1", "user-1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = prRepo.DeleteAssignedReviewer(context.Background(), sqlxDB, "pr-1", "user-1")
+
+		require.NoError(t, err)
+	})
+
+	t.Run("DeleteNotAssigned", func(t *testing.T) {
+		mock.ExpectExec(deleteAssignedReviewerQuery).WithArgs("pr-2", "user-1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = prRepo.DeleteAssignedReviewer(context.Background(), sqlxDB, "pr-2", "user-1")
+
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestGetOpenPullRequestIDsByReviewer(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	prRepo := NewPullRequestRepository()
+
+	t.Run("Get", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"pull_request_id"}).AddRow("pr-1").AddRow("pr-3")
+		mock.ExpectQuery(getOpenPullRequestIDsByReviewerQuery).WithArgs("user-1").WillReturnRows(rows)
+
+		prIDs, err := prRepo.GetOpenPullRequestIDsByReviewer(context.Background(), sqlxDB, "user-1")
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"pr-1", "pr-3"}, prIDs)
+	})
+}
+
+func TestTeamPullRequests(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	prRepo := NewPullRequestRepository()
+
+	t.Run("Get active PRs of team", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"pull_request_id"}).AddRow("pr-1").AddRow("pr-2")
+		mock.ExpectQuery(getActivePullRequestIDsByTeamQuery).WithArgs("legacy").WillReturnRows(rows)
+
+		prIDs, err := prRepo.GetActivePullRequestIDsByTeam(context.Background(), sqlxDB, "legacy")
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"pr-1", "pr-2"}, prIDs)
+	})
+
+	t.Run("Set team of PRs", func(t *testing.T) {
+		mock.ExpectExec(setPullRequestsTeamQuery).WithArgs(pq.Array([]string{"pr-1", "pr-2"}), "backend").WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := prRepo.SetPullRequestsTeam(context.Background(), sqlxDB, []string{"pr-1", "pr-2"}, "backend")
+
+		require.NoError(t, err)
+	})
+
+	t.Run("Count PRs of teams", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"team_name", "draft", "open", "merged", "closed", "open_reviews"}).
+			AddRow("backend", 1, 2, 3, 0, 4)
+		mock.ExpectQuery(getTeamsPullRequestCountsQuery).WithArgs(pq.Array([]string{"backend", "payments"}), "").WillReturnRows(rows)
+
+		counts, err := prRepo.GetTeamsPullRequestCounts(context.Background(), sqlxDB, []string{"backend", "payments"}, "")
+
+		require.NoError(t, err)
+		require.Equal(t, []models.TeamPullRequestCounts{
+			{TeamName: "backend", PullRequestCounts: models.PullRequestCounts{Draft: 1, Open: 2, Merged: 3, OpenReviews: 4}},
+		}, counts)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLockAssignments(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	prRepo := NewPullRequestRepository()
+
+	t.Run("Lock", func(t *testing.T) {
+		mock.ExpectExec(lockAssignmentsQuery).WithArgs(assignmentsLockKey).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err = prRepo.LockAssignments(context.Background(), sqlxDB)
+
+		require.NoError(t, err)
+	})
+}
+
+func TestGetOpenAssignmentsByReviewers(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	prRepo := NewPullRequestRepository()
+
+	t.Run("Get", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"pull_request_id", "author_id", "review_team_name", "reviewer_user_id", "assigned_reviewers", "labels"}).
+			AddRow("pr-1", "user-3", "backend", "user-1", "{user-1,user-2}", "{backend}").
+			AddRow("pr-2", "user-4", "backend", "user-2", "{user-2}", "{}")
+		mock.ExpectQuery(getOpenAssignmentsByReviewersQuery).WithArgs(pq.Array([]string{"user-1", "user-2"}), "").WillReturnRows(rows)
+
+		assignments, err := prRepo.GetOpenAssignmentsByReviewers(context.Background(), sqlxDB, []string{"user-1", "user-2"}, "")
+
+		require.NoError(t, err)
+		require.Equal(t, []models.OpenAssignment{
+			{PullRequestID: "pr-1", AuthorID: "user-3", ReviewTeamName: "backend", ReviewerID: "user-1", AssignedReviewers: []string{"user-1", "user-2"}, Labels: []string{"backend"}},
+			{PullRequestID: "pr-2", AuthorID: "user-4", ReviewTeamName: "backend", ReviewerID: "user-2", AssignedReviewers: []string{"user-2"}, Labels: []string{}},
+		}, assignments)
+	})
+}
+
+func TestReplaceManyReviewers(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	prRepo := NewPullRequestRepository()
+
+	t.Run("Replace", func(t *testing.T) {
+		mock.ExpectExec(replaceManyReviewersQuery).
+			WithArgs(pq.Array([]string{"pr-1", "pr-2"}), pq.Array([]string{"user-1", "user-1"}), pq.Array([]string{"user-4", "user-3"})).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err = prRepo.ReplaceManyReviewers(context.Background(), sqlxDB, []models.ReviewerReplacement{
+			{PullRequestID: "pr-1", OldReviewerID: "user-1", NewReviewerID: "user-4"},
+			{PullRequestID: "pr-2", OldReviewerID: "user-1", NewReviewerID: "user-3"},
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("Nothing to replace", func(t *testing.T) {
+		err = prRepo.ReplaceManyReviewers(context.Background(), sqlxDB, nil)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReviews(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	prRepo := NewPullRequestRepository()
+	submittedAt := time.Now()
+
+	t.Run("Create", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"review_id", "pull_request_id", "reviewer_user_id", "state", "body", "submitted_at"}).
+			AddRow(1, "pr-1", "u2", "CHANGES_REQUESTED", "fix tests", submittedAt)
+		mock.ExpectQuery(createReviewQuery).WithArgs("pr-1", "u2", models.ReviewStateChangesRequested, "fix tests").WillReturnRows(rows)
+
+		review, err := prRepo.CreateReview(context.Background(), sqlxDB, &models.Review{
+			PullRequestID: "pr-1",
+			ReviewerID:    "u2",
+			State:         models.ReviewStateChangesRequested,
+			Body:          "fix tests",
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, int64(1), review.ID)
+		require.Equal(t, submittedAt, review.SubmittedAt)
+	})
+
+	t.Run("History", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"review_id", "pull_request_id", "reviewer_user_id", "state", "body", "submitted_at"}).
+			AddRow(1, "pr-1", "u2", "CHANGES_REQUESTED", "fix tests", submittedAt).
+			AddRow(2, "pr-1", "u2", "APPROVED", "", submittedAt)
+		mock.ExpectQuery(getPullRequestReviewsQuery).WithArgs("pr-1").WillReturnRows(rows)
+
+		reviews, err := prRepo.GetPullRequestReviews(context.Background(), sqlxDB, "pr-1")
+
+		require.NoError(t, err)
+		require.Equal(t, 2, len(reviews))
+		require.Equal(t, models.ReviewStateApproved, reviews[1].State)
+	})
+}
@@ -2,34 +2,93 @@ package pullrequestrepository
 
 const (
 	createPullRequestQuery = `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id)
-			VALUES ($1, $2, $3);
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, repository, number, team_name)
+			VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''));
 	`
 
 	getPullRequestByIDQuery = `
-		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, closed_at, merge_override,
+			repository, number, COALESCE(team_name, '') AS team_name
 		FROM pull_requests
 		WHERE pull_request_id = $1
 	`
 
+	// PRs page with reviewers and labels aggregated in one query,
+	// filled with filter conditions, sort column, sort direction and limit placeholder
+	listPullRequestsQuery = `
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status,
+			pr.created_at, pr.merged_at, pr.closed_at, pr.merge_override, pr.repository, pr.number,
+			COALESCE(pr.team_name, '') AS team_name,
+			ARRAY(
+				SELECT ar.reviewer_user_id FROM assigned_reviewers ar
+				WHERE ar.pull_request_id = pr.pull_request_id
+				ORDER BY ar.reviewer_user_id
+			) AS assigned_reviewers,
+			ARRAY(
+				SELECT l.label FROM pull_request_labels l
+				WHERE l.pull_request_id = pr.pull_request_id
+				ORDER BY l.label
+			) AS labels
+			FROM pull_requests pr
+		JOIN users u
+			ON u.user_id = pr.author_id
+		WHERE %s
+		ORDER BY pr.%s %s, pr.pull_request_id %[3]s
+		LIMIT %s
+	`
+
+	// comments do not change the decision, COMMENTED is the state only of reviewers who have not decided yet
 	getPullRequestReviewersQuery = `
-		SELECT reviewer_user_id
-		FROM assigned_reviewers
+		SELECT ar.reviewer_user_id, COALESCE(r.state, 'PENDING') AS state, r.submitted_at
+			FROM assigned_reviewers ar
+		LEFT JOIN LATERAL (
+			SELECT prr.state, prr.submitted_at
+				FROM pull_request_reviews prr
+			WHERE prr.pull_request_id = ar.pull_request_id AND prr.reviewer_user_id = ar.reviewer_user_id
+			ORDER BY prr.state = 'COMMENTED', prr.review_id DESC
+			LIMIT 1
+		) r ON true
+		WHERE ar.pull_request_id = $1
+		ORDER BY ar.reviewer_user_id
+	`
+
+	getPullRequestLabelsQuery = `
+		SELECT label
+		FROM pull_request_labels
 		WHERE pull_request_id = $1
-		ORDER BY reviewer_user_id
+		ORDER BY label
+	`
+
+	deletePullRequestLabelsQuery = `
+		DELETE FROM pull_request_labels WHERE pull_request_id = $1
+	`
+
+	createPullRequestLabelsQuery = `
+		INSERT INTO pull_request_labels (pull_request_id, label)
+			SELECT $1, unnest($2::text[])
+		ON CONFLICT (pull_request_id, label) DO NOTHING
 	`
 
 	mergePullRequestQuery = `
 		UPDATE pull_requests
 				SET status = 'MERGED',
-				merged_at = now()
-			WHERE pull_request_id = $1
+				merged_at = now(),
+				merge_override = $2
+			WHERE pull_request_id = $1 AND status = 'OPEN'
+	`
+
+	updatePullRequestStatusQuery = `
+		UPDATE pull_requests
+				SET status = $3::text,
+				closed_at = CASE WHEN $3::text = 'CLOSED' THEN now() END
+			WHERE pull_request_id = $1 AND status = $2
 	`
 
 	getPullRequestsQuantityAssignedReviewers = `
 		SELECT pr.pull_request_id, COUNT(ar.reviewer_user_id) AS quantity_reviewers
 			FROM pull_requests pr 
 		LEFT JOIN assigned_reviewers ar ON pr.pull_request_id = ar.pull_request_id 
+		WHERE $1 = '' OR pr.repository = $1
 			GROUP by pr.pull_request_id
 	`
 	createAssignedQuery = `
@@ -43,4 +102,241 @@ const (
 	deleteAssignedByReviewerIDQuery = `
 		DELETE FROM assigned_reviewers WHERE reviewer_user_id = $1
 	`
+	deleteAssignedByPullRequestIDQuery = `
+		DELETE FROM assigned_reviewers WHERE pull_request_id = $1
+	`
+	deleteAssignedReviewerQuery = `
+		DELETE FROM assigned_reviewers WHERE pull_request_id = $1 AND reviewer_user_id = $2
+	`
+
+	getOpenPullRequestIDsByReviewerQuery = `
+		SELECT pr.pull_request_id
+			FROM assigned_reviewers ar
+		JOIN pull_requests pr
+			ON pr.pull_request_id = ar.pull_request_id
+		WHERE ar.reviewer_user_id = $1 AND pr.status = 'OPEN'
+		ORDER BY pr.created_at, pr.pull_request_id
+	`
+
+	// DRAFT and OPEN PRs reviewed by the team: team chosen by the author, owner of the repository or primary team of the author
+	getActivePullRequestIDsByTeamQuery = `
+		SELECT pr.pull_request_id
+			FROM pull_requests pr
+		JOIN users u
+			ON u.user_id = pr.author_id
+		JOIN repositories repo
+			ON repo.repository = pr.repository
+		WHERE pr.status IN ('DRAFT', 'OPEN') AND COALESCE(pr.team_name, repo.team_name, u.team_name) = $1
+		ORDER BY pr.created_at, pr.pull_request_id
+	`
+
+	getTeamsPullRequestCountsQuery = `
+		SELECT COALESCE(pr.team_name, repo.team_name, u.team_name) AS team_name,
+			COUNT(*) FILTER (WHERE pr.status = 'DRAFT') AS draft,
+			COUNT(*) FILTER (WHERE pr.status = 'OPEN') AS open,
+			COUNT(*) FILTER (WHERE pr.status = 'MERGED') AS merged,
+			COUNT(*) FILTER (WHERE pr.status = 'CLOSED') AS closed,
+			COALESCE(SUM(ar.reviewers) FILTER (WHERE pr.status = 'OPEN'), 0) AS open_reviews
+			FROM pull_requests pr
+		JOIN users u
+			ON u.user_id = pr.author_id
+		JOIN repositories repo
+			ON repo.repository = pr.repository
+		CROSS JOIN LATERAL (
+			SELECT COUNT(*) AS reviewers
+				FROM assigned_reviewers
+			WHERE pull_request_id = pr.pull_request_id
+		) ar
+		WHERE COALESCE(pr.team_name, repo.team_name, u.team_name) = ANY($1) AND ($2 = '' OR pr.repository = $2)
+		GROUP BY 1
+		ORDER BY 1
+	`
+
+	setPullRequestsTeamQuery = `
+		UPDATE pull_requests
+			SET team_name = $2
+		WHERE pull_request_id = ANY($1)
+	`
+
+	getOpenAssignmentsByReviewersQuery = `
+		SELECT pr.pull_request_id, pr.author_id, COALESCE(pr.team_name, repo.team_name, u.team_name) AS review_team_name, ar.reviewer_user_id,
+			ARRAY(
+				SELECT r.reviewer_user_id FROM assigned_reviewers r
+				WHERE r.pull_request_id = pr.pull_request_id
+				ORDER BY r.reviewer_user_id
+			) AS assigned_reviewers,
+			ARRAY(
+				SELECT l.label FROM pull_request_labels l
+				WHERE l.pull_request_id = pr.pull_request_id
+				ORDER BY l.label
+			) AS labels
+			FROM assigned_reviewers ar
+		JOIN pull_requests pr
+			ON pr.pull_request_id = ar.pull_request_id
+		JOIN users u
+			ON u.user_id = pr.author_id
+		JOIN repositories repo
+			ON repo.repository = pr.repository
+		WHERE ar.reviewer_user_id = ANY($1) AND pr.status = 'OPEN'
+			AND ($2 = '' OR COALESCE(pr.team_name, repo.team_name, u.team_name) = $2)
+		ORDER BY pr.created_at, pr.pull_request_id, ar.reviewer_user_id
+	`
+
+	replaceManyReviewersQuery = `
+		WITH replacement AS (
+			SELECT * FROM unnest($1::text[], $2::text[], $3::text[])
+				AS r(pull_request_id, old_reviewer_id, new_reviewer_id)
+		), deleted AS (
+			DELETE FROM assigned_reviewers ar
+				USING replacement r
+			WHERE ar.pull_request_id = r.pull_request_id AND ar.reviewer_user_id = r.old_reviewer_id
+		)
+		INSERT INTO assigned_reviewers (reviewer_user_id, pull_request_id)
+			SELECT new_reviewer_id, pull_request_id FROM replacement
+		ON CONFLICT (reviewer_user_id, pull_request_id) DO NOTHING
+	`
+
+	createReviewQuery = `
+		INSERT INTO pull_request_reviews (pull_request_id, reviewer_user_id, state, body)
+			VALUES ($1, $2, $3, $4)
+		RETURNING review_id, pull_request_id, reviewer_user_id, state, body, submitted_at
+	`
+
+	getPullRequestReviewsQuery = `
+		SELECT review_id, pull_request_id, reviewer_user_id, state, body, submitted_at
+			FROM pull_request_reviews
+		WHERE pull_request_id = $1
+		ORDER BY review_id
+	`
+
+	createEventsQuery = `
+		INSERT INTO pull_request_events (pull_request_id, event_type, actor, payload)
+			SELECT e.pull_request_id, e.event_type, e.actor, e.payload::jsonb
+				FROM unnest($1::text[], $2::text[], $3::text[], $4::text[])
+				WITH ORDINALITY AS e(pull_request_id, event_type, actor, payload, n)
+			ORDER BY e.n
+	`
+
+	getPullRequestEventsQuery = `
+		SELECT event_id, pull_request_id, event_type, actor, payload, created_at
+			FROM pull_request_events
+		WHERE pull_request_id = $1
+		ORDER BY event_id
+	`
+
+	deleteDependenciesQuery = `
+		DELETE FROM pull_request_dependencies WHERE pull_request_id = $1
+	`
+
+	createDependenciesQuery = `
+		INSERT INTO pull_request_dependencies (pull_request_id, depends_on_id)
+			SELECT $1, unnest($2::text[])
+		ON CONFLICT (pull_request_id, depends_on_id) DO NOTHING
+	`
+
+	// UNION stops the recursion on visited PRs
+	hasDependencyPathQuery = `
+		WITH RECURSIVE reachable AS (
+			SELECT unnest($1::text[]) AS pull_request_id
+			UNION
+			SELECT d.depends_on_id
+				FROM pull_request_dependencies d
+			JOIN reachable r ON d.pull_request_id = r.pull_request_id
+		)
+		SELECT EXISTS(SELECT 1 FROM reachable WHERE pull_request_id = $2)
+	`
+
+	getDependencyGraphQuery = `
+		WITH RECURSIVE edges AS (
+			SELECT pull_request_id, depends_on_id
+				FROM pull_request_dependencies
+			WHERE pull_request_id = $1
+			UNION
+			SELECT d.pull_request_id, d.depends_on_id
+				FROM pull_request_dependencies d
+			JOIN edges e ON d.pull_request_id = e.depends_on_id
+		)
+		SELECT e.pull_request_id, e.depends_on_id, pr.status
+			FROM edges e
+		JOIN pull_requests pr ON pr.pull_request_id = e.depends_on_id
+		ORDER BY e.pull_request_id, e.depends_on_id
+	`
+
+	getUnmergedDependenciesQuery = `
+		SELECT d.depends_on_id
+			FROM pull_request_dependencies d
+		JOIN pull_requests pr ON pr.pull_request_id = d.depends_on_id
+		WHERE d.pull_request_id = $1 AND pr.status IN ('DRAFT', 'OPEN')
+		ORDER BY d.depends_on_id
+	`
+
+	// DRAFT and OPEN PRs which depend on the PR directly or through other PRs
+	getBlockedPullRequestsQuery = `
+		WITH RECURSIVE dependents AS (
+			SELECT pull_request_id
+				FROM pull_request_dependencies
+			WHERE depends_on_id = $1
+			UNION
+			SELECT d.pull_request_id
+				FROM pull_request_dependencies d
+			JOIN dependents r ON d.depends_on_id = r.pull_request_id
+		)
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at
+			FROM dependents r
+		JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		WHERE pr.status IN ('DRAFT', 'OPEN')
+		ORDER BY pr.created_at, pr.pull_request_id
+	`
+
+	// running SLAs of OPEN PRs which deadline is not later than $1 and which breach is not reported yet,
+	// review SLA runs until the reviewer submits a review after assignment. SLA is of the team which reviews the PR
+	getDueSLATimersQuery = `
+		WITH timers AS (
+			SELECT 'REVIEW' AS sla_kind, pr.pull_request_id, ar.reviewer_user_id, t.team_name,
+				ar.assigned_at AS started_at, t.review_sla_hours AS sla_hours, t.sla_escalation
+				FROM assigned_reviewers ar
+			JOIN pull_requests pr ON pr.pull_request_id = ar.pull_request_id
+			JOIN users u ON u.user_id = pr.author_id
+			JOIN repositories repo ON repo.repository = pr.repository
+			JOIN teams t ON t.team_name = COALESCE(pr.team_name, repo.team_name, u.team_name)
+			WHERE pr.status = 'OPEN' AND t.review_sla_hours > 0
+				AND NOT EXISTS(
+					SELECT 1 FROM pull_request_reviews r
+					WHERE r.pull_request_id = ar.pull_request_id AND r.reviewer_user_id = ar.reviewer_user_id
+						AND r.submitted_at >= ar.assigned_at
+				)
+			UNION ALL
+			SELECT 'MERGE' AS sla_kind, pr.pull_request_id, '' AS reviewer_user_id, t.team_name,
+				pr.created_at AS started_at, t.merge_sla_hours AS sla_hours, t.sla_escalation
+				FROM pull_requests pr
+			JOIN users u ON u.user_id = pr.author_id
+			JOIN repositories repo ON repo.repository = pr.repository
+			JOIN teams t ON t.team_name = COALESCE(pr.team_name, repo.team_name, u.team_name)
+			WHERE pr.status = 'OPEN' AND t.merge_sla_hours > 0
+		)
+		SELECT sla_kind, pull_request_id, reviewer_user_id, team_name, started_at, sla_hours, sla_escalation
+			FROM timers tm
+		WHERE tm.started_at + tm.sla_hours * interval '1 hour' <= $1
+			AND NOT EXISTS(
+				SELECT 1 FROM sla_notices n
+				WHERE n.pull_request_id = tm.pull_request_id AND n.sla_kind = tm.sla_kind
+					AND n.reviewer_user_id = tm.reviewer_user_id AND n.started_at = tm.started_at
+					AND n.level = 'BREACH'
+			)
+		ORDER BY started_at, pull_request_id, reviewer_user_id
+	`
+
+	// concurrent checks wait for each other on the primary key and only one of them inserts the notice
+	createSLANoticeQuery = `
+		INSERT INTO sla_notices (pull_request_id, sla_kind, reviewer_user_id, started_at, level)
+			VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT DO NOTHING
+	`
+
+	lockAssignmentsQuery = `
+		SELECT pg_advisory_xact_lock($1)
+	`
+	lockDependenciesQuery = `
+		SELECT pg_advisory_xact_lock($1)
+	`
 )
@@ -0,0 +1,75 @@
+package schedulerrepository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/jmoiron/sqlx"
+)
+
+type schedulerRepository struct{}
+
+func NewSchedulerRepository() *schedulerRepository {
+	return &schedulerRepository{}
+}
+
+// takes transaction level lock of the job, returns false if it is held by another transaction
+func (r *schedulerRepository) TryLockJob(ctx context.Context, exec sqlx.ExtContext, job string) (bool, error) {
+	var locked bool
+	if err := exec.QueryRowxContext(ctx, tryLockJobQuery, job).Scan(&locked); err != nil {
+		return false, err
+	}
+	return locked, nil
+}
+
+// staleReviewRow scans quiet hours of reviewer who may have none
+type staleReviewRow struct {
+	models.ReviewReminder
+	QuietFrom     sql.NullString `db:"quiet_from"`
+	QuietTo       sql.NullString `db:"quiet_to"`
+	QuietTimezone sql.NullString `db:"quiet_timezone"`
+}
+
+func (r *schedulerRepository) GetStaleReviews(ctx context.Context, exec sqlx.ExtContext, assignedBefore, claimedBefore time.Time) ([]models.ReviewReminder, error) {
+	var rows []staleReviewRow
+	if err := sqlx.SelectContext(ctx, exec, &rows, getStaleReviewsQuery, assignedBefore, claimedBefore); err != nil {
+		return nil, err
+	}
+
+	reminders := make([]models.ReviewReminder, 0, len(rows))
+	for _, row := range rows {
+		reminder := row.ReviewReminder
+		if row.QuietFrom.Valid {
+			reminder.QuietHours = &models.QuietHours{
+				UserID:   reminder.ReviewerID,
+				From:     row.QuietFrom.String,
+				To:       row.QuietTo.String,
+				Timezone: row.QuietTimezone.String,
+			}
+		}
+		reminders = append(reminders, reminder)
+	}
+	return reminders, nil
+}
+
+func (r *schedulerRepository) ClaimReminder(ctx context.Context, exec sqlx.ExtContext, reminder *models.ReviewReminder) error {
+	_, err := exec.ExecContext(ctx, claimReminderQuery, reminder.PullRequestID, reminder.ReviewerID, reminder.AssignedAt)
+	return err
+}
+
+func (r *schedulerRepository) SetReminderStatus(ctx context.Context, exec sqlx.ExtContext, reminder *models.ReviewReminder, status models.ReminderStatus) error {
+	res, err := exec.ExecContext(ctx, setReminderStatusQuery, reminder.PullRequestID, reminder.ReviewerID, reminder.AssignedAt, status)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package schedulerrepository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryLockJob(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	schedulerRepo := NewSchedulerRepository()
+
+	mock.ExpectQuery(tryLockJobQuery).WithArgs("stale_reviews").WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+	mock.ExpectQuery(tryLockJobQuery).WithArgs("stale_reviews").WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(false))
+
+	locked, err := schedulerRepo.TryLockJob(context.Background(), sqlxDB, "stale_reviews")
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	locked, err = schedulerRepo.TryLockJob(context.Background(), sqlxDB, "stale_reviews")
+	require.NoError(t, err)
+	require.False(t, locked)
+}
+
+func TestStaleReviews(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	schedulerRepo := NewSchedulerRepository()
+	assignedBefore := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	assignedAt := assignedBefore.Add(-time.Hour)
+	claimedBefore := assignedBefore.Add(23 * time.Hour)
+
+	t.Run("Get with quiet hours", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"reviewer_user_id", "pull_request_id", "pull_request_name", "assigned_at", "quiet_from", "quiet_to", "quiet_timezone"}).
+			AddRow("u1", "pr-1", "fix", assignedAt, "22:00", "08:00", "UTC").
+			AddRow("u2", "pr-1", "fix", assignedAt, nil, nil, nil)
+		mock.ExpectQuery(getStaleReviewsQuery).WithArgs(assignedBefore, claimedBefore).WillReturnRows(rows)
+
+		reminders, err := schedulerRepo.GetStaleReviews(context.Background(), sqlxDB, assignedBefore, claimedBefore)
+		require.NoError(t, err)
+		require.Equal(t, []models.ReviewReminder{
+			{ReviewerID: "u1", PullRequestID: "pr-1", PullRequestName: "fix", AssignedAt: assignedAt,
+				QuietHours: &models.QuietHours{UserID: "u1", From: "22:00", To: "08:00", Timezone: "UTC"}},
+			{ReviewerID: "u2", PullRequestID: "pr-1", PullRequestName: "fix", AssignedAt: assignedAt},
+		}, reminders)
+	})
+
+	reminder := models.ReviewReminder{ReviewerID: "u1", PullRequestID: "pr-1", AssignedAt: assignedAt}
+
+	t.Run("Claim reminder", func(t *testing.T) {
+		mock.ExpectExec(claimReminderQuery).WithArgs("pr-1", "u1", assignedAt).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := schedulerRepo.ClaimReminder(context.Background(), sqlxDB, &reminder)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Mark reminder", func(t *testing.T) {
+		mock.ExpectExec(setReminderStatusQuery).WithArgs("pr-1", "u1", assignedAt, models.ReminderStatusSent).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(setReminderStatusQuery).WithArgs("pr-1", "u1", assignedAt, models.ReminderStatusFailed).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		require.NoError(t, schedulerRepo.SetReminderStatus(context.Background(), sqlxDB, &reminder, models.ReminderStatusSent))
+		require.ErrorIs(t, schedulerRepo.SetReminderStatus(context.Background(), sqlxDB, &reminder, models.ReminderStatusFailed), sql.ErrNoRows)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,47 @@
+package schedulerrepository
+
+const (
+	// lock is released with the transaction, concurrent replicas do not wait for it and skip the run
+	tryLockJobQuery = `
+		SELECT pg_try_advisory_xact_lock(hashtext($1))
+	`
+
+	// assignments of active reviewers to OPEN PRs which are older than $1,
+	// which are not reviewed since assignment and which are not reminded yet.
+	// Failed reminders are retried, pending ones only if they were claimed before $2 by a run which did not finish
+	getStaleReviewsQuery = `
+		SELECT ar.reviewer_user_id, pr.pull_request_id, pr.pull_request_name, ar.assigned_at,
+			to_char(qh.starts_at, 'HH24:MI') AS quiet_from, to_char(qh.ends_at, 'HH24:MI') AS quiet_to, qh.timezone AS quiet_timezone
+			FROM assigned_reviewers ar
+		JOIN pull_requests pr ON pr.pull_request_id = ar.pull_request_id
+		JOIN users u ON u.user_id = ar.reviewer_user_id
+		LEFT JOIN user_quiet_hours qh ON qh.user_id = ar.reviewer_user_id
+		WHERE pr.status = 'OPEN' AND u.is_active AND ar.assigned_at <= $1
+			AND NOT EXISTS(
+				SELECT 1 FROM pull_request_reviews r
+				WHERE r.pull_request_id = ar.pull_request_id AND r.reviewer_user_id = ar.reviewer_user_id
+					AND r.submitted_at >= ar.assigned_at
+			)
+			AND NOT EXISTS(
+				SELECT 1 FROM review_reminders rr
+				WHERE rr.pull_request_id = ar.pull_request_id AND rr.reviewer_user_id = ar.reviewer_user_id
+					AND rr.assigned_at = ar.assigned_at
+					AND (rr.status = 'SENT' OR (rr.status = 'PENDING' AND rr.claimed_at > $2))
+			)
+		ORDER BY ar.reviewer_user_id, ar.assigned_at, pr.pull_request_id
+	`
+
+	claimReminderQuery = `
+		INSERT INTO review_reminders (pull_request_id, reviewer_user_id, assigned_at, status, claimed_at)
+			VALUES ($1, $2, $3, 'PENDING', now())
+		ON CONFLICT (pull_request_id, reviewer_user_id, assigned_at) DO UPDATE
+			SET status = 'PENDING', claimed_at = now()
+	`
+
+	setReminderStatusQuery = `
+		UPDATE review_reminders
+			SET status = $4,
+				sent_at = CASE WHEN $4 = 'SENT' THEN now() END
+		WHERE pull_request_id = $1 AND reviewer_user_id = $2 AND assigned_at = $3
+	`
+)
@@ -5,11 +5,16 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Negat1v9/pr-review-service/internal/models"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
+// hierarchyLockKey guards team hierarchy changes, so concurrent changes can not create a cycle
+const hierarchyLockKey int64 = 7_301_003
+
 type teamRepositiry struct{}
 
 func NewTeamRepositiry() *teamRepositiry {
@@ -37,13 +42,19 @@ func (r *teamRepositiry) GetTeamWithMembers(ctx context.Context, exec sqlx.ExtCo
 	for rows.Next() {
 		var member models.User
 		var scannedTeamName string
+		var membershipActive bool
+		var archivedAt *time.Time
+		var parentTeam string
 
-		if err = rows.Scan(&scannedTeamName, &member.UserID, &member.Username, &member.IsActive); err != nil {
+		if err = rows.Scan(&scannedTeamName, &member.UserID, &member.Username, &member.IsActive, &member.Role, &membershipActive, &archivedAt, &parentTeam); err != nil {
 			return nil, err
 		}
+		member.MembershipActive = &membershipActive
 
 		if team.TeamName == "" {
 			team.TeamName = scannedTeamName
+			team.ArchivedAt = archivedAt
+			team.ParentTeam = parentTeam
 		}
 
 		team.Members = append(team.Members, member)
@@ -60,91 +71,339 @@ func (r *teamRepositiry) GetTeamWithMembers(ctx context.Context, exec sqlx.ExtCo
 	return &team, nil
 }
 
-// return all active users team and empty array if user with userID does nit exists
-// if user exists but he is one active user in team return sql.ErrNoRows
-func (r *teamRepositiry) GetUsersIDFromUserTeam(ctx context.Context, exec sqlx.ExtContext, userID string, limit int) ([]string, error) {
-	// limit+1 select reasoon -> target id selected also
-	rows, err := exec.QueryxContext(ctx, getActiveUserIDFromUserTeamQuery, userID, limit+1)
+// sets archive time of the team, returns sql.ErrNoRows if team does not exist or is already archived
+func (r *teamRepositiry) ArchiveTeam(ctx context.Context, exec sqlx.ExtContext, teamName string) (time.Time, error) {
+	var archivedAt time.Time
+	if err := exec.QueryRowxContext(ctx, archiveTeamQuery, teamName).Scan(&archivedAt); err != nil {
+		return time.Time{}, err
+	}
+	return archivedAt, nil
+}
+
+func (r *teamRepositiry) SetParentTeam(ctx context.Context, exec sqlx.ExtContext, teamName, parentTeam string) error {
+	res, err := exec.ExecContext(ctx, setParentTeamQuery, teamName, parentTeam)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
 	}
+	return nil
+}
 
-	userTeamMembersID := make([]string, 0, limit)
+func (r *teamRepositiry) HasAncestor(ctx context.Context, exec sqlx.ExtContext, teamName, ancestor string) (bool, error) {
+	var exists bool
+	if err := exec.QueryRowxContext(ctx, hasAncestorQuery, teamName, ancestor).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *teamRepositiry) LockHierarchy(ctx context.Context, exec sqlx.ExtContext) error {
+	_, err := exec.ExecContext(ctx, lockHierarchyQuery, hierarchyLockKey)
+	return err
+}
 
+// returns the team and its descendants ordered by name with their members
+func (r *teamRepositiry) GetSubtree(ctx context.Context, exec sqlx.ExtContext, teamName string) ([]models.Team, error) {
+	rows, err := exec.QueryxContext(ctx, getSubtreeQuery, teamName)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
-	exitstUserID := false
+	teams := make([]models.Team, 0)
 	for rows.Next() {
-		var id string
-		if err = rows.Scan(&id); err != nil {
+		var team models.Team
+		var userID, username, primaryTeam, role sql.NullString
+		var isActive, membershipActive sql.NullBool
+
+		if err := rows.Scan(&team.TeamName, &team.ParentTeam, &team.ArchivedAt,
+			&userID, &username, &primaryTeam, &isActive, &role, &membershipActive); err != nil {
 			return nil, err
 		}
-		// user with userID exists
-		if id == userID {
-			exitstUserID = true
-			// not add in result
+
+		if len(teams) == 0 || teams[len(teams)-1].TeamName != team.TeamName {
+			team.Members = make([]models.User, 0)
+			teams = append(teams, team)
+		}
+		if !userID.Valid {
 			continue
 		}
-		userTeamMembersID = append(userTeamMembersID, id)
+
+		last := &teams[len(teams)-1]
+		last.Members = append(last.Members, models.User{
+			UserID:           userID.String,
+			Username:         username.String,
+			TeamName:         primaryTeam.String,
+			IsActive:         isActive.Bool,
+			Role:             models.MembershipRole(role.String),
+			MembershipActive: &membershipActive.Bool,
+		})
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
 
-	// user not exists
-	if !exitstUserID {
+	if len(teams) == 0 {
 		return nil, sql.ErrNoRows
 	}
+	return teams, nil
+}
+
+// adds users to the team, users who are already members keep their memberships.
+// Role is MEMBER if empty and membership is active unless MembershipActive is false
+func (r *teamRepositiry) CreateMemberships(ctx context.Context, exec sqlx.ExtContext, teamName string, members []models.User) error {
+	return r.insertMemberships(ctx, exec, createMembershipsQuery, teamName, members)
+}
 
-	return userTeamMembersID, nil
+// adds users to the team or updates role and flag of their memberships, defaults are the same as in CreateMemberships
+func (r *teamRepositiry) UpsertMemberships(ctx context.Context, exec sqlx.ExtContext, teamName string, members []models.User) error {
+	return r.insertMemberships(ctx, exec, upsertMembershipsQuery, teamName, members)
 }
 
-func (r *teamRepositiry) GetActiveUsersTeamWithException(ctx context.Context, exec sqlx.ExtContext, userID string, exceptions []string, limit int) ([]string, error) {
+func (r *teamRepositiry) insertMemberships(ctx context.Context, exec sqlx.ExtContext, query, teamName string, members []models.User) error {
+	if len(members) == 0 {
+		return nil
+	}
+
 	var placeholders []string
+	var args []any
+
+	for i, member := range members {
+		role := member.Role
+		if role == "" {
+			role = models.MembershipRoleMember
+		}
+		isActive := member.MembershipActive == nil || *member.MembershipActive
+
+		offset := i * 4
+		placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d, $%d)", offset+1, offset+2, offset+3, offset+4))
+		args = append(args, member.UserID, teamName, role, isActive)
+	}
+
+	_, err := exec.ExecContext(ctx, fmt.Sprintf(query, strings.Join(placeholders, ",")), args...)
+	return err
+}
 
-	for _, expUserID := range exceptions {
-		placeholders = append(placeholders, fmt.Sprintf("'%s'", expUserID))
+func (r *teamRepositiry) DeleteMembership(ctx context.Context, exec sqlx.ExtContext, teamName, userID string) error {
+	res, err := exec.ExecContext(ctx, deleteMembershipQuery, teamName, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *teamRepositiry) GetMembership(ctx context.Context, exec sqlx.ExtContext, teamName, userID string) (*models.TeamMembership, error) {
+	var membership models.TeamMembership
+	if err := exec.QueryRowxContext(ctx, getMembershipQuery, teamName, userID).StructScan(&membership); err != nil {
+		return nil, err
 	}
-	query := fmt.Sprintf(getActiveUserFromUserTeamWithException, strings.Join(placeholders, ","))
-	// limit select reasoon -> target id selected also
-	rows, err := exec.QueryxContext(ctx, query, userID, limit+1)
+	return &membership, nil
+}
+
+func (r *teamRepositiry) UpdateMembership(ctx context.Context, exec sqlx.ExtContext, membership *models.TeamMembership) (*models.TeamMembership, error) {
+	var updated models.TeamMembership
+	err := exec.QueryRowxContext(ctx, updateMembershipQuery, membership.Role, membership.IsActive, membership.TeamName, membership.UserID).StructScan(&updated)
 	if err != nil {
 		return nil, err
 	}
+	return &updated, nil
+}
 
-	// limit select reasoon -> target id selected also
-	userTeamMembersID := make([]string, 0, limit)
+// returns team settings with fallback teams in their order
+func (r *teamRepositiry) GetTeamSettings(ctx context.Context, exec sqlx.ExtContext, teamName string) (*models.TeamSettings, error) {
+	var settings models.TeamSettings
+	if err := exec.QueryRowxContext(ctx, getTeamSettingsQuery, teamName).StructScan(&settings); err != nil {
+		return nil, err
+	}
 
+	rows, err := exec.QueryxContext(ctx, getTeamFallbacksQuery, teamName)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
-	exitstUserID := false
 
+	settings.FallbackTeams = make([]string, 0)
 	for rows.Next() {
-		var id string
-		if err = rows.Scan(&id); err != nil {
+		var fallbackTeam string
+		if err := rows.Scan(&fallbackTeam); err != nil {
 			return nil, err
 		}
-		// user with userID exists
-		if id == userID {
-			exitstUserID = true
-			// not add in result
-			continue
-		}
-		userTeamMembersID = append(userTeamMembersID, id)
+		settings.FallbackTeams = append(settings.FallbackTeams, fallbackTeam)
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
 
-	// user not exists
-	if !exitstUserID {
-		return nil, sql.ErrNoRows
+	return &settings, nil
+}
+
+// updates team settings and replaces its fallback teams, should be called in transaction
+func (r *teamRepositiry) UpdateTeamSettings(ctx context.Context, exec sqlx.ExtContext, settings *models.TeamSettings) error {
+	res, err := exec.ExecContext(ctx, updateTeamSettingsQuery,
+		settings.MinReviewers, settings.MaxReviewers, settings.UnderstaffedPolicy, settings.TeamName,
+	)
+	if err != nil {
+		return err
 	}
 
-	if len(userTeamMembersID) == 0 {
-		return nil, sql.ErrNoRows
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if _, err := exec.ExecContext(ctx, deleteTeamFallbacksQuery, settings.TeamName); err != nil {
+		return err
+	}
+
+	if len(settings.FallbackTeams) == 0 {
+		return nil
+	}
+
+	var placeholders []string
+	var args []any
+
+	for i, fallbackTeam := range settings.FallbackTeams {
+		offset := i * 3
+		placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d)", offset+1, offset+2, offset+3))
+		args = append(args, settings.TeamName, fallbackTeam, i+1)
+	}
+
+	query := fmt.Sprintf(createTeamFallbacksQuery, strings.Join(placeholders, ","))
+
+	_, err = exec.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *teamRepositiry) GetMergePolicy(ctx context.Context, exec sqlx.ExtContext, teamName string) (*models.MergePolicy, error) {
+	var policy models.MergePolicy
+	if err := exec.QueryRowxContext(ctx, getMergePolicyQuery, teamName).StructScan(&policy); err != nil {
+		return nil, err
+	}
+
+	policy.RequiredApprovers = make([]string, 0)
+	if err := sqlx.SelectContext(ctx, exec, &policy.RequiredApprovers, getRequiredApproversQuery, teamName); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// updates merge policy and replaces required approvers, should be called in transaction
+func (r *teamRepositiry) UpdateMergePolicy(ctx context.Context, exec sqlx.ExtContext, policy *models.MergePolicy) error {
+	res, err := exec.ExecContext(ctx, updateMergePolicyQuery, policy.MinApprovals, policy.BlockOnChangesRequested, policy.TeamName)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if _, err := exec.ExecContext(ctx, deleteRequiredApproversQuery, policy.TeamName); err != nil {
+		return err
+	}
+
+	if len(policy.RequiredApprovers) == 0 {
+		return nil
+	}
+
+	_, err = exec.ExecContext(ctx, createRequiredApproversQuery, policy.TeamName, pq.Array(policy.RequiredApprovers))
+	return err
+}
+
+func (r *teamRepositiry) GetSLAPolicy(ctx context.Context, exec sqlx.ExtContext, teamName string) (*models.SLAPolicy, error) {
+	var policy models.SLAPolicy
+	if err := exec.QueryRowxContext(ctx, getSLAPolicyQuery, teamName).StructScan(&policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *teamRepositiry) UpdateSLAPolicy(ctx context.Context, exec sqlx.ExtContext, policy *models.SLAPolicy) error {
+	res, err := exec.ExecContext(ctx, updateSLAPolicyQuery, policy.ReviewSLAHours, policy.MergeSLAHours, policy.Escalation, policy.TeamName)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// returns all members of the team with their review load, inactive members included
+func (r *teamRepositiry) GetTeamReviewCandidates(ctx context.Context, exec sqlx.ExtContext, teamName string) ([]models.ReviewCandidate, error) {
+	return r.queryReviewCandidates(ctx, exec, getTeamReviewCandidatesQuery, teamName)
+}
+
+// returns listed users with their review load, unknown users are skipped
+func (r *teamRepositiry) GetSubtreeReviewCandidates(ctx context.Context, exec sqlx.ExtContext, teamName string) ([]models.ReviewCandidate, error) {
+	return r.queryReviewCandidates(ctx, exec, getSubtreeReviewCandidatesQuery, teamName)
+}
+
+func (r *teamRepositiry) GetReviewCandidatesByUserIDs(ctx context.Context, exec sqlx.ExtContext, userIDs []string) ([]models.ReviewCandidate, error) {
+	return r.queryReviewCandidates(ctx, exec, getReviewCandidatesByUserIDsQuery, pq.Array(userIDs))
+}
+
+// reviewCandidateRow scans postgres array of skills
+type reviewCandidateRow struct {
+	models.ReviewCandidate
+	Skills pq.StringArray `db:"skills"`
+}
+
+func (r *teamRepositiry) queryReviewCandidates(ctx context.Context, exec sqlx.ExtContext, query string, args ...any) ([]models.ReviewCandidate, error) {
+	rows, err := exec.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := make([]models.ReviewCandidate, 0)
+	for rows.Next() {
+		var row reviewCandidateRow
+		if err = rows.StructScan(&row); err != nil {
+			return nil, err
+		}
+		row.ReviewCandidate.Skills = row.Skills
+		candidates = append(candidates, row.ReviewCandidate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
 	}
 
-	return userTeamMembersID, nil
+	return candidates, nil
 }
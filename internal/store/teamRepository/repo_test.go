@@ -2,12 +2,15 @@ package teamrepository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
-	"strings"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Negat1v9/pr-review-service/internal/models"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -43,9 +46,9 @@ func TestGetTeamWithMembers(t *testing.T) {
 
 	t.Run("Get team with memmbers", func(t *testing.T) {
 
-		rowsTeam := sqlmock.NewRows([]string{"team_name", "user_id", "username", "is_active"}).
-			AddRow("team-1", "userID", "username", true).
-			AddRow("team-1", "userID-1", "username2", false)
+		rowsTeam := sqlmock.NewRows([]string{"team_name", "user_id", "username", "is_active", "role", "is_active", "archived_at", "parent_team"}).
+			AddRow("team-1", "userID", "username", true, "LEAD", true, nil, "platform").
+			AddRow("team-1", "userID-1", "username2", true, "MEMBER", false, nil, "platform")
 
 		mock.ExpectQuery(getTeamWithUsersByNameQuery).
 			WithArgs("team-1").WillReturnRows(rowsTeam)
@@ -53,10 +56,14 @@ func TestGetTeamWithMembers(t *testing.T) {
 		team, err := teamRepo.GetTeamWithMembers(context.Background(), sqlxDB, "team-1")
 		require.NoError(t, err)
 		require.Equal(t, 2, len(team.Members))
+		require.Equal(t, models.MembershipRoleLead, team.Members[0].Role)
+		require.False(t, *team.Members[1].MembershipActive)
+		require.Nil(t, team.ArchivedAt)
+		require.Equal(t, "platform", team.ParentTeam)
 	})
 }
 
-func TestGetUsersIDFromUserTeam(t *testing.T) {
+func TestHierarchy(t *testing.T) {
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 	defer db.Close()
@@ -66,23 +73,51 @@ func TestGetUsersIDFromUserTeam(t *testing.T) {
 
 	teamRepo := NewTeamRepositiry()
 
-	t.Run("Get User IDs from user team", func(t *testing.T) {
+	t.Run("Set parent of unknown team", func(t *testing.T) {
+		mock.ExpectExec(setParentTeamQuery).WithArgs("team-1", "platform").WillReturnResult(sqlmock.NewResult(0, 0))
 
-		rowsTeamUsers := sqlmock.NewRows([]string{"user_id"}).
-			AddRow("userID").
-			AddRow("userID-1").
-			AddRow("userID-2")
+		err := teamRepo.SetParentTeam(context.Background(), sqlxDB, "team-1", "platform")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("Has ancestor", func(t *testing.T) {
+		mock.ExpectQuery(hasAncestorQuery).WithArgs("platform", "team-1").
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		exists, err := teamRepo.HasAncestor(context.Background(), sqlxDB, "platform", "team-1")
+		require.NoError(t, err)
+		require.True(t, exists)
+	})
 
-		mock.ExpectQuery(getActiveUserIDFromUserTeamQuery).
-			WithArgs("userID", 3).WillReturnRows(rowsTeamUsers)
+	t.Run("Get subtree", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"team_name", "parent_team", "archived_at", "user_id", "username", "team_name", "is_active", "role", "is_active"}).
+			AddRow("backend", "platform", nil, "u2", "bob", "backend", true, "MEMBER", true).
+			AddRow("backend", "platform", nil, "u3", "carol", "backend", true, "LEAD", true).
+			AddRow("empty", "platform", nil, nil, nil, nil, nil, nil, nil).
+			AddRow("platform", "", nil, "u1", "alice", "platform", true, "LEAD", true)
+		mock.ExpectQuery(getSubtreeQuery).WithArgs("platform").WillReturnRows(rows)
 
-		userIDs, err := teamRepo.GetUsersIDFromUserTeam(context.Background(), sqlxDB, "userID", 2)
+		teams, err := teamRepo.GetSubtree(context.Background(), sqlxDB, "platform")
 		require.NoError(t, err)
-		require.Equal(t, 2, len(userIDs))
+		require.Len(t, teams, 3)
+		require.Len(t, teams[0].Members, 2)
+		require.Equal(t, models.MembershipRoleLead, teams[0].Members[1].Role)
+		require.Empty(t, teams[1].Members)
+		require.Equal(t, "", teams[2].ParentTeam)
 	})
+
+	t.Run("Get subtree of unknown team", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"team_name", "parent_team", "archived_at", "user_id", "username", "team_name", "is_active", "role", "is_active"})
+		mock.ExpectQuery(getSubtreeQuery).WithArgs("unknown").WillReturnRows(rows)
+
+		_, err := teamRepo.GetSubtree(context.Background(), sqlxDB, "unknown")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestGetActiveUsersTeamWithException(t *testing.T) {
+func TestArchiveTeam(t *testing.T) {
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
 	defer db.Close()
@@ -92,24 +127,288 @@ func TestGetActiveUsersTeamWithException(t *testing.T) {
 
 	teamRepo := NewTeamRepositiry()
 
-	t.Run("Get Active Users Team With Exception", func(t *testing.T) {
-		exceptionUsers := []string{"userID-1", "userID-2"}
-		rowsTeamUsers := sqlmock.NewRows([]string{"user_id"}).
-			AddRow("userID").
-			AddRow("userID-4").
-			AddRow("userID-3")
+	t.Run("Archive team", func(t *testing.T) {
+		archivedAt := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(archiveTeamQuery).WithArgs("team-1").
+			WillReturnRows(sqlmock.NewRows([]string{"archived_at"}).AddRow(archivedAt))
+
+		res, err := teamRepo.ArchiveTeam(context.Background(), sqlxDB, "team-1")
+		require.NoError(t, err)
+		require.Equal(t, archivedAt, res)
+	})
+
+	t.Run("Archive already archived team", func(t *testing.T) {
+		mock.ExpectQuery(archiveTeamQuery).WithArgs("team-1").WillReturnRows(sqlmock.NewRows([]string{"archived_at"}))
+
+		_, err := teamRepo.ArchiveTeam(context.Background(), sqlxDB, "team-1")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestMemberships(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
 
-		placeholders := make([]string, 0, len(exceptionUsers))
-		for _, expUserID := range exceptionUsers {
-			placeholders = append(placeholders, fmt.Sprintf("'%s'", expUserID))
+	teamRepo := NewTeamRepositiry()
+
+	t.Run("Create memberships with defaults", func(t *testing.T) {
+		inactive := false
+		members := []models.User{
+			{UserID: "u1"},
+			{UserID: "u2", Role: models.MembershipRoleLead, MembershipActive: &inactive},
 		}
-		query := fmt.Sprintf(getActiveUserFromUserTeamWithException, strings.Join(placeholders, ","))
+		query := fmt.Sprintf(createMembershipsQuery, "($1, $2, $3, $4),($5, $6, $7, $8)")
+		mock.ExpectExec(query).
+			WithArgs("u1", "guild", models.MembershipRoleMember, true, "u2", "guild", models.MembershipRoleLead, false).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		require.NoError(t, teamRepo.CreateMemberships(context.Background(), sqlxDB, "guild", members))
+	})
+
+	t.Run("Get membership", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"user_id", "team_name", "role", "is_active"}).AddRow("u1", "guild", "MEMBER", true)
+		mock.ExpectQuery(getMembershipQuery).WithArgs("guild", "u1").WillReturnRows(rows)
+
+		membership, err := teamRepo.GetMembership(context.Background(), sqlxDB, "guild", "u1")
+		require.NoError(t, err)
+		require.Equal(t, models.TeamMembership{UserID: "u1", TeamName: "guild", Role: models.MembershipRoleMember, IsActive: true}, *membership)
+	})
+
+	t.Run("Update membership of not a member", func(t *testing.T) {
+		mock.ExpectQuery(updateMembershipQuery).
+			WithArgs(models.MembershipRoleLead, false, "guild", "u9").
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "team_name", "role", "is_active"}))
+
+		_, err := teamRepo.UpdateMembership(context.Background(), sqlxDB, &models.TeamMembership{UserID: "u9", TeamName: "guild", Role: models.MembershipRoleLead})
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("Upsert memberships", func(t *testing.T) {
+		members := []models.User{{UserID: "u1", Role: models.MembershipRoleLead}}
+		query := fmt.Sprintf(upsertMembershipsQuery, "($1, $2, $3, $4)")
+		mock.ExpectExec(query).
+			WithArgs("u1", "guild", models.MembershipRoleLead, true).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, teamRepo.UpsertMemberships(context.Background(), sqlxDB, "guild", members))
+	})
+
+	t.Run("Delete membership of not a member", func(t *testing.T) {
+		mock.ExpectExec(deleteMembershipQuery).WithArgs("guild", "u9").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := teamRepo.DeleteMembership(context.Background(), sqlxDB, "guild", "u9")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestGetTeamReviewCandidates(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	teamRepo := NewTeamRepositiry()
+
+	t.Run("Get team review candidates", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"user_id", "team_name", "is_active", "open_reviews", "skills", "unavailable"}).
+			AddRow("userID", "team-1", true, 0, "{}", true).
+			AddRow("userID-1", "team-1", false, 3, "{go}", false).
+			AddRow("userID-2", "team-1", true, 1, "{go,sql}", false)
+
+		mock.ExpectQuery(getTeamReviewCandidatesQuery).
+			WithArgs("team-1").WillReturnRows(rows)
+
+		candidates, err := teamRepo.GetTeamReviewCandidates(context.Background(), sqlxDB, "team-1")
+		require.NoError(t, err)
+		require.Equal(t, 3, len(candidates))
+		require.Equal(t, false, candidates[1].IsActive)
+		require.Equal(t, 1, candidates[2].OpenReviews)
+		require.Equal(t, []string{"go", "sql"}, candidates[2].Skills)
+		require.Equal(t, true, candidates[0].Unavailable)
+	})
+
+	t.Run("Empty team", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"user_id", "team_name", "is_active", "open_reviews"})
+
+		mock.ExpectQuery(getTeamReviewCandidatesQuery).
+			WithArgs("team-2").WillReturnRows(rows)
+
+		candidates, err := teamRepo.GetTeamReviewCandidates(context.Background(), sqlxDB, "team-2")
+		require.NoError(t, err)
+		require.Equal(t, 0, len(candidates))
+	})
+}
+
+func TestGetReviewCandidatesByUserIDs(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
 
-		mock.ExpectQuery(query).
-			WithArgs("userID", 3).WillReturnRows(rowsTeamUsers)
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
 
-		userIDs, err := teamRepo.GetActiveUsersTeamWithException(context.Background(), sqlxDB, "userID", exceptionUsers, 2)
+	teamRepo := NewTeamRepositiry()
+
+	t.Run("Get candidates by user ids", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"user_id", "team_name", "is_active", "max_open_reviews", "open_reviews"}).
+			AddRow("u1", "team-1", true, 2, 2).
+			AddRow("u2", "team-2", true, nil, 0)
+
+		mock.ExpectQuery(getReviewCandidatesByUserIDsQuery).
+			WithArgs(pq.Array([]string{"u1", "u2", "unknown"})).WillReturnRows(rows)
+
+		candidates, err := teamRepo.GetReviewCandidatesByUserIDs(context.Background(), sqlxDB, []string{"u1", "u2", "unknown"})
 		require.NoError(t, err)
-		require.Equal(t, 2, len(userIDs))
+		require.Equal(t, 2, len(candidates))
+		require.True(t, candidates[0].OverCapacity())
+		require.False(t, candidates[1].OverCapacity())
+	})
+}
+
+func TestTeamSettings(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	teamRepo := NewTeamRepositiry()
+	columns := []string{"team_name", "min_reviewers", "max_reviewers", "understaffed_policy"}
+
+	t.Run("Get team settings", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).AddRow("team-1", 1, 3, "FALLBACK")
+		fallbackRows := sqlmock.NewRows([]string{"fallback_team_name"}).AddRow("team-3").AddRow("team-2")
+
+		mock.ExpectQuery(getTeamSettingsQuery).WithArgs("team-1").WillReturnRows(rows)
+		mock.ExpectQuery(getTeamFallbacksQuery).WithArgs("team-1").WillReturnRows(fallbackRows)
+
+		settings, err := teamRepo.GetTeamSettings(context.Background(), sqlxDB, "team-1")
+		require.NoError(t, err)
+		require.Equal(t, 3, settings.MaxReviewers)
+		require.Equal(t, models.UnderstaffedPolicyFallback, settings.UnderstaffedPolicy)
+		require.Equal(t, []string{"team-3", "team-2"}, settings.FallbackTeams)
+	})
+
+	t.Run("Get team settings not found", func(t *testing.T) {
+		mock.ExpectQuery(getTeamSettingsQuery).WithArgs("team-3").WillReturnError(sql.ErrNoRows)
+
+		settings, err := teamRepo.GetTeamSettings(context.Background(), sqlxDB, "team-3")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		require.Nil(t, settings)
+	})
+
+	t.Run("Update team settings", func(t *testing.T) {
+		settings := models.TeamSettings{
+			TeamName:           "team-1",
+			MinReviewers:       1,
+			MaxReviewers:       2,
+			UnderstaffedPolicy: models.UnderstaffedPolicyFallback,
+			FallbackTeams:      []string{"team-3", "team-2"},
+		}
+
+		mock.ExpectExec(updateTeamSettingsQuery).
+			WithArgs(1, 2, models.UnderstaffedPolicyFallback, "team-1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(deleteTeamFallbacksQuery).WithArgs("team-1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(fmt.Sprintf(createTeamFallbacksQuery, "($1, $2, $3),($4, $5, $6)")).
+			WithArgs("team-1", "team-3", 1, "team-1", "team-2", 2).WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := teamRepo.UpdateTeamSettings(context.Background(), sqlxDB, &settings)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Update team settings not found", func(t *testing.T) {
+		settings := models.TeamSettings{TeamName: "team-4", MaxReviewers: 1, UnderstaffedPolicy: models.UnderstaffedPolicyFail}
+
+		mock.ExpectExec(updateTeamSettingsQuery).
+			WithArgs(0, 1, models.UnderstaffedPolicyFail, "team-4").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := teamRepo.UpdateTeamSettings(context.Background(), sqlxDB, &settings)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestMergePolicy(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	teamRepo := NewTeamRepositiry()
+
+	t.Run("Get merge policy", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"team_name", "min_approvals", "block_on_changes_requested"}).AddRow("team-1", 2, true)
+		approverRows := sqlmock.NewRows([]string{"user_id"}).AddRow("u1")
+
+		mock.ExpectQuery(getMergePolicyQuery).WithArgs("team-1").WillReturnRows(rows)
+		mock.ExpectQuery(getRequiredApproversQuery).WithArgs("team-1").WillReturnRows(approverRows)
+
+		policy, err := teamRepo.GetMergePolicy(context.Background(), sqlxDB, "team-1")
+		require.NoError(t, err)
+		require.Equal(t, &models.MergePolicy{TeamName: "team-1", MinApprovals: 2, BlockOnChangesRequested: true, RequiredApprovers: []string{"u1"}}, policy)
+	})
+
+	t.Run("Update merge policy", func(t *testing.T) {
+		policy := models.MergePolicy{TeamName: "team-1", MinApprovals: 1, RequiredApprovers: []string{"u1", "u2"}}
+
+		mock.ExpectExec(updateMergePolicyQuery).WithArgs(1, false, "team-1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(deleteRequiredApproversQuery).WithArgs("team-1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(createRequiredApproversQuery).WithArgs("team-1", pq.Array([]string{"u1", "u2"})).WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := teamRepo.UpdateMergePolicy(context.Background(), sqlxDB, &policy)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Update merge policy team not found", func(t *testing.T) {
+		mock.ExpectExec(updateMergePolicyQuery).WithArgs(0, false, "team-4").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := teamRepo.UpdateMergePolicy(context.Background(), sqlxDB, &models.MergePolicy{TeamName: "team-4"})
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestSLAPolicy(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	teamRepo := NewTeamRepositiry()
+
+	t.Run("Get SLA policy", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"team_name", "review_sla_hours", "merge_sla_hours", "sla_escalation"}).AddRow("team-1", 24, 72, "REASSIGN")
+		mock.ExpectQuery(getSLAPolicyQuery).WithArgs("team-1").WillReturnRows(rows)
+
+		policy, err := teamRepo.GetSLAPolicy(context.Background(), sqlxDB, "team-1")
+		require.NoError(t, err)
+		require.Equal(t, &models.SLAPolicy{TeamName: "team-1", ReviewSLAHours: 24, MergeSLAHours: 72, Escalation: models.SLAEscalationReassign}, policy)
+	})
+
+	t.Run("Update SLA policy", func(t *testing.T) {
+		policy := models.SLAPolicy{TeamName: "team-1", ReviewSLAHours: 8, Escalation: models.SLAEscalationAddReviewer}
+		mock.ExpectExec(updateSLAPolicyQuery).WithArgs(8, 0, models.SLAEscalationAddReviewer, "team-1").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := teamRepo.UpdateSLAPolicy(context.Background(), sqlxDB, &policy)
+		require.NoError(t, err)
+	})
+
+	t.Run("Update SLA policy team not found", func(t *testing.T) {
+		mock.ExpectExec(updateSLAPolicyQuery).WithArgs(0, 0, models.SLAEscalationWebhook, "team-4").WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := teamRepo.UpdateSLAPolicy(context.Background(), sqlxDB, &models.SLAPolicy{TeamName: "team-4", Escalation: models.SLAEscalationWebhook})
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		require.NoError(t, mock.ExpectationsWereMet())
 	})
 }
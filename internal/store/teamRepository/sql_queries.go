@@ -6,25 +6,253 @@ const (
 			VALUES ($1)
 	`
 
+	archiveTeamQuery = `
+		UPDATE teams
+			SET archived_at = now()
+		WHERE team_name = $1 AND archived_at IS NULL
+			RETURNING archived_at
+	`
+
 	getTeamWithUsersByNameQuery = `
-		SELECT t.team_name, u.user_id, u.username, u.is_active
+		SELECT t.team_name, u.user_id, u.username, u.is_active, tm.role, tm.is_active, t.archived_at, COALESCE(t.parent_team, '')
 			FROM teams t
-		INNER JOIN users u 
-			ON t.team_name = u.team_name
+		INNER JOIN team_memberships tm
+			ON tm.team_name = t.team_name
+		INNER JOIN users u
+			ON u.user_id = tm.user_id
 		WHERE t.team_name = $1
+		ORDER BY u.user_id
+	`
+
+	createMembershipsQuery = `
+		INSERT INTO team_memberships (user_id, team_name, role, is_active)
+			VALUES %s
+		ON CONFLICT (user_id, team_name) DO NOTHING
+	`
+
+	upsertMembershipsQuery = `
+		INSERT INTO team_memberships (user_id, team_name, role, is_active)
+			VALUES %s
+		ON CONFLICT (user_id, team_name) DO UPDATE
+			SET role = EXCLUDED.role,
+			is_active = EXCLUDED.is_active
+	`
+
+	deleteMembershipQuery = `
+		DELETE FROM team_memberships WHERE team_name = $1 AND user_id = $2
+	`
+
+	getMembershipQuery = `
+		SELECT user_id, team_name, role, is_active
+			FROM team_memberships
+		WHERE team_name = $1 AND user_id = $2
+	`
+
+	updateMembershipQuery = `
+		UPDATE team_memberships
+			SET role = $1,
+			is_active = $2
+		WHERE team_name = $3 AND user_id = $4
+			RETURNING user_id, team_name, role, is_active
+	`
+
+	getTeamSettingsQuery = `
+		SELECT team_name, min_reviewers, max_reviewers, understaffed_policy, archived_at, COALESCE(parent_team, '') AS parent_team
+			FROM teams
+		WHERE team_name = $1
+	`
+
+	setParentTeamQuery = `
+		UPDATE teams
+			SET parent_team = NULLIF($2, '')
+		WHERE team_name = $1
+	`
+
+	lockHierarchyQuery = `
+		SELECT pg_advisory_xact_lock($1)
+	`
+
+	// whether $2 is the team $1 or one of its ancestors
+	hasAncestorQuery = `
+		WITH RECURSIVE ancestors AS (
+			SELECT team_name, parent_team
+				FROM teams
+			WHERE team_name = $1
+			UNION
+			SELECT t.team_name, t.parent_team
+				FROM teams t
+			JOIN ancestors a
+				ON t.team_name = a.parent_team
+		)
+		SELECT EXISTS(SELECT 1 FROM ancestors WHERE team_name = $2)
+	`
+
+	// team $1 and its descendants with their members, team without members has one row without member
+	getSubtreeQuery = `
+		WITH RECURSIVE subtree AS (
+			SELECT team_name
+				FROM teams
+			WHERE team_name = $1
+			UNION
+			SELECT t.team_name
+				FROM teams t
+			JOIN subtree s
+				ON t.parent_team = s.team_name
+		)
+		SELECT t.team_name, COALESCE(t.parent_team, ''), t.archived_at,
+			u.user_id, u.username, u.team_name, u.is_active, tm.role, tm.is_active
+			FROM subtree s
+		JOIN teams t
+			ON t.team_name = s.team_name
+		LEFT JOIN team_memberships tm
+			ON tm.team_name = t.team_name
+		LEFT JOIN users u
+			ON u.user_id = tm.user_id
+		ORDER BY t.team_name, u.user_id
+	`
+
+	getTeamFallbacksQuery = `
+		SELECT fallback_team_name
+			FROM team_fallbacks
+		WHERE team_name = $1
+		ORDER BY position
+	`
+
+	updateTeamSettingsQuery = `
+		UPDATE teams
+			SET min_reviewers = $1,
+			max_reviewers = $2,
+			understaffed_policy = $3
+		WHERE team_name = $4
+	`
+
+	deleteTeamFallbacksQuery = `
+		DELETE FROM team_fallbacks WHERE team_name = $1
+	`
+
+	createTeamFallbacksQuery = `
+		INSERT INTO team_fallbacks (team_name, fallback_team_name, position)
+			VALUES %s
+	`
+
+	getMergePolicyQuery = `
+		SELECT team_name, min_approvals, block_on_changes_requested
+			FROM teams
+		WHERE team_name = $1
+	`
+
+	getRequiredApproversQuery = `
+		SELECT user_id
+			FROM team_required_approvers
+		WHERE team_name = $1
+		ORDER BY user_id
+	`
+
+	updateMergePolicyQuery = `
+		UPDATE teams
+			SET min_approvals = $1,
+			block_on_changes_requested = $2
+		WHERE team_name = $3
+	`
+
+	deleteRequiredApproversQuery = `
+		DELETE FROM team_required_approvers WHERE team_name = $1
+	`
+
+	createRequiredApproversQuery = `
+		INSERT INTO team_required_approvers (team_name, user_id)
+			SELECT $1, unnest($2::text[])
+		ON CONFLICT (team_name, user_id) DO NOTHING
+	`
+
+	getSLAPolicyQuery = `
+		SELECT team_name, review_sla_hours, merge_sla_hours, sla_escalation
+			FROM teams
+		WHERE team_name = $1
+	`
+
+	updateSLAPolicyQuery = `
+		UPDATE teams
+			SET review_sla_hours = $1,
+			merge_sla_hours = $2,
+			sla_escalation = $3
+		WHERE team_name = $4
+	`
+
+	// review load of candidate u: quantity of OPEN PRs user is reviewing and user skills,
+	// user is unavailable during unavailability periods and on days off of the working schedule
+	reviewCandidateLoad = `
+			u.max_open_reviews, COUNT(pr.pull_request_id) AS open_reviews,
+			ARRAY(SELECT s.skill FROM user_skills s WHERE s.user_id = u.user_id ORDER BY s.skill) AS skills,
+			(
+				EXISTS(
+					SELECT 1 FROM user_unavailability ua
+					WHERE ua.user_id = u.user_id AND ua.starts_at <= now() AND ua.ends_at > now()
+				)
+				OR EXISTS(
+					SELECT 1 FROM user_schedules us
+					WHERE us.user_id = u.user_id
+						AND NOT EXTRACT(ISODOW FROM now() AT TIME ZONE us.timezone)::int = ANY(us.working_days)
+				)
+			) AS unavailable
+	`
+
+	reviewCandidateOpenReviews = `
+		LEFT JOIN assigned_reviewers ar
+			ON ar.reviewer_user_id = u.user_id
+		LEFT JOIN pull_requests pr
+			ON pr.pull_request_id = ar.pull_request_id AND pr.status = 'OPEN'
+	`
+
+	// members of the team, member is active while both user and membership are active,
+	// archived team has no candidates
+	getTeamReviewCandidatesQuery = `
+		SELECT u.user_id, tm.team_name, u.is_active AND tm.is_active AS is_active,` + reviewCandidateLoad + `
+			FROM team_memberships tm
+		JOIN teams t
+			ON t.team_name = tm.team_name AND t.archived_at IS NULL
+		JOIN users u
+			ON u.user_id = tm.user_id` + reviewCandidateOpenReviews + `
+		WHERE tm.team_name = $1
+		GROUP BY u.user_id, tm.user_id, tm.team_name
+		ORDER BY u.user_id
 	`
 
-	getActiveUserIDFromUserTeamQuery = `
-		SELECT (user_id) FROM users 
-			WHERE team_name IN (
-				SELECT team_name from users WHERE user_id = $1
-			) AND (user_id = $1 OR is_active = true) LIMIT $2
+	// members of not archived teams of the subtree of the team, member of several of them
+	// is active while the user and any of the memberships are active
+	getSubtreeReviewCandidatesQuery = `
+		WITH RECURSIVE subtree AS (
+			SELECT team_name
+				FROM teams
+			WHERE team_name = $1
+			UNION
+			SELECT t.team_name
+				FROM teams t
+			JOIN subtree s
+				ON t.parent_team = s.team_name
+		), members AS (
+			SELECT tm.user_id, MIN(tm.team_name) AS team_name, bool_or(tm.is_active) AS is_active
+				FROM subtree s
+			JOIN teams t
+				ON t.team_name = s.team_name AND t.archived_at IS NULL
+			JOIN team_memberships tm
+				ON tm.team_name = t.team_name
+			GROUP BY tm.user_id
+		)
+		SELECT u.user_id, m.team_name, u.is_active AND m.is_active AS is_active,` + reviewCandidateLoad + `
+			FROM members m
+		JOIN users u
+			ON u.user_id = m.user_id` + reviewCandidateOpenReviews + `
+		GROUP BY u.user_id, m.team_name, m.is_active
+		ORDER BY u.user_id
 	`
 
-	getActiveUserFromUserTeamWithException = `
-		SELECT (user_id) FROM users 
-			WHERE team_name IN (
-				SELECT team_name from users WHERE user_id = $1
-			) AND (user_id = $1 OR is_active = true) AND user_id NOT IN (%s) LIMIT $2
+	// users with their primary teams
+	getReviewCandidatesByUserIDsQuery = `
+		SELECT u.user_id, u.team_name, u.is_active,` + reviewCandidateLoad + `
+			FROM users u` + reviewCandidateOpenReviews + `
+		WHERE u.user_id = ANY($1)
+		GROUP BY u.user_id
+		ORDER BY u.user_id
 	`
 )
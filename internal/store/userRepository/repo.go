@@ -3,9 +3,11 @@ package userrepository
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"github.com/Negat1v9/pr-review-service/internal/models"
 )
@@ -26,7 +28,32 @@ func (r *userRepository) CreateManyUsers(ctx context.Context, exec sqlx.ExtConte
 	if len(users) == 0 {
 		return fmt.Errorf("userRepository.CreateManyUsers: no users")
 	}
+	return r.insertUsers(ctx, exec, createManyUsersQuery, teamName, users)
+}
+
+// creates new users with the primary team and updates name and status of existing users of the team,
+// returns ids of created and updated users
+func (r *userRepository) UpsertUsers(ctx context.Context, exec sqlx.ExtContext, teamName string, users []models.User) ([]string, error) {
+	userIDs := make([]string, 0, len(users))
+	if len(users) == 0 {
+		return userIDs, nil
+	}
 
+	query, args := usersInsert(upsertUsersQuery, teamName, users)
+	if err := sqlx.SelectContext(ctx, exec, &userIDs, query, args...); err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
+
+func (r *userRepository) insertUsers(ctx context.Context, exec sqlx.ExtContext, query, teamName string, users []models.User) error {
+	query, args := usersInsert(query, teamName, users)
+	_, err := exec.ExecContext(ctx, query, args...)
+	return err
+}
+
+// usersInsert fills VALUES of the query with the users of the primary team
+func usersInsert(query, teamName string, users []models.User) (string, []any) {
 	var placeholders []string
 	var args []any
 
@@ -36,14 +63,122 @@ func (r *userRepository) CreateManyUsers(ctx context.Context, exec sqlx.ExtConte
 		args = append(args, user.UserID, user.Username, user.IsActive, teamName)
 	}
 
-	query := fmt.Sprintf(createManyUsersQuery, strings.Join(placeholders, ","))
+	return fmt.Sprintf(query, strings.Join(placeholders, ",")), args
+}
 
-	_, err := exec.ExecContext(ctx, query, args...)
-	if err != nil {
+func (r *userRepository) GetUserByID(ctx context.Context, exec sqlx.ExtContext, userID string) (*models.User, error) {
+	var user models.User
+	if err := exec.QueryRowxContext(ctx, getUserByIDQuery, userID).StructScan(&user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) ListUsers(ctx context.Context, exec sqlx.ExtContext, filter *models.UserFilter) ([]models.User, error) {
+	conditions := make([]string, 0)
+	args := make([]any, 0)
+	where := func(condition string, values ...any) {
+		placeholders := make([]any, 0, len(values))
+		for _, value := range values {
+			args = append(args, value)
+			placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+		}
+		conditions = append(conditions, fmt.Sprintf(condition, placeholders...))
+	}
+
+	if filter.TeamName != "" {
+		where("EXISTS (SELECT 1 FROM team_memberships tm WHERE tm.user_id = u.user_id AND tm.team_name = %s)", filter.TeamName)
+	}
+	if filter.IsActive != nil {
+		where("u.is_active = %s", *filter.IsActive)
+	}
+	if filter.NamePrefix != "" {
+		where("(lower(u.username) LIKE %[1]s OR lower(u.display_name) LIKE %[1]s)", likePrefix(filter.NamePrefix))
+	}
+	if filter.After != "" {
+		where("u.user_id > %s", filter.After)
+	}
+	if len(conditions) == 0 {
+		conditions = append(conditions, "TRUE")
+	}
+
+	args = append(args, filter.Limit)
+	query := fmt.Sprintf(listUsersQuery, strings.Join(conditions, " AND "), fmt.Sprintf("$%d", len(args)))
+
+	users := make([]models.User, 0)
+	if err := sqlx.SelectContext(ctx, exec, &users, query, args...); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// likePrefix returns lower case LIKE pattern matching strings which start with the prefix
+func likePrefix(prefix string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return escaper.Replace(strings.ToLower(prefix)) + "%"
+}
+
+func (r *userRepository) UpdateUserProfile(ctx context.Context, exec sqlx.ExtContext, req *models.UpdateUserRequest) (*models.User, error) {
+	var updatedUser models.User
+	if err := exec.QueryRowxContext(ctx, updateUserProfileQuery, req.UserID, req.Username, req.Email, req.DisplayName).StructScan(&updatedUser); err != nil {
+		return nil, err
+	}
+	return &updatedUser, nil
+}
+
+func (r *userRepository) GetUsersForgeLogins(ctx context.Context, exec sqlx.ExtContext, userIDs []string) (map[string]map[string]string, error) {
+	logins := make([]models.UserForgeLogin, 0)
+	if err := sqlx.SelectContext(ctx, exec, &logins, getUsersForgeLoginsQuery, pq.Array(userIDs)); err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]map[string]string)
+	for _, login := range logins {
+		if res[login.UserID] == nil {
+			res[login.UserID] = make(map[string]string)
+		}
+		res[login.UserID][login.Forge] = login.Login
+	}
+	return res, nil
+}
+
+func (r *userRepository) GetTakenForgeLogins(ctx context.Context, exec sqlx.ExtContext, userID string, logins map[string]string) ([]string, error) {
+	forges, values := splitForgeLogins(logins)
+
+	taken := make([]string, 0)
+	if err := sqlx.SelectContext(ctx, exec, &taken, getTakenForgeLoginsQuery, userID, pq.Array(forges), pq.Array(values)); err != nil {
+		return nil, err
+	}
+	return taken, nil
+}
+
+func (r *userRepository) SetUserForgeLogins(ctx context.Context, exec sqlx.ExtContext, userID string, logins map[string]string) error {
+	if _, err := exec.ExecContext(ctx, deleteUserForgeLoginsQuery, userID); err != nil {
 		return err
 	}
 
-	return nil
+	if len(logins) == 0 {
+		return nil
+	}
+
+	forges, values := splitForgeLogins(logins)
+	_, err := exec.ExecContext(ctx, createUserForgeLoginsQuery, userID, pq.Array(forges), pq.Array(values))
+	return err
+}
+
+// splitForgeLogins returns forges in sorted order and their logins
+func splitForgeLogins(logins map[string]string) ([]string, []string) {
+	forges := make([]string, 0, len(logins))
+	for forge := range logins {
+		forges = append(forges, forge)
+	}
+	sort.Strings(forges)
+
+	values := make([]string, 0, len(forges))
+	for _, forge := range forges {
+		values = append(values, logins[forge])
+	}
+	return forges, values
 }
 
 func (r *userRepository) GetUserReviews(ctx context.Context, exec sqlx.ExtContext, userID string) (*models.UserReviews, error) {
@@ -74,8 +209,45 @@ func (r *userRepository) GetUserReviews(ctx context.Context, exec sqlx.ExtContex
 	return userReviews, nil
 }
 
+func (r *userRepository) UpdateUserReviewLimit(ctx context.Context, exec sqlx.ExtContext, userID string, maxOpenReviews *int) (*models.User, error) {
+	var updatedUser models.User
+	if err := exec.QueryRowxContext(ctx, updateUserReviewLimitQuery, maxOpenReviews, userID).StructScan(&updatedUser); err != nil {
+		return nil, err
+	}
+	return &updatedUser, nil
+}
+
 func (r *userRepository) UpdateUserStatus(ctx context.Context, exec sqlx.ExtContext, userID string, isActive bool) (*models.User, error) {
 	var updatedUser models.User
 	err := exec.QueryRowxContext(ctx, updateUserStatusQuery, isActive, userID).StructScan(&updatedUser)
 	return &updatedUser, err
 }
+
+func (r *userRepository) DeactivateTeamUsers(ctx context.Context, exec sqlx.ExtContext, teamName string, userIDs []string) ([]models.User, error) {
+	users := make([]models.User, 0, len(userIDs))
+	if err := sqlx.SelectContext(ctx, exec, &users, deactivateTeamUsersQuery, teamName, pq.Array(userIDs)); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *userRepository) GetUserSkills(ctx context.Context, exec sqlx.ExtContext, userID string) ([]string, error) {
+	skills := make([]string, 0)
+	if err := sqlx.SelectContext(ctx, exec, &skills, getUserSkillsQuery, userID); err != nil {
+		return nil, err
+	}
+	return skills, nil
+}
+
+func (r *userRepository) SetUserSkills(ctx context.Context, exec sqlx.ExtContext, userID string, skills []string) error {
+	if _, err := exec.ExecContext(ctx, deleteUserSkillsQuery, userID); err != nil {
+		return err
+	}
+
+	if len(skills) == 0 {
+		return nil
+	}
+
+	_, err := exec.ExecContext(ctx, createUserSkillsQuery, userID, pq.Array(skills))
+	return err
+}
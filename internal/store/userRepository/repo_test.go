@@ -11,6 +11,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Negat1v9/pr-review-service/internal/models"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -75,6 +76,56 @@ func TestCreateManyUsers(t *testing.T) {
 
 		require.NoError(t, err)
 	})
+
+	t.Run("Upsert users", func(t *testing.T) {
+		users := []models.User{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: false},
+		}
+
+		query := fmt.Sprintf(upsertUsersQuery, "($1, $2, $3, $4),($5, $6, $7, $8)")
+		// u2 has another primary team and is not changed
+		mock.ExpectQuery(query).
+			WithArgs("u1", "Alice", true, "payment", "u2", "Bob", false, "payment").
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow("u1"))
+
+		userIDs, err := userRepo.UpsertUsers(context.Background(), sqlxDB, "payment", users)
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"u1"}, userIDs)
+	})
+}
+
+func TestGetUserByID(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	userRepo := NewUserRepository()
+
+	t.Run("Get user", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"user_id", "username", "team_name", "is_active"}).
+			AddRow("u1", "Alice", "payment", true)
+
+		mock.ExpectQuery(getUserByIDQuery).WithArgs("u1").WillReturnRows(rows)
+
+		user, err := userRepo.GetUserByID(context.Background(), sqlxDB, "u1")
+
+		require.NoError(t, err)
+		require.Equal(t, "payment", user.TeamName)
+	})
+
+	t.Run("Get user not found", func(t *testing.T) {
+		mock.ExpectQuery(getUserByIDQuery).WithArgs("nonexistent").WillReturnError(sql.ErrNoRows)
+
+		user, err := userRepo.GetUserByID(context.Background(), sqlxDB, "nonexistent")
+
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		require.Nil(t, user)
+	})
 }
 
 func TestGetUserReviews(t *testing.T) {
@@ -107,6 +158,51 @@ func TestGetUserReviews(t *testing.T) {
 	})
 }
 
+func TestUpdateUserReviewLimit(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	userRepo := NewUserRepository()
+
+	t.Run("Set limit", func(t *testing.T) {
+		limit := 3
+		rows := sqlmock.NewRows([]string{"user_id", "username", "team_name", "is_active", "max_open_reviews"}).
+			AddRow("u1", "u1", "pay", true, 3)
+
+		mock.ExpectQuery(updateUserReviewLimitQuery).WithArgs(&limit, "u1").WillReturnRows(rows)
+
+		updatedUser, err := userRepo.UpdateUserReviewLimit(context.Background(), sqlxDB, "u1", &limit)
+
+		require.NoError(t, err)
+		require.Equal(t, 3, *updatedUser.MaxOpenReviews)
+	})
+
+	t.Run("Remove limit", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"user_id", "username", "team_name", "is_active", "max_open_reviews"}).
+			AddRow("u1", "u1", "pay", true, nil)
+
+		mock.ExpectQuery(updateUserReviewLimitQuery).WithArgs(nil, "u1").WillReturnRows(rows)
+
+		updatedUser, err := userRepo.UpdateUserReviewLimit(context.Background(), sqlxDB, "u1", nil)
+
+		require.NoError(t, err)
+		require.Nil(t, updatedUser.MaxOpenReviews)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mock.ExpectQuery(updateUserReviewLimitQuery).WithArgs(nil, "nonexistent").WillReturnError(sql.ErrNoRows)
+
+		updatedUser, err := userRepo.UpdateUserReviewLimit(context.Background(), sqlxDB, "nonexistent", nil)
+
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		require.Nil(t, updatedUser)
+	})
+}
+
 func TestUpdateUserStatus(t *testing.T) {
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	require.NoError(t, err)
@@ -142,3 +238,188 @@ func TestUpdateUserStatus(t *testing.T) {
 		require.NotNil(t, updatedUser)
 	})
 }
+
+func TestUserSkills(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	userRepo := NewUserRepository()
+
+	t.Run("Set skills", func(t *testing.T) {
+		skills := []string{"go", "sql"}
+		mock.ExpectExec(deleteUserSkillsQuery).WithArgs("u1").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(createUserSkillsQuery).WithArgs("u1", pq.Array(skills)).WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err = userRepo.SetUserSkills(context.Background(), sqlxDB, "u1", skills)
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Get skills", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"skill"}).AddRow("go").AddRow("sql")
+		mock.ExpectQuery(getUserSkillsQuery).WithArgs("u1").WillReturnRows(rows)
+
+		skills, err := userRepo.GetUserSkills(context.Background(), sqlxDB, "u1")
+
+		require.NoError(t, err)
+		require.Equal(t, []string{"go", "sql"}, skills)
+	})
+}
+
+func TestDeactivateTeamUsers(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	userRepo := NewUserRepository()
+
+	t.Run("Deactivate", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"user_id", "username", "team_name", "is_active", "max_open_reviews"}).
+			AddRow("user-1", "user-1", "backend", false, nil).
+			AddRow("user-2", "user-2", "backend", false, 3)
+		mock.ExpectQuery(deactivateTeamUsersQuery).WithArgs("backend", pq.Array([]string{"user-1", "user-2"})).WillReturnRows(rows)
+
+		users, err := userRepo.DeactivateTeamUsers(context.Background(), sqlxDB, "backend", []string{"user-1", "user-2"})
+
+		require.NoError(t, err)
+		require.Equal(t, 2, len(users))
+		require.False(t, users[0].IsActive)
+		require.Equal(t, 3, *users[1].MaxOpenReviews)
+	})
+}
+
+func TestListUsers(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	userRepo := NewUserRepository()
+	columns := []string{"user_id", "username", "team_name", "is_active", "max_open_reviews", "email", "display_name"}
+
+	t.Run("List all users", func(t *testing.T) {
+		query := fmt.Sprintf(listUsersQuery, "TRUE", "$1")
+		mock.ExpectQuery(query).WithArgs(3).WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("u1", "alice", "backend", true, nil, "alice@example.com", "Alice"))
+
+		users, err := userRepo.ListUsers(context.Background(), sqlxDB, &models.UserFilter{Limit: 3})
+
+		require.NoError(t, err)
+		require.Equal(t, "alice@example.com", users[0].Email)
+	})
+
+	t.Run("List users by filter", func(t *testing.T) {
+		active := true
+		conditions := []string{
+			"EXISTS (SELECT 1 FROM team_memberships tm WHERE tm.user_id = u.user_id AND tm.team_name = $1)",
+			"u.is_active = $2",
+			"(lower(u.username) LIKE $3 OR lower(u.display_name) LIKE $3)",
+			"u.user_id > $4",
+		}
+		query := fmt.Sprintf(listUsersQuery, strings.Join(conditions, " AND "), "$5")
+		mock.ExpectQuery(query).WithArgs("backend", true, `a\_b%`, "u1", 21).WillReturnRows(sqlmock.NewRows(columns))
+
+		users, err := userRepo.ListUsers(context.Background(), sqlxDB, &models.UserFilter{
+			TeamName:   "backend",
+			IsActive:   &active,
+			NamePrefix: "A_b",
+			After:      "u1",
+			Limit:      21,
+		})
+
+		require.NoError(t, err)
+		require.Empty(t, users)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateUserProfile(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	userRepo := NewUserRepository()
+
+	t.Run("Update profile", func(t *testing.T) {
+		email := ""
+		name := "Alice L."
+		rows := sqlmock.NewRows([]string{"user_id", "username", "team_name", "is_active", "max_open_reviews", "email", "display_name"}).
+			AddRow("u1", "alice", "backend", true, nil, "", name)
+		mock.ExpectQuery(updateUserProfileQuery).WithArgs("u1", nil, &email, &name).WillReturnRows(rows)
+
+		user, err := userRepo.UpdateUserProfile(context.Background(), sqlxDB, &models.UpdateUserRequest{UserID: "u1", Email: &email, DisplayName: &name})
+
+		require.NoError(t, err)
+		require.Equal(t, name, user.DisplayName)
+	})
+
+	t.Run("Update unknown user", func(t *testing.T) {
+		mock.ExpectQuery(updateUserProfileQuery).WithArgs("unknown", nil, nil, nil).WillReturnError(sql.ErrNoRows)
+
+		_, err := userRepo.UpdateUserProfile(context.Background(), sqlxDB, &models.UpdateUserRequest{UserID: "unknown"})
+
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestUserForgeLogins(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	userRepo := NewUserRepository()
+	logins := map[string]string{"gitlab": "alice-l", "github": "alice"}
+
+	t.Run("Set forge logins", func(t *testing.T) {
+		mock.ExpectExec(deleteUserForgeLoginsQuery).WithArgs("u1").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(createUserForgeLoginsQuery).
+			WithArgs("u1", pq.Array([]string{"github", "gitlab"}), pq.Array([]string{"alice", "alice-l"})).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := userRepo.SetUserForgeLogins(context.Background(), sqlxDB, "u1", logins)
+		require.NoError(t, err)
+	})
+
+	t.Run("Taken forge logins", func(t *testing.T) {
+		mock.ExpectQuery(getTakenForgeLoginsQuery).
+			WithArgs("u1", pq.Array([]string{"github", "gitlab"}), pq.Array([]string{"alice", "alice-l"})).
+			WillReturnRows(sqlmock.NewRows([]string{"forge"}).AddRow("github"))
+
+		taken, err := userRepo.GetTakenForgeLogins(context.Background(), sqlxDB, "u1", logins)
+		require.NoError(t, err)
+		require.Equal(t, []string{"github"}, taken)
+	})
+
+	t.Run("Get forge logins of users", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"user_id", "forge", "login"}).
+			AddRow("u1", "github", "alice").
+			AddRow("u1", "gitlab", "alice-l").
+			AddRow("u2", "github", "bob")
+		mock.ExpectQuery(getUsersForgeLoginsQuery).WithArgs(pq.Array([]string{"u1", "u2", "u3"})).WillReturnRows(rows)
+
+		res, err := userRepo.GetUsersForgeLogins(context.Background(), sqlxDB, []string{"u1", "u2", "u3"})
+		require.NoError(t, err)
+		require.Equal(t, logins, res["u1"])
+		require.Equal(t, map[string]string{"github": "bob"}, res["u2"])
+		require.NotContains(t, res, "u3")
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
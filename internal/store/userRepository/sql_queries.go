@@ -8,6 +8,42 @@ const (
 	createManyUsersQuery = `
 		INSERT INTO users (user_id, username, is_active, team_name)
 			VALUES %s
+		ON CONFLICT (user_id) DO NOTHING
+	`
+	upsertUsersQuery = `
+		INSERT INTO users (user_id, username, is_active, team_name)
+			VALUES %s
+		ON CONFLICT (user_id) DO UPDATE
+			SET username = EXCLUDED.username,
+			is_active = EXCLUDED.is_active
+		WHERE users.team_name = EXCLUDED.team_name
+			RETURNING user_id
+	`
+	getUserByIDQuery = `
+		SELECT user_id, username, team_name, is_active, max_open_reviews,
+			COALESCE(email, '') AS email, COALESCE(display_name, '') AS display_name
+			FROM users
+		WHERE user_id = $1
+	`
+
+	listUsersQuery = `
+		SELECT u.user_id, u.username, u.team_name, u.is_active, u.max_open_reviews,
+			COALESCE(u.email, '') AS email, COALESCE(u.display_name, '') AS display_name
+			FROM users u
+		WHERE %s
+		ORDER BY u.user_id
+		LIMIT %s
+	`
+
+	// nil arguments keep the values, empty email and display name are removed
+	updateUserProfileQuery = `
+		UPDATE users
+			SET username = COALESCE($2, username),
+			email = CASE WHEN $3::text IS NULL THEN email ELSE NULLIF($3::text, '') END,
+			display_name = CASE WHEN $4::text IS NULL THEN display_name ELSE NULLIF($4::text, '') END
+		WHERE user_id = $1
+			RETURNING user_id, username, team_name, is_active, max_open_reviews,
+			COALESCE(email, '') AS email, COALESCE(display_name, '') AS display_name
 	`
 	getUserReviewsQuery = `
 		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at 
@@ -22,6 +58,83 @@ const (
 		UPDATE users 
 			SET is_active = $1 
 		WHERE user_id = $2
-			RETURNING *
+			RETURNING user_id, username, team_name, is_active, max_open_reviews
+	`
+
+	// users of the primary team are deactivated, other members only in the team,
+	// statements of the query see users before the update, so is_active is computed
+	deactivateTeamUsersQuery = `
+		WITH members AS (
+			SELECT u.user_id, u.team_name = $1 AS is_primary
+				FROM users u
+			JOIN team_memberships tm
+				ON tm.user_id = u.user_id AND tm.team_name = $1
+			WHERE u.user_id = ANY($2)
+		), deactivated_memberships AS (
+			UPDATE team_memberships tm
+				SET is_active = false
+			FROM members m
+			WHERE tm.user_id = m.user_id AND tm.team_name = $1 AND NOT m.is_primary
+		), deactivated_users AS (
+			UPDATE users u
+				SET is_active = false
+			FROM members m
+			WHERE u.user_id = m.user_id AND m.is_primary
+		)
+		SELECT u.user_id, u.username, u.team_name, u.is_active AND NOT m.is_primary AS is_active, u.max_open_reviews
+			FROM users u
+		JOIN members m
+			ON m.user_id = u.user_id
+		ORDER BY u.user_id
+	`
+
+	updateUserReviewLimitQuery = `
+		UPDATE users
+			SET max_open_reviews = $1
+		WHERE user_id = $2
+			RETURNING user_id, username, team_name, is_active, max_open_reviews
+	`
+
+	getUserSkillsQuery = `
+		SELECT skill
+			FROM user_skills
+		WHERE user_id = $1
+		ORDER BY skill
+	`
+
+	deleteUserSkillsQuery = `
+		DELETE FROM user_skills WHERE user_id = $1
+	`
+
+	createUserSkillsQuery = `
+		INSERT INTO user_skills (user_id, skill)
+			SELECT $1, unnest($2::text[])
+		ON CONFLICT (user_id, skill) DO NOTHING
+	`
+
+	getUsersForgeLoginsQuery = `
+		SELECT user_id, forge, login
+			FROM user_forge_logins
+		WHERE user_id = ANY($1)
+		ORDER BY user_id, forge
+	`
+
+	// forges where the logins belong to other users than $1
+	getTakenForgeLoginsQuery = `
+		SELECT f.forge
+			FROM user_forge_logins f
+		JOIN unnest($2::text[], $3::text[]) AS l(forge, login)
+			ON l.forge = f.forge AND l.login = f.login
+		WHERE f.user_id <> $1
+		ORDER BY f.forge
+	`
+
+	deleteUserForgeLoginsQuery = `
+		DELETE FROM user_forge_logins WHERE user_id = $1
+	`
+
+	createUserForgeLoginsQuery = `
+		INSERT INTO user_forge_logins (user_id, forge, login)
+			SELECT $1, unnest($2::text[]), unnest($3::text[])
 	`
 )
@@ -63,3 +63,305 @@ func (h *TeamHanler) Get(w http.ResponseWriter, r *http.Request) {
 
 	utils.WriteJsonResponse(w, http.StatusOK, "", team)
 }
+
+func (h *TeamHanler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		utils.WriteErrResponse(w, utils.NewNotFoundError("resource not found", nil))
+		return
+	}
+
+	settings, err := h.service.GetSettings(ctx, teamName)
+	if err != nil {
+		h.log.Errorf("failed to get team settings: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "settings", settings)
+}
+
+func (h *TeamHanler) SetSettings(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	var settings models.TeamSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	updated, err := h.service.SetSettings(ctx, &settings)
+	if err != nil {
+		h.log.Errorf("failed to set team settings: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "settings", updated)
+}
+
+func (h *TeamHanler) DeactivateUsers(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	var req models.DeactivateTeamUsersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	res, err := h.service.DeactivateUsers(ctx, &req)
+	if err != nil {
+		h.log.Errorf("failed to deactivate team users: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "", res)
+}
+
+func (h *TeamHanler) SetMembership(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	var req models.SetMembershipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	membership, err := h.service.SetMembership(ctx, &req)
+	if err != nil {
+		h.log.Errorf("failed to set team membership: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "membership", membership)
+}
+
+func (h *TeamHanler) Upsert(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	var team models.Team
+	if err := json.NewDecoder(r.Body).Decode(&team); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	res, err := h.service.UpsertTeam(ctx, &team)
+	if err != nil {
+		h.log.Errorf("failed to upsert team: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "", res)
+}
+
+func (h *TeamHanler) AddMember(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	var req models.AddTeamMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	team, err := h.service.AddMember(ctx, &req)
+	if err != nil {
+		h.log.Errorf("failed to add team member: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "team", team)
+}
+
+func (h *TeamHanler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	var req models.RemoveTeamMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	res, err := h.service.RemoveMember(ctx, &req)
+	if err != nil {
+		h.log.Errorf("failed to remove team member: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "", res)
+}
+
+func (h *TeamHanler) GetMergePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		utils.WriteErrResponse(w, utils.NewNotFoundError("resource not found", nil))
+		return
+	}
+
+	policy, err := h.service.GetMergePolicy(ctx, teamName)
+	if err != nil {
+		h.log.Errorf("failed to get team merge policy: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "policy", policy)
+}
+
+func (h *TeamHanler) SetMergePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	var policy models.MergePolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	updated, err := h.service.SetMergePolicy(ctx, &policy)
+	if err != nil {
+		h.log.Errorf("failed to set team merge policy: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "policy", updated)
+}
+
+func (h *TeamHanler) GetSLAPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		utils.WriteErrResponse(w, utils.NewNotFoundError("resource not found", nil))
+		return
+	}
+
+	policy, err := h.service.GetSLAPolicy(ctx, teamName)
+	if err != nil {
+		h.log.Errorf("failed to get team SLA policy: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "policy", policy)
+}
+
+func (h *TeamHanler) SetSLAPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	var policy models.SLAPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	updated, err := h.service.SetSLAPolicy(ctx, &policy)
+	if err != nil {
+		h.log.Errorf("failed to set team SLA policy: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "policy", updated)
+}
+
+func (h *TeamHanler) Archive(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	query := r.URL.Query()
+	req := models.ArchiveTeamRequest{
+		TeamName:      query.Get("team_name"),
+		Policy:        models.TeamArchivePolicy(query.Get("policy")),
+		SuccessorTeam: query.Get("successor_team"),
+	}
+
+	res, err := h.service.ArchiveTeam(ctx, &req)
+	if err != nil {
+		h.log.Errorf("failed to archive team: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "", res)
+}
+
+func (h *TeamHanler) SetParent(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	var req models.SetParentTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	settings, err := h.service.SetParent(ctx, &req)
+	if err != nil {
+		h.log.Errorf("failed to set parent team: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "settings", settings)
+}
+
+func (h *TeamHanler) GetSubtree(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		utils.WriteErrResponse(w, utils.NewNotFoundError("resource not found", nil))
+		return
+	}
+
+	subtree, err := h.service.GetSubtree(ctx, teamName)
+	if err != nil {
+		h.log.Errorf("failed to get team subtree: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "", subtree)
+}
+
+func (h *TeamHanler) Statistics(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	query := r.URL.Query()
+	teamName := query.Get("team_name")
+	if teamName == "" {
+		utils.WriteErrResponse(w, utils.NewNotFoundError("resource not found", nil))
+		return
+	}
+
+	stat, err := h.service.Statistics(ctx, teamName, query.Get("repository"))
+	if err != nil {
+		h.log.Errorf("failed to get team statistics: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "stat", stat)
+}
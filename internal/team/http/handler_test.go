@@ -8,8 +8,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/Negat1v9/pr-review-service/config"
 	"github.com/Negat1v9/pr-review-service/internal/models"
+	prservice "github.com/Negat1v9/pr-review-service/internal/pullRequest/service"
 	mock_store "github.com/Negat1v9/pr-review-service/internal/store/mock"
 	teamservice "github.com/Negat1v9/pr-review-service/internal/team/service"
 	"github.com/Negat1v9/pr-review-service/pkg/logger"
@@ -33,7 +36,7 @@ func TestAdd(t *testing.T) {
 	mockStore.EXPECT().DB().Return(&db).AnyTimes()
 
 	doReq := func(body any) *httptest.ResponseRecorder {
-		service := teamservice.NewTeamService(mockStore)
+		service := teamservice.NewTeamService(mockStore, nil)
 		handler := NewTeamHanlder(logger.NewLogger("local"), service)
 		teamMux := TeamRouter(handler)
 
@@ -68,6 +71,7 @@ func TestAdd(t *testing.T) {
 
 		mockTeamRepo.EXPECT().CreateTeam(gomock.Any(), gomock.Any(), "bb").Return(nil)
 		mockUserRepo.EXPECT().CreateManyUsers(gomock.Any(), gomock.Any(), "bb", newTeam.Members).Return(nil)
+		mockTeamRepo.EXPECT().CreateMemberships(gomock.Any(), gomock.Any(), "bb", newTeam.Members).Return(nil)
 		mockTeamRepo.EXPECT().GetTeamWithMembers(gomock.Any(), gomock.Any(), "bb").Return(&newTeam, nil)
 
 		rr := doReq(newTeam)
@@ -81,6 +85,14 @@ func TestAdd(t *testing.T) {
 
 	})
 
+	t.Run("Add team with unknown role", func(t *testing.T) {
+		rr := doReq(models.Team{
+			TeamName: "bb",
+			Members:  []models.User{{UserID: "u1", Username: "u1", Role: "OWNER"}},
+		})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
 	t.Run("Add team already exists", func(t *testing.T) {
 		newTeam := models.Team{
 			TeamName: "bb",
@@ -116,7 +128,7 @@ func TestGet(t *testing.T) {
 	mockStore.EXPECT().DB().Return(&db).AnyTimes()
 
 	doReq := func(teamName string) *httptest.ResponseRecorder {
-		service := teamservice.NewTeamService(mockStore)
+		service := teamservice.NewTeamService(mockStore, nil)
 		handler := NewTeamHanlder(logger.NewLogger("local"), service)
 		teamMux := TeamRouter(handler)
 
@@ -169,3 +181,1115 @@ func TestGet(t *testing.T) {
 		require.Equal(t, "resource not found", r["error"].(map[string]any)["message"])
 	})
 }
+
+func TestSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+
+	doReq := func(method, path string, body any) *httptest.ResponseRecorder {
+		service := teamservice.NewTeamService(mockStore, nil)
+		handler := NewTeamHanlder(logger.NewLogger("local"), service)
+		teamMux := TeamRouter(handler)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest(method, path, bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		teamMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Get settings", func(t *testing.T) {
+		settings := &models.TeamSettings{TeamName: "bb", MinReviewers: 0, MaxReviewers: 2, UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer}
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "bb").Return(settings, nil)
+
+		rr := doReq("GET", "/getSettings?team_name=bb", nil)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, float64(2), r["settings"].(map[string]any)["max_reviewers"])
+		require.Equal(t, "ASSIGN_FEWER", r["settings"].(map[string]any)["understaffed_policy"])
+	})
+
+	t.Run("Set settings", func(t *testing.T) {
+		settings := models.TeamSettings{
+			TeamName:           "platform",
+			MinReviewers:       2,
+			MaxReviewers:       3,
+			UnderstaffedPolicy: models.UnderstaffedPolicyFallback,
+			FallbackTeams:      []string{"docs", "backend"},
+		}
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "docs").Return(&models.TeamSettings{TeamName: "docs"}, nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "backend").Return(&models.TeamSettings{TeamName: "backend"}, nil)
+		mockTeamRepo.EXPECT().UpdateTeamSettings(gomock.Any(), gomock.Any(), &settings).Return(nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "platform").Return(&settings, nil)
+
+		rr := doReq("POST", "/setSettings", settings)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, float64(3), r["settings"].(map[string]any)["max_reviewers"])
+		require.Equal(t, []any{"docs", "backend"}, r["settings"].(map[string]any)["fallback_teams"])
+	})
+
+	t.Run("Set fallback team not found", func(t *testing.T) {
+		settings := models.TeamSettings{
+			TeamName:           "platform",
+			MinReviewers:       1,
+			MaxReviewers:       2,
+			UnderstaffedPolicy: models.UnderstaffedPolicyFallback,
+			FallbackTeams:      []string{"unknown"},
+		}
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "unknown").Return(nil, sql.ErrNoRows)
+
+		rr := doReq("POST", "/setSettings", settings)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Set archived fallback team", func(t *testing.T) {
+		archivedAt := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+		settings := models.TeamSettings{
+			TeamName:           "platform",
+			MaxReviewers:       2,
+			UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer,
+			FallbackTeams:      []string{"legacy"},
+		}
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "legacy").Return(&models.TeamSettings{TeamName: "legacy", ArchivedAt: &archivedAt}, nil)
+
+		rr := doReq("POST", "/setSettings", settings)
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Set duplicated fallback teams", func(t *testing.T) {
+		settings := models.TeamSettings{
+			TeamName:           "platform",
+			MinReviewers:       1,
+			MaxReviewers:       2,
+			UnderstaffedPolicy: models.UnderstaffedPolicyFallback,
+			FallbackTeams:      []string{"docs", "docs"},
+		}
+
+		rr := doReq("POST", "/setSettings", settings)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Set invalid range", func(t *testing.T) {
+		settings := models.TeamSettings{TeamName: "platform", MinReviewers: 3, MaxReviewers: 2, UnderstaffedPolicy: models.UnderstaffedPolicyFail}
+
+		rr := doReq("POST", "/setSettings", settings)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Set fallback without team", func(t *testing.T) {
+		settings := models.TeamSettings{TeamName: "platform", MinReviewers: 1, MaxReviewers: 2, UnderstaffedPolicy: models.UnderstaffedPolicyFallback}
+
+		rr := doReq("POST", "/setSettings", settings)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Set settings team not found", func(t *testing.T) {
+		settings := models.TeamSettings{TeamName: "unknown", MinReviewers: 1, MaxReviewers: 1, UnderstaffedPolicy: models.UnderstaffedPolicyFail}
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		)
+		mockTeamRepo.EXPECT().UpdateTeamSettings(gomock.Any(), gomock.Any(), &settings).Return(sql.ErrNoRows)
+
+		rr := doReq("POST", "/setSettings", settings)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestDeactivateUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+	mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+
+	selector, err := prservice.NewReviewerSelector(config.AssignmentConfig{Strategy: prservice.StrategyLeastLoaded})
+	require.NoError(t, err)
+
+	doReq := func(body any) *httptest.ResponseRecorder {
+		service := teamservice.NewTeamService(mockStore, prservice.NewPRService(mockStore, selector))
+		handler := NewTeamHanlder(logger.NewLogger("local"), service)
+		teamMux := TeamRouter(handler)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", "/deactivateUsers", bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		teamMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Reviews are moved to remaining members", func(t *testing.T) {
+		req := models.DeactivateTeamUsersRequest{TeamName: "backend", UserIDs: []string{"u1", "u2"}}
+
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "backend").
+			Return(&models.TeamSettings{TeamName: "backend", MinReviewers: 1, MaxReviewers: 2, UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer}, nil)
+		mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil)
+		mockUserRepo.EXPECT().DeactivateTeamUsers(gomock.Any(), gomock.Any(), "backend", req.UserIDs).Return([]models.User{
+			{UserID: "u1", Username: "u1", TeamName: "backend"},
+			{UserID: "u2", Username: "u2", TeamName: "backend"},
+		}, nil)
+		mockPRRepo.EXPECT().GetOpenAssignmentsByReviewers(gomock.Any(), gomock.Any(), req.UserIDs, "").Return([]models.OpenAssignment{
+			{PullRequestID: "pr-1", AuthorID: "u3", ReviewTeamName: "backend", ReviewerID: "u1", AssignedReviewers: []string{"u1", "u2"}},
+			{PullRequestID: "pr-1", AuthorID: "u3", ReviewTeamName: "backend", ReviewerID: "u2", AssignedReviewers: []string{"u1", "u2"}},
+			{PullRequestID: "pr-2", AuthorID: "u4", ReviewTeamName: "backend", ReviewerID: "u1", AssignedReviewers: []string{"u1"}},
+		}, nil)
+		// candidates are loaded once for the team
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "backend").Return([]models.ReviewCandidate{
+			{UserID: "u1", TeamName: "backend", IsActive: false},
+			{UserID: "u2", TeamName: "backend", IsActive: false},
+			{UserID: "u3", TeamName: "backend", IsActive: true},
+			{UserID: "u4", TeamName: "backend", IsActive: true, OpenReviews: 1},
+		}, nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "backend").
+			Return(&models.TeamSettings{TeamName: "backend", MinReviewers: 1, MaxReviewers: 2, UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer}, nil)
+		mockPRRepo.EXPECT().ReplaceManyReviewers(gomock.Any(), gomock.Any(), []models.ReviewerReplacement{
+			{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u4"},
+			{PullRequestID: "pr-2", OldReviewerID: "u1", NewReviewerID: "u3"},
+		}).Return(nil)
+
+		rr := doReq(req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, "backend", r["team_name"])
+		require.Equal(t, 2, len(r["deactivated"].([]any)))
+		require.Equal(t, 2, len(r["reassigned"].([]any)))
+		require.Equal(t, []any{map[string]any{"pull_request_id": "pr-1", "reviewer_id": "u2"}}, r["not_reassigned"])
+	})
+
+	t.Run("Member of other primary team is deactivated only in the team", func(t *testing.T) {
+		req := models.DeactivateTeamUsersRequest{TeamName: "backend", UserIDs: []string{"g1"}}
+
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "backend").Return(&models.TeamSettings{TeamName: "backend", MaxReviewers: 2}, nil)
+		mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil)
+		mockUserRepo.EXPECT().DeactivateTeamUsers(gomock.Any(), gomock.Any(), "backend", req.UserIDs).
+			Return([]models.User{{UserID: "g1", Username: "g1", TeamName: "platform", IsActive: true}}, nil)
+		// only reviews of PRs of the team are moved, in one statement
+		mockPRRepo.EXPECT().GetOpenAssignmentsByReviewers(gomock.Any(), gomock.Any(), []string{"g1"}, "backend").Return([]models.OpenAssignment{
+			{PullRequestID: "pr-8", AuthorID: "u3", ReviewTeamName: "backend", ReviewerID: "g1", AssignedReviewers: []string{"g1"}},
+		}, nil)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "backend").Return([]models.ReviewCandidate{
+			{UserID: "g1", TeamName: "backend", IsActive: false},
+			{UserID: "u3", TeamName: "backend", IsActive: true},
+			{UserID: "u4", TeamName: "backend", IsActive: true},
+		}, nil)
+		mockPRRepo.EXPECT().ReplaceManyReviewers(gomock.Any(), gomock.Any(), []models.ReviewerReplacement{
+			{PullRequestID: "pr-8", OldReviewerID: "g1", NewReviewerID: "u4"},
+		}).Return(nil)
+
+		rr := doReq(req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, true, r["deactivated"].([]any)[0].(map[string]any)["is_active"])
+		require.Equal(t, []any{map[string]any{"pull_request_id": "pr-8", "old_reviewer_id": "g1", "new_reviewer_id": "u4"}}, r["reassigned"])
+		require.Equal(t, []any{}, r["not_reassigned"])
+	})
+
+	t.Run("User from another team", func(t *testing.T) {
+		req := models.DeactivateTeamUsersRequest{TeamName: "backend", UserIDs: []string{"u1", "x1"}}
+
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "backend").Return(&models.TeamSettings{TeamName: "backend"}, nil)
+		mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil)
+		mockUserRepo.EXPECT().DeactivateTeamUsers(gomock.Any(), gomock.Any(), "backend", req.UserIDs).
+			Return([]models.User{{UserID: "u1", TeamName: "backend"}}, nil)
+
+		rr := doReq(req)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Team not found", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "unknown").Return(nil, sql.ErrNoRows)
+
+		rr := doReq(models.DeactivateTeamUsersRequest{TeamName: "unknown", UserIDs: []string{"u1"}})
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Duplicated users", func(t *testing.T) {
+		rr := doReq(models.DeactivateTeamUsersRequest{TeamName: "backend", UserIDs: []string{"u1", "u1"}})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Empty users", func(t *testing.T) {
+		rr := doReq(models.DeactivateTeamUsersRequest{TeamName: "backend"})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestMergePolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+
+	doReq := func(method, path string, body any) *httptest.ResponseRecorder {
+		service := teamservice.NewTeamService(mockStore, nil)
+		handler := NewTeamHanlder(logger.NewLogger("local"), service)
+		teamMux := TeamRouter(handler)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest(method, path, bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		teamMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	policy := models.MergePolicy{TeamName: "backend", MinApprovals: 1, BlockOnChangesRequested: true, RequiredApprovers: []string{"lead"}}
+
+	t.Run("Get merge policy", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetMergePolicy(gomock.Any(), gomock.Any(), "backend").Return(&policy, nil)
+
+		rr := doReq("GET", "/getMergePolicy?team_name=backend", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, float64(1), r["policy"].(map[string]any)["min_approvals"])
+		require.Equal(t, []any{"lead"}, r["policy"].(map[string]any)["required_approvers"])
+	})
+
+	t.Run("Set merge policy", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "lead").Return(&models.User{UserID: "lead"}, nil)
+		mockTeamRepo.EXPECT().UpdateMergePolicy(gomock.Any(), gomock.Any(), &policy).Return(nil)
+		mockTeamRepo.EXPECT().GetMergePolicy(gomock.Any(), gomock.Any(), "backend").Return(&policy, nil)
+
+		rr := doReq("POST", "/setMergePolicy", policy)
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Set unknown approver", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "lead").Return(nil, sql.ErrNoRows)
+
+		rr := doReq("POST", "/setMergePolicy", policy)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Set negative approvals", func(t *testing.T) {
+		rr := doReq("POST", "/setMergePolicy", models.MergePolicy{TeamName: "backend", MinApprovals: -1})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Set team not found", func(t *testing.T) {
+		unknown := models.MergePolicy{TeamName: "unknown"}
+		mockTeamRepo.EXPECT().UpdateMergePolicy(gomock.Any(), gomock.Any(), &unknown).Return(sql.ErrNoRows)
+
+		rr := doReq("POST", "/setMergePolicy", unknown)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestSLAPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+
+	doReq := func(method, path string, body any) *httptest.ResponseRecorder {
+		service := teamservice.NewTeamService(mockStore, nil)
+		handler := NewTeamHanlder(logger.NewLogger("local"), service)
+		teamMux := TeamRouter(handler)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest(method, path, bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		teamMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Set SLA policy with default escalation", func(t *testing.T) {
+		expected := models.SLAPolicy{TeamName: "backend", ReviewSLAHours: 24, Escalation: models.SLAEscalationWebhook}
+		mockTeamRepo.EXPECT().UpdateSLAPolicy(gomock.Any(), gomock.Any(), &expected).Return(nil)
+		mockTeamRepo.EXPECT().GetSLAPolicy(gomock.Any(), gomock.Any(), "backend").Return(&expected, nil)
+
+		rr := doReq("POST", "/setSLAPolicy", models.SLAPolicy{TeamName: "backend", ReviewSLAHours: 24})
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, "WEBHOOK", r["policy"].(map[string]any)["escalation"])
+	})
+
+	t.Run("Set unknown escalation", func(t *testing.T) {
+		rr := doReq("POST", "/setSLAPolicy", models.SLAPolicy{TeamName: "backend", Escalation: "PAGE_ON_CALL"})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Set negative SLA", func(t *testing.T) {
+		rr := doReq("POST", "/setSLAPolicy", models.SLAPolicy{TeamName: "backend", MergeSLAHours: -1})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Get SLA policy of unknown team", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetSLAPolicy(gomock.Any(), gomock.Any(), "unknown").Return(nil, sql.ErrNoRows)
+
+		rr := doReq("GET", "/getSLAPolicy?team_name=unknown", nil)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestSetMembership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+
+	doReq := func(body any) *httptest.ResponseRecorder {
+		service := teamservice.NewTeamService(mockStore, nil)
+		handler := NewTeamHanlder(logger.NewLogger("local"), service)
+		teamMux := TeamRouter(handler)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", "/setMembership", bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		teamMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Deactivate membership with default role", func(t *testing.T) {
+		expected := models.TeamMembership{UserID: "u1", TeamName: "go-guild", Role: models.MembershipRoleMember}
+		mockTeamRepo.EXPECT().UpdateMembership(gomock.Any(), gomock.Any(), &expected).Return(&expected, nil)
+
+		rr := doReq(models.SetMembershipRequest{TeamName: "go-guild", UserID: "u1"})
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, "MEMBER", r["membership"].(map[string]any)["role"])
+		require.Equal(t, false, r["membership"].(map[string]any)["is_active"])
+	})
+
+	t.Run("Unknown role", func(t *testing.T) {
+		rr := doReq(models.SetMembershipRequest{TeamName: "go-guild", UserID: "u1", Role: "OWNER", IsActive: true})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Not a member", func(t *testing.T) {
+		mockTeamRepo.EXPECT().UpdateMembership(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows)
+
+		rr := doReq(models.SetMembershipRequest{TeamName: "go-guild", UserID: "u9", Role: models.MembershipRoleLead, IsActive: true})
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestUpsert(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+	mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+
+	selector, err := prservice.NewReviewerSelector(config.AssignmentConfig{Strategy: prservice.StrategyLeastLoaded})
+	require.NoError(t, err)
+
+	doReq := func(body any) *httptest.ResponseRecorder {
+		service := teamservice.NewTeamService(mockStore, prservice.NewPRService(mockStore, selector))
+		handler := NewTeamHanlder(logger.NewLogger("local"), service)
+		teamMux := TeamRouter(handler)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest("PUT", "/upsert", bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		teamMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Members match the list", func(t *testing.T) {
+		team := models.Team{TeamName: "backend", Members: []models.User{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: false},
+			{UserID: "u4", Username: "Dave", IsActive: true, Role: models.MembershipRoleLead},
+		}}
+
+		mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "backend").
+			Return(&models.TeamSettings{TeamName: "backend", MinReviewers: 1, MaxReviewers: 2, UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer}, nil)
+		mockTeamRepo.EXPECT().GetTeamWithMembers(gomock.Any(), gomock.Any(), "backend").Return(&models.Team{TeamName: "backend", Members: []models.User{
+			{UserID: "u1", TeamName: "backend", IsActive: true},
+			{UserID: "u2", TeamName: "backend", IsActive: true},
+			{UserID: "u3", TeamName: "backend", IsActive: true},
+		}}, nil)
+		mockUserRepo.EXPECT().UpsertUsers(gomock.Any(), gomock.Any(), "backend", team.Members).Return([]string{"u1", "u2", "u4"}, nil)
+		mockTeamRepo.EXPECT().UpsertMemberships(gomock.Any(), gomock.Any(), "backend", team.Members).Return(nil)
+
+		// u3 is removed from the primary team and deactivated
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u3").Return(&models.User{UserID: "u3", TeamName: "backend", IsActive: true}, nil)
+		mockTeamRepo.EXPECT().DeleteMembership(gomock.Any(), gomock.Any(), "backend", "u3").Return(nil)
+		mockUserRepo.EXPECT().UpdateUserStatus(gomock.Any(), gomock.Any(), "u3", false).Return(&models.User{UserID: "u3", TeamName: "backend"}, nil)
+
+		mockPRRepo.EXPECT().GetOpenPullRequestIDsByReviewer(gomock.Any(), gomock.Any(), "u2").Return([]string{"pr-1"}, nil)
+		mockPRRepo.EXPECT().GetOpenPullRequestIDsByReviewer(gomock.Any(), gomock.Any(), "u3").Return([]string{"pr-2"}, nil)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").
+			Return(&models.PullRequest{ID: "pr-1", AuthorID: "u5", TeamName: "backend", AssignedReviewers: []string{"u2"}}, nil)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-2").
+			Return(&models.PullRequest{ID: "pr-2", AuthorID: "u5", TeamName: "backend", AssignedReviewers: []string{"u1", "u3"}}, nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u5").Return(&models.User{UserID: "u5", TeamName: "frontend"}, nil).Times(2)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "backend").Return([]models.ReviewCandidate{
+			{UserID: "u1", TeamName: "backend", IsActive: true},
+			{UserID: "u4", TeamName: "backend", IsActive: true, OpenReviews: 1},
+		}, nil).Times(2)
+		mockPRRepo.EXPECT().DeleteAssignedReviewer(gomock.Any(), gomock.Any(), "pr-1", "u2").Return(nil)
+		mockPRRepo.EXPECT().AssignReviewer(gomock.Any(), gomock.Any(), "pr-1", "u1").Return(nil)
+		mockPRRepo.EXPECT().DeleteAssignedReviewer(gomock.Any(), gomock.Any(), "pr-2", "u3").Return(nil)
+		mockPRRepo.EXPECT().AssignReviewer(gomock.Any(), gomock.Any(), "pr-2", "u4").Return(nil)
+
+		mockTeamRepo.EXPECT().GetTeamWithMembers(gomock.Any(), gomock.Any(), "backend").Return(&models.Team{TeamName: "backend", Members: team.Members}, nil)
+
+		rr := doReq(team)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, []any{"u3"}, r["removed"])
+		require.Equal(t, 2, len(r["reassigned"].([]any)))
+		require.Empty(t, r["not_reassigned"])
+		require.Equal(t, 3, len(r["team"].(map[string]any)["members"].([]any)))
+	})
+
+	t.Run("New team is created", func(t *testing.T) {
+		team := models.Team{TeamName: "mobile", Members: []models.User{{UserID: "m1", Username: "Max", IsActive: true}}}
+
+		mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "mobile").Return(nil, sql.ErrNoRows)
+		mockTeamRepo.EXPECT().CreateTeam(gomock.Any(), gomock.Any(), "mobile").Return(nil)
+		mockTeamRepo.EXPECT().GetTeamWithMembers(gomock.Any(), gomock.Any(), "mobile").Return(nil, sql.ErrNoRows)
+		mockUserRepo.EXPECT().UpsertUsers(gomock.Any(), gomock.Any(), "mobile", team.Members).Return([]string{"m1"}, nil)
+		mockTeamRepo.EXPECT().UpsertMemberships(gomock.Any(), gomock.Any(), "mobile", team.Members).Return(nil)
+		mockTeamRepo.EXPECT().GetTeamWithMembers(gomock.Any(), gomock.Any(), "mobile").Return(&models.Team{TeamName: "mobile", Members: team.Members}, nil)
+
+		rr := doReq(team)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Empty(t, r["removed"])
+		require.Empty(t, r["reassigned"])
+	})
+
+	t.Run("Member of other primary team stays active", func(t *testing.T) {
+		team := models.Team{TeamName: "backend", Members: []models.User{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "g1", Username: "Renamed", IsActive: false},
+		}}
+		membershipActive := false
+		members := []models.User{team.Members[0], team.Members[1]}
+		members[1].MembershipActive = &membershipActive
+
+		mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "backend").
+			Return(&models.TeamSettings{TeamName: "backend", MaxReviewers: 2, UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer}, nil)
+		mockTeamRepo.EXPECT().GetTeamWithMembers(gomock.Any(), gomock.Any(), "backend").Return(&models.Team{TeamName: "backend", Members: []models.User{
+			{UserID: "u1", TeamName: "backend", IsActive: true},
+			{UserID: "g1", TeamName: "platform", IsActive: true},
+		}}, nil)
+		// g1 has primary team platform, so the user row is not changed
+		mockUserRepo.EXPECT().UpsertUsers(gomock.Any(), gomock.Any(), "backend", team.Members).Return([]string{"u1"}, nil)
+		mockTeamRepo.EXPECT().UpsertMemberships(gomock.Any(), gomock.Any(), "backend", members).Return(nil)
+		// only review of PR of the team is moved
+		mockPRRepo.EXPECT().GetOpenAssignmentsByReviewers(gomock.Any(), gomock.Any(), []string{"g1"}, "backend").Return([]models.OpenAssignment{
+			{PullRequestID: "pr-8", AuthorID: "u3", ReviewTeamName: "backend", ReviewerID: "g1", AssignedReviewers: []string{"g1"}},
+		}, nil)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "backend").Return([]models.ReviewCandidate{
+			{UserID: "u1", TeamName: "backend", IsActive: true},
+			{UserID: "g1", TeamName: "backend", IsActive: false},
+		}, nil)
+		mockPRRepo.EXPECT().ReplaceManyReviewers(gomock.Any(), gomock.Any(), []models.ReviewerReplacement{
+			{PullRequestID: "pr-8", OldReviewerID: "g1", NewReviewerID: "u1"},
+		}).Return(nil)
+		mockTeamRepo.EXPECT().GetTeamWithMembers(gomock.Any(), gomock.Any(), "backend").Return(&models.Team{TeamName: "backend", Members: team.Members}, nil)
+
+		rr := doReq(team)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Empty(t, r["removed"])
+		require.Equal(t, []any{map[string]any{"pull_request_id": "pr-8", "old_reviewer_id": "g1", "new_reviewer_id": "u1"}}, r["reassigned"])
+	})
+
+	t.Run("Duplicated users", func(t *testing.T) {
+		rr := doReq(models.Team{TeamName: "backend", Members: []models.User{{UserID: "u1"}, {UserID: "u1"}}})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Unknown role", func(t *testing.T) {
+		rr := doReq(models.Team{TeamName: "backend", Members: []models.User{{UserID: "u1", Role: "OWNER"}}})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Empty team name", func(t *testing.T) {
+		rr := doReq(models.Team{Members: []models.User{{UserID: "u1"}}})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestAddMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+
+	doReq := func(body any) *httptest.ResponseRecorder {
+		service := teamservice.NewTeamService(mockStore, nil)
+		handler := NewTeamHanlder(logger.NewLogger("local"), service)
+		teamMux := TeamRouter(handler)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", "/addMember", bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		teamMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("New user is created", func(t *testing.T) {
+		member := models.User{UserID: "u9", Username: "Zoe", IsActive: true, Role: models.MembershipRoleLead}
+
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "backend").Return(&models.TeamSettings{TeamName: "backend"}, nil)
+		mockTeamRepo.EXPECT().GetMembership(gomock.Any(), gomock.Any(), "backend", "u9").Return(nil, sql.ErrNoRows)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u9").Return(nil, sql.ErrNoRows)
+		mockUserRepo.EXPECT().CreateManyUsers(gomock.Any(), gomock.Any(), "backend", []models.User{member}).Return(nil)
+		mockTeamRepo.EXPECT().CreateMemberships(gomock.Any(), gomock.Any(), "backend", []models.User{member}).Return(nil)
+		mockTeamRepo.EXPECT().GetTeamWithMembers(gomock.Any(), gomock.Any(), "backend").
+			Return(&models.Team{TeamName: "backend", Members: []models.User{{UserID: "u1"}, {UserID: "u9"}}}, nil)
+
+		rr := doReq(models.AddTeamMemberRequest{TeamName: "backend", UserID: "u9", Username: "Zoe", Role: models.MembershipRoleLead})
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(r["team"].(map[string]any)["members"].([]any)))
+	})
+
+	t.Run("Existing user joins the team", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "go-guild").Return(&models.TeamSettings{TeamName: "go-guild"}, nil)
+		mockTeamRepo.EXPECT().GetMembership(gomock.Any(), gomock.Any(), "go-guild", "u1").Return(nil, sql.ErrNoRows)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&models.User{UserID: "u1", TeamName: "backend"}, nil)
+		mockTeamRepo.EXPECT().CreateMemberships(gomock.Any(), gomock.Any(), "go-guild", gomock.Any()).Return(nil)
+		mockTeamRepo.EXPECT().GetTeamWithMembers(gomock.Any(), gomock.Any(), "go-guild").
+			Return(&models.Team{TeamName: "go-guild", Members: []models.User{{UserID: "u1"}}}, nil)
+
+		rr := doReq(models.AddTeamMemberRequest{TeamName: "go-guild", UserID: "u1"})
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("New user without username", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "backend").Return(&models.TeamSettings{TeamName: "backend"}, nil)
+		mockTeamRepo.EXPECT().GetMembership(gomock.Any(), gomock.Any(), "backend", "u9").Return(nil, sql.ErrNoRows)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u9").Return(nil, sql.ErrNoRows)
+
+		rr := doReq(models.AddTeamMemberRequest{TeamName: "backend", UserID: "u9"})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Already a member", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "backend").Return(&models.TeamSettings{TeamName: "backend"}, nil)
+		mockTeamRepo.EXPECT().GetMembership(gomock.Any(), gomock.Any(), "backend", "u1").
+			Return(&models.TeamMembership{UserID: "u1", TeamName: "backend", Role: models.MembershipRoleMember, IsActive: true}, nil)
+
+		rr := doReq(models.AddTeamMemberRequest{TeamName: "backend", UserID: "u1"})
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Team not found", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "unknown").Return(nil, sql.ErrNoRows)
+
+		rr := doReq(models.AddTeamMemberRequest{TeamName: "unknown", UserID: "u1"})
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestRemoveMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+	mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+
+	selector, err := prservice.NewReviewerSelector(config.AssignmentConfig{Strategy: prservice.StrategyLeastLoaded})
+	require.NoError(t, err)
+
+	doReq := func(body any) *httptest.ResponseRecorder {
+		service := teamservice.NewTeamService(mockStore, prservice.NewPRService(mockStore, selector))
+		handler := NewTeamHanlder(logger.NewLogger("local"), service)
+		teamMux := TeamRouter(handler)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", "/removeMember", bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		teamMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Only reviews of the team are moved", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetMembership(gomock.Any(), gomock.Any(), "go-guild", "u1").
+			Return(&models.TeamMembership{UserID: "u1", TeamName: "go-guild", Role: models.MembershipRoleMember, IsActive: true}, nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&models.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+		mockTeamRepo.EXPECT().DeleteMembership(gomock.Any(), gomock.Any(), "go-guild", "u1").Return(nil)
+
+		mockPRRepo.EXPECT().GetOpenPullRequestIDsByReviewer(gomock.Any(), gomock.Any(), "u1").Return([]string{"pr-1", "pr-2"}, nil)
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").
+			Return(&models.PullRequest{ID: "pr-1", AuthorID: "u5", TeamName: "go-guild", AssignedReviewers: []string{"u1"}}, nil)
+		// reviewed by primary team of the author
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-2").
+			Return(&models.PullRequest{ID: "pr-2", AuthorID: "u6", AssignedReviewers: []string{"u1"}}, nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u5").Return(&models.User{UserID: "u5", TeamName: "frontend"}, nil).Times(2)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u6").Return(&models.User{UserID: "u6", TeamName: "backend"}, nil)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "go-guild").Return([]models.ReviewCandidate{
+			{UserID: "u2", TeamName: "go-guild", IsActive: true},
+		}, nil)
+		mockPRRepo.EXPECT().DeleteAssignedReviewer(gomock.Any(), gomock.Any(), "pr-1", "u1").Return(nil)
+		mockPRRepo.EXPECT().AssignReviewer(gomock.Any(), gomock.Any(), "pr-1", "u2").Return(nil)
+
+		rr := doReq(models.RemoveTeamMemberRequest{TeamName: "go-guild", UserID: "u1"})
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, false, r["deactivated"])
+		require.Equal(t, []any{map[string]any{"pull_request_id": "pr-1", "old_reviewer_id": "u1", "new_reviewer_id": "u2"}}, r["reassigned"])
+		require.Empty(t, r["not_reassigned"])
+	})
+
+	t.Run("Member of primary team is deactivated", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetMembership(gomock.Any(), gomock.Any(), "backend", "u3").
+			Return(&models.TeamMembership{UserID: "u3", TeamName: "backend", Role: models.MembershipRoleMember, IsActive: true}, nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u3").Return(&models.User{UserID: "u3", TeamName: "backend", IsActive: true}, nil)
+		mockTeamRepo.EXPECT().DeleteMembership(gomock.Any(), gomock.Any(), "backend", "u3").Return(nil)
+		mockUserRepo.EXPECT().UpdateUserStatus(gomock.Any(), gomock.Any(), "u3", false).Return(&models.User{UserID: "u3", TeamName: "backend"}, nil)
+		mockPRRepo.EXPECT().GetOpenPullRequestIDsByReviewer(gomock.Any(), gomock.Any(), "u3").Return([]string{}, nil)
+
+		rr := doReq(models.RemoveTeamMemberRequest{TeamName: "backend", UserID: "u3"})
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, true, r["deactivated"])
+	})
+
+	t.Run("Not a member", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetMembership(gomock.Any(), gomock.Any(), "backend", "u9").Return(nil, sql.ErrNoRows)
+
+		rr := doReq(models.RemoveTeamMemberRequest{TeamName: "backend", UserID: "u9"})
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestArchive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
+	mockOwnershipRepo := mock_store.NewMockOwnershipRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+	mockStore.EXPECT().OwnershipRepo().Return(mockOwnershipRepo).AnyTimes()
+	mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+
+	selector, err := prservice.NewReviewerSelector(config.AssignmentConfig{Strategy: prservice.StrategyLeastLoaded})
+	require.NoError(t, err)
+
+	doReq := func(query string) *httptest.ResponseRecorder {
+		service := teamservice.NewTeamService(mockStore, prservice.NewPRService(mockStore, selector))
+		handler := NewTeamHanlder(logger.NewLogger("local"), service)
+		teamMux := TeamRouter(handler)
+
+		req, err := http.NewRequest("DELETE", "/archive?"+query, nil)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		teamMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	archivedAt := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("PRs are moved to successor team", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "legacy").Return(&models.TeamSettings{TeamName: "legacy"}, nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "backend").Return(&models.TeamSettings{TeamName: "backend"}, nil)
+		mockPRRepo.EXPECT().GetActivePullRequestIDsByTeam(gomock.Any(), gomock.Any(), "legacy").Return([]string{"pr-1"}, nil)
+		mockTeamRepo.EXPECT().ArchiveTeam(gomock.Any(), gomock.Any(), "legacy").Return(archivedAt, nil)
+		mockOwnershipRepo.EXPECT().TransferRepositories(gomock.Any(), gomock.Any(), "legacy", "backend").Return(nil)
+		mockPRRepo.EXPECT().SetPullRequestsTeam(gomock.Any(), gomock.Any(), []string{"pr-1"}, "backend").Return(nil)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "backend").Return([]models.ReviewCandidate{
+			{UserID: "u1", TeamName: "backend", IsActive: true},
+			{UserID: "u2", TeamName: "backend", IsActive: true},
+			{UserID: "u5", TeamName: "backend", IsActive: true},
+		}, nil).Times(2)
+		// u1 is a member of the successor and keeps the review
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").
+			Return(&models.PullRequest{ID: "pr-1", AuthorID: "u5", TeamName: "backend", AssignedReviewers: []string{"u1", "x1"}}, nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u5").Return(&models.User{UserID: "u5", TeamName: "legacy"}, nil)
+		mockPRRepo.EXPECT().DeleteAssignedReviewer(gomock.Any(), gomock.Any(), "pr-1", "x1").Return(nil)
+		mockPRRepo.EXPECT().AssignReviewer(gomock.Any(), gomock.Any(), "pr-1", "u2").Return(nil)
+
+		rr := doReq("team_name=legacy&policy=REASSIGN&successor_team=backend")
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, "backend", r["successor_team"])
+		require.Equal(t, []any{"pr-1"}, r["pull_requests"])
+		require.Equal(t, []any{map[string]any{"pull_request_id": "pr-1", "old_reviewer_id": "x1", "new_reviewer_id": "u2"}}, r["reassigned"])
+		require.Empty(t, r["not_reassigned"])
+	})
+
+	t.Run("Team without PRs is archived by default policy", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "legacy").Return(&models.TeamSettings{TeamName: "legacy"}, nil)
+		mockPRRepo.EXPECT().GetActivePullRequestIDsByTeam(gomock.Any(), gomock.Any(), "legacy").Return([]string{}, nil)
+		mockTeamRepo.EXPECT().ArchiveTeam(gomock.Any(), gomock.Any(), "legacy").Return(archivedAt, nil)
+		mockOwnershipRepo.EXPECT().TransferRepositories(gomock.Any(), gomock.Any(), "legacy", "").Return(nil)
+
+		rr := doReq("team_name=legacy")
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, "BLOCK", r["policy"])
+		require.Equal(t, "2026-01-10T12:00:00Z", r["archived_at"])
+	})
+
+	t.Run("Open PRs block archive", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "legacy").Return(&models.TeamSettings{TeamName: "legacy"}, nil)
+		mockPRRepo.EXPECT().GetActivePullRequestIDsByTeam(gomock.Any(), gomock.Any(), "legacy").Return([]string{"pr-1", "pr-2"}, nil)
+
+		rr := doReq("team_name=legacy&policy=BLOCK")
+
+		require.Equal(t, http.StatusConflict, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, "TEAM_HAS_OPEN_PRS", r["error"].(map[string]any)["code"])
+		require.Equal(t, []any{"pr-1", "pr-2"}, r["error"].(map[string]any)["details"])
+	})
+
+	t.Run("Archived successor team", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "legacy").Return(&models.TeamSettings{TeamName: "legacy"}, nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "old").Return(&models.TeamSettings{TeamName: "old", ArchivedAt: &archivedAt}, nil)
+
+		rr := doReq("team_name=legacy&policy=REASSIGN&successor_team=old")
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Team not found", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "unknown").Return(nil, sql.ErrNoRows)
+
+		rr := doReq("team_name=unknown")
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Invalid policy", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, doReq("team_name=legacy&policy=DELETE").Code)
+		require.Equal(t, http.StatusBadRequest, doReq("team_name=legacy&policy=REASSIGN").Code)
+		require.Equal(t, http.StatusBadRequest, doReq("team_name=legacy&successor_team=backend").Code)
+		require.Equal(t, http.StatusBadRequest, doReq("team_name=legacy&policy=REASSIGN&successor_team=legacy").Code)
+	})
+}
+
+func TestHierarchy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+	mockTeamRepo.EXPECT().LockHierarchy(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+
+	doReq := func(method, target string, body any) *httptest.ResponseRecorder {
+		service := teamservice.NewTeamService(mockStore, nil)
+		handler := NewTeamHanlder(logger.NewLogger("local"), service)
+		teamMux := TeamRouter(handler)
+
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		req, err := http.NewRequest(method, target, &buf)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		teamMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// platform -> backend -> payments, platform -> frontend
+	subtree := []models.Team{
+		{TeamName: "backend", ParentTeam: "platform", Members: []models.User{
+			{UserID: "u2", Username: "bob", TeamName: "backend", IsActive: true, Role: models.MembershipRoleLead},
+			{UserID: "u3", Username: "carol", TeamName: "payments", IsActive: true},
+		}},
+		{TeamName: "frontend", ParentTeam: "platform", Members: []models.User{}},
+		{TeamName: "payments", ParentTeam: "backend", Members: []models.User{
+			{UserID: "u3", Username: "carol", TeamName: "payments", IsActive: true},
+		}},
+		{TeamName: "platform", Members: []models.User{
+			{UserID: "u1", Username: "alice", TeamName: "platform", IsActive: true},
+		}},
+	}
+
+	t.Run("Set parent", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "payments").Return(&models.TeamSettings{TeamName: "payments"}, nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "backend").Return(&models.TeamSettings{TeamName: "backend"}, nil)
+		mockTeamRepo.EXPECT().HasAncestor(gomock.Any(), gomock.Any(), "backend", "payments").Return(false, nil)
+		mockTeamRepo.EXPECT().SetParentTeam(gomock.Any(), gomock.Any(), "payments", "backend").Return(nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "payments").Return(&models.TeamSettings{TeamName: "payments", ParentTeam: "backend"}, nil)
+
+		rr := doReq("POST", "/setParent", models.SetParentTeamRequest{TeamName: "payments", ParentTeam: "backend"})
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, "backend", r["settings"]["parent_team"])
+	})
+
+	t.Run("Parent is a descendant", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "platform").Return(&models.TeamSettings{TeamName: "platform"}, nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "payments").Return(&models.TeamSettings{TeamName: "payments", ParentTeam: "backend"}, nil)
+		mockTeamRepo.EXPECT().HasAncestor(gomock.Any(), gomock.Any(), "payments", "platform").Return(true, nil)
+
+		rr := doReq("POST", "/setParent", models.SetParentTeamRequest{TeamName: "platform", ParentTeam: "payments"})
+
+		require.Equal(t, http.StatusConflict, rr.Code)
+		r := map[string]map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, "TEAM_HIERARCHY_CYCLE", r["error"]["code"])
+	})
+
+	t.Run("Archived parent", func(t *testing.T) {
+		archivedAt := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "payments").Return(&models.TeamSettings{TeamName: "payments"}, nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "legacy").Return(&models.TeamSettings{TeamName: "legacy", ArchivedAt: &archivedAt}, nil)
+
+		rr := doReq("POST", "/setParent", models.SetParentTeamRequest{TeamName: "payments", ParentTeam: "legacy"})
+		require.Equal(t, http.StatusConflict, rr.Code)
+	})
+
+	t.Run("Make team a root", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "payments").Return(&models.TeamSettings{TeamName: "payments", ParentTeam: "backend"}, nil)
+		mockTeamRepo.EXPECT().SetParentTeam(gomock.Any(), gomock.Any(), "payments", "").Return(nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "payments").Return(&models.TeamSettings{TeamName: "payments"}, nil)
+
+		rr := doReq("POST", "/setParent", models.SetParentTeamRequest{TeamName: "payments"})
+		require.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("Invalid parent", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, doReq("POST", "/setParent", models.SetParentTeamRequest{TeamName: "payments", ParentTeam: "payments"}).Code)
+		require.Equal(t, http.StatusBadRequest, doReq("POST", "/setParent", models.SetParentTeamRequest{ParentTeam: "payments"}).Code)
+	})
+
+	t.Run("Get subtree", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetSubtree(gomock.Any(), gomock.Any(), "platform").Return(subtree, nil)
+
+		rr := doReq("GET", "/subtree?team_name=platform", nil)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var r models.TeamSubtree
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, "platform", r.Team.TeamName)
+		require.Len(t, r.Team.Children, 2)
+		require.Equal(t, "backend", r.Team.Children[0].TeamName)
+		require.Equal(t, "payments", r.Team.Children[0].Children[0].TeamName)
+		require.Empty(t, r.Team.Children[1].Children)
+		// carol is a member of two teams
+		require.Len(t, r.Members, 3)
+		require.Equal(t, "u1", r.Members[0].UserID)
+		require.Equal(t, "payments", r.Members[2].TeamName)
+	})
+
+	t.Run("Subtree of unknown team", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetSubtree(gomock.Any(), gomock.Any(), "unknown").Return(nil, sql.ErrNoRows)
+
+		rr := doReq("GET", "/subtree?team_name=unknown", nil)
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Statistics roll up to parents", func(t *testing.T) {
+		mockTeamRepo.EXPECT().GetSubtree(gomock.Any(), gomock.Any(), "platform").Return(subtree, nil)
+		mockPRRepo.EXPECT().GetTeamsPullRequestCounts(gomock.Any(), gomock.Any(), []string{"backend", "frontend", "payments", "platform"}, "svc").
+			Return([]models.TeamPullRequestCounts{
+				{TeamName: "backend", PullRequestCounts: models.PullRequestCounts{Open: 2, Merged: 1, OpenReviews: 4}},
+				{TeamName: "payments", PullRequestCounts: models.PullRequestCounts{Open: 1, Draft: 1, OpenReviews: 2}},
+				{TeamName: "platform", PullRequestCounts: models.PullRequestCounts{Closed: 1}},
+			}, nil)
+
+		rr := doReq("GET", "/statistics?team_name=platform&repository=svc", nil)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var r map[string]models.TeamStatistics
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		stat := r["stat"]
+		require.Equal(t, models.PullRequestCounts{Closed: 1}, stat.Own)
+		require.Equal(t, models.PullRequestCounts{Draft: 1, Open: 3, Merged: 1, Closed: 1, OpenReviews: 6}, stat.Total)
+		require.Equal(t, models.PullRequestCounts{Draft: 1, Open: 3, Merged: 1, OpenReviews: 6}, stat.Children[0].Total)
+		require.Equal(t, models.PullRequestCounts{}, stat.Children[1].Total)
+	})
+}
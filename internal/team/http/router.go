@@ -6,7 +6,22 @@ func TeamRouter(h *TeamHanler) http.Handler {
 	handler := http.NewServeMux()
 
 	handler.HandleFunc("POST /add", h.Add)
+	handler.HandleFunc("PUT /upsert", h.Upsert)
 	handler.HandleFunc("GET /get", h.Get)
+	handler.HandleFunc("GET /getSettings", h.GetSettings)
+	handler.HandleFunc("POST /setSettings", h.SetSettings)
+	handler.HandleFunc("GET /getMergePolicy", h.GetMergePolicy)
+	handler.HandleFunc("POST /setMergePolicy", h.SetMergePolicy)
+	handler.HandleFunc("GET /getSLAPolicy", h.GetSLAPolicy)
+	handler.HandleFunc("POST /setSLAPolicy", h.SetSLAPolicy)
+	handler.HandleFunc("POST /deactivateUsers", h.DeactivateUsers)
+	handler.HandleFunc("POST /setMembership", h.SetMembership)
+	handler.HandleFunc("POST /addMember", h.AddMember)
+	handler.HandleFunc("POST /removeMember", h.RemoveMember)
+	handler.HandleFunc("DELETE /archive", h.Archive)
+	handler.HandleFunc("POST /setParent", h.SetParent)
+	handler.HandleFunc("GET /subtree", h.GetSubtree)
+	handler.HandleFunc("GET /statistics", h.Statistics)
 
 	return handler
 }
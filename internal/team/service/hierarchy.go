@@ -0,0 +1,163 @@
+package teamservice
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/Negat1v9/pr-review-service/pkg/utils"
+	"github.com/jmoiron/sqlx"
+)
+
+// SetParent moves the team under the parent team, empty parent makes the team a root.
+// Parent must not be the team or one of its descendants
+func (s *TeamService) SetParent(ctx context.Context, req *models.SetParentTeamRequest) (*models.TeamSettings, error) {
+	if req.TeamName == "" {
+		return nil, utils.NewBadRequestError("team_name is required", nil)
+	}
+	if req.ParentTeam == req.TeamName {
+		return nil, utils.NewBadRequestError("team cannot be its own parent", nil)
+	}
+
+	var settings *models.TeamSettings
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		// concurrent moves of two teams under each other could make a cycle
+		if err := s.store.TeamRepo().LockHierarchy(ctx, exec); err != nil {
+			return err
+		}
+
+		if _, err := s.store.TeamRepo().GetTeamSettings(ctx, exec, req.TeamName); err != nil {
+			if err == sql.ErrNoRows {
+				return utils.NewNotFoundError("resource not found", req.TeamName)
+			}
+			return err
+		}
+
+		if req.ParentTeam != "" {
+			if err := s.checkActiveTeam(ctx, exec, req.ParentTeam); err != nil {
+				return err
+			}
+
+			cycle, err := s.store.TeamRepo().HasAncestor(ctx, exec, req.ParentTeam, req.TeamName)
+			if err != nil {
+				return err
+			}
+			if cycle {
+				return utils.NewError(409, utils.ErrHierarchyCycle, "parent team is a descendant of the team", req.ParentTeam)
+			}
+		}
+
+		if err := s.store.TeamRepo().SetParentTeam(ctx, exec, req.TeamName, req.ParentTeam); err != nil {
+			return err
+		}
+
+		var err error
+		settings, err = s.store.TeamRepo().GetTeamSettings(ctx, exec, req.TeamName)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// GetSubtree returns the team with its descendants and members of all of them
+func (s *TeamService) GetSubtree(ctx context.Context, teamName string) (*models.TeamSubtree, error) {
+	teams, err := s.store.TeamRepo().GetSubtree(ctx, s.store.DB(), teamName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NewNotFoundError("resource not found", nil)
+		}
+		return nil, err
+	}
+
+	members := make([]models.User, 0)
+	seen := make(map[string]bool)
+	for _, team := range teams {
+		for _, member := range team.Members {
+			if seen[member.UserID] {
+				continue
+			}
+			seen[member.UserID] = true
+			members = append(members, models.User{
+				UserID:   member.UserID,
+				Username: member.Username,
+				TeamName: member.TeamName,
+				IsActive: member.IsActive,
+			})
+		}
+	}
+	slices.SortFunc(members, func(a, b models.User) int {
+		return strings.Compare(a.UserID, b.UserID)
+	})
+
+	return &models.TeamSubtree{
+		Team:    buildTree(teams, teamName),
+		Members: members,
+	}, nil
+}
+
+// Statistics counts PRs reviewed by every team of the subtree, totals of parent teams include their descendants
+func (s *TeamService) Statistics(ctx context.Context, teamName, repository string) (*models.TeamStatistics, error) {
+	teams, err := s.store.TeamRepo().GetSubtree(ctx, s.store.DB(), teamName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NewNotFoundError("resource not found", nil)
+		}
+		return nil, err
+	}
+
+	teamNames := make([]string, 0, len(teams))
+	for _, team := range teams {
+		teamNames = append(teamNames, team.TeamName)
+	}
+	counts, err := s.store.PRRepo().GetTeamsPullRequestCounts(ctx, s.store.DB(), teamNames, repository)
+	if err != nil {
+		return nil, err
+	}
+	own := make(map[string]models.PullRequestCounts, len(counts))
+	for _, count := range counts {
+		own[count.TeamName] = count.PullRequestCounts
+	}
+
+	return teamStatistics(buildTree(teams, teamName), own), nil
+}
+
+// buildTree links teams ordered by name to their parents, children keep the order
+func buildTree(teams []models.Team, root string) *models.TeamNode {
+	nodes := make(map[string]*models.TeamNode, len(teams))
+	for _, team := range teams {
+		nodes[team.TeamName] = &models.TeamNode{Team: team, Children: make([]*models.TeamNode, 0)}
+	}
+
+	for _, team := range teams {
+		if team.TeamName == root {
+			continue
+		}
+		if parent, ok := nodes[team.ParentTeam]; ok {
+			parent.Children = append(parent.Children, nodes[team.TeamName])
+		}
+	}
+	return nodes[root]
+}
+
+func teamStatistics(node *models.TeamNode, own map[string]models.PullRequestCounts) *models.TeamStatistics {
+	stat := &models.TeamStatistics{
+		TeamName:   node.TeamName,
+		ParentTeam: node.ParentTeam,
+		ArchivedAt: node.ArchivedAt,
+		Own:        own[node.TeamName],
+		Total:      own[node.TeamName],
+		Children:   make([]*models.TeamStatistics, 0, len(node.Children)),
+	}
+
+	for _, child := range node.Children {
+		childStat := teamStatistics(child, own)
+		stat.Total.Add(childStat.Total)
+		stat.Children = append(stat.Children, childStat)
+	}
+	return stat
+}
@@ -3,6 +3,7 @@ package teamservice
 import (
 	"context"
 	"database/sql"
+	"slices"
 
 	"github.com/Negat1v9/pr-review-service/internal/models"
 	"github.com/Negat1v9/pr-review-service/internal/store"
@@ -10,17 +11,34 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// ReviewReassigner moves OPEN reviews of deactivated users and removed members to other reviewers
+type ReviewReassigner interface {
+	ReassignOpenReviewsOfUsers(ctx context.Context, exec sqlx.ExtContext, reviewerIDs []string) (*models.BulkReviewsReassignment, error)
+	ReassignTeamReviewsOfUsers(ctx context.Context, exec sqlx.ExtContext, teamName string, reviewerIDs []string) (*models.BulkReviewsReassignment, error)
+	ReassignOpenReviews(ctx context.Context, exec sqlx.ExtContext, reviewerID string) (*models.ReviewsReassignment, error)
+	ReassignTeamReviews(ctx context.Context, exec sqlx.ExtContext, teamName, reviewerID string, reason models.AssignmentReason) (*models.ReviewsReassignment, error)
+	MoveToTeam(ctx context.Context, exec sqlx.ExtContext, prIDs []string, teamName string) (*models.BulkReviewsReassignment, error)
+}
+
 type TeamService struct {
-	store store.Store
+	store      store.Store
+	reassigner ReviewReassigner
 }
 
-func NewTeamService(store store.Store) *TeamService {
+func NewTeamService(store store.Store, reassigner ReviewReassigner) *TeamService {
 	return &TeamService{
-		store: store,
+		store:      store,
+		reassigner: reassigner,
 	}
 }
 
+// AddTeam creates team with its members, existing users join the team and keep their primary team
 func (s *TeamService) AddTeam(ctx context.Context, newTeam *models.Team) (*models.Team, error) {
+	for _, member := range newTeam.Members {
+		if !validRole(member.Role) {
+			return nil, utils.NewBadRequestError("role must be one of MEMBER, LEAD", member.UserID)
+		}
+	}
 
 	_, err := s.store.TeamRepo().GetTeamWithMembers(ctx, s.store.DB(), newTeam.TeamName)
 	if err == nil {
@@ -41,6 +59,10 @@ func (s *TeamService) AddTeam(ctx context.Context, newTeam *models.Team) (*model
 			return err
 		}
 
+		if err := s.store.TeamRepo().CreateMemberships(ctx, exec, newTeam.TeamName, newTeam.Members); err != nil {
+			return err
+		}
+
 		return nil
 	})
 
@@ -56,6 +78,348 @@ func (s *TeamService) AddTeam(ctx context.Context, newTeam *models.Team) (*model
 	return createdTeam, nil
 }
 
+// UpsertTeam makes the team match the member list in one transaction: creates the team and new users,
+// updates existing users of the team and memberships and removes members which are not listed.
+// Name and status of members whose primary team is another one are kept, inactive ones are deactivated only in the team.
+// OPEN reviews of deactivated users and removed members are reassigned by ReassignPR rules
+func (s *TeamService) UpsertTeam(ctx context.Context, team *models.Team) (*models.UpsertTeamResponse, error) {
+	if team.TeamName == "" {
+		return nil, utils.NewBadRequestError("team_name is required", nil)
+	}
+	listed := make(map[string]bool, len(team.Members))
+	for _, member := range team.Members {
+		if member.UserID == "" {
+			return nil, utils.NewBadRequestError("user_id is required", nil)
+		}
+		if listed[member.UserID] {
+			return nil, utils.NewBadRequestError("user_ids must be unique", nil)
+		}
+		listed[member.UserID] = true
+		if !validRole(member.Role) {
+			return nil, utils.NewBadRequestError("role must be one of MEMBER, LEAD", member.UserID)
+		}
+	}
+
+	res := &models.UpsertTeamResponse{
+		Removed: make([]string, 0),
+		BulkReviewsReassignment: &models.BulkReviewsReassignment{
+			Reassigned:    make([]models.ReviewerReplacement, 0),
+			NotReassigned: make([]models.OpenReview, 0),
+		},
+	}
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		// wait for running assignments, they could pick users being deactivated or removed
+		if err := s.store.PRRepo().LockAssignments(ctx, exec); err != nil {
+			return err
+		}
+
+		settings, err := s.store.TeamRepo().GetTeamSettings(ctx, exec, team.TeamName)
+		switch {
+		case err == sql.ErrNoRows:
+			if err := s.store.TeamRepo().CreateTeam(ctx, exec, team.TeamName); err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		case settings.ArchivedAt != nil:
+			return utils.NewError(409, utils.ErrTeamArchived, "team is archived", nil)
+		}
+
+		current, err := s.store.TeamRepo().GetTeamWithMembers(ctx, exec, team.TeamName)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+
+		primaryIDs, err := s.store.UserRepo().UpsertUsers(ctx, exec, team.TeamName, team.Members)
+		if err != nil {
+			return err
+		}
+
+		// users of other primary teams keep name and status, inactive ones are deactivated only in the team
+		members := slices.Clone(team.Members)
+		var teamDeactivated []string
+		for i, member := range members {
+			if member.IsActive || slices.Contains(primaryIDs, member.UserID) {
+				continue
+			}
+			membershipActive := false
+			members[i].MembershipActive = &membershipActive
+			teamDeactivated = append(teamDeactivated, member.UserID)
+		}
+		if err := s.store.TeamRepo().UpsertMemberships(ctx, exec, team.TeamName, members); err != nil {
+			return err
+		}
+
+		// user id -> whether user is deactivated, reviews are moved after all changes
+		// so that no one who is deactivated or removed becomes a replacement
+		var leaving []string
+		deactivated := make(map[string]bool)
+		for _, member := range team.Members {
+			if !member.IsActive && slices.Contains(primaryIDs, member.UserID) {
+				leaving = append(leaving, member.UserID)
+				deactivated[member.UserID] = true
+			}
+		}
+		if current != nil {
+			for _, member := range current.Members {
+				if listed[member.UserID] {
+					continue
+				}
+				if deactivated[member.UserID], err = s.removeMember(ctx, exec, team.TeamName, member.UserID); err != nil {
+					return err
+				}
+				leaving = append(leaving, member.UserID)
+				res.Removed = append(res.Removed, member.UserID)
+			}
+		}
+
+		for _, userID := range leaving {
+			reassignment, err := s.reassignReviews(ctx, exec, team.TeamName, userID, deactivated[userID])
+			if err != nil {
+				return err
+			}
+			res.Reassigned = append(res.Reassigned, reassignment.Reassigned...)
+			for _, prID := range reassignment.NotReassigned {
+				res.NotReassigned = append(res.NotReassigned, models.OpenReview{PullRequestID: prID, ReviewerID: userID})
+			}
+		}
+		if len(teamDeactivated) == 0 {
+			return nil
+		}
+
+		teamRes, err := s.reassigner.ReassignTeamReviewsOfUsers(ctx, exec, team.TeamName, teamDeactivated)
+		if err != nil {
+			return err
+		}
+		res.Reassigned = append(res.Reassigned, teamRes.Reassigned...)
+		res.NotReassigned = append(res.NotReassigned, teamRes.NotReassigned...)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.Team, err = s.store.TeamRepo().GetTeamWithMembers(ctx, s.store.DB(), team.TeamName)
+	if err == sql.ErrNoRows {
+		res.Team, err = &models.Team{TeamName: team.TeamName, Members: make([]models.User, 0)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AddMember adds the user to the team, new user is created with the team as primary one
+func (s *TeamService) AddMember(ctx context.Context, req *models.AddTeamMemberRequest) (*models.Team, error) {
+	if req.UserID == "" {
+		return nil, utils.NewBadRequestError("user_id is required", nil)
+	}
+	if !validRole(req.Role) {
+		return nil, utils.NewBadRequestError("role must be one of MEMBER, LEAD", nil)
+	}
+
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		if err := s.checkActiveTeam(ctx, exec, req.TeamName); err != nil {
+			return err
+		}
+
+		_, err := s.store.TeamRepo().GetMembership(ctx, exec, req.TeamName, req.UserID)
+		if err == nil {
+			return utils.NewError(409, utils.ErrAlreadyMember, "user is already a member of the team", nil)
+		}
+		if err != sql.ErrNoRows {
+			return err
+		}
+
+		member := models.User{
+			UserID:           req.UserID,
+			Username:         req.Username,
+			IsActive:         true,
+			Role:             req.Role,
+			MembershipActive: req.MembershipActive,
+		}
+		_, err = s.store.UserRepo().GetUserByID(ctx, exec, req.UserID)
+		if err == sql.ErrNoRows {
+			if req.Username == "" {
+				return utils.NewBadRequestError("username is required for a new user", nil)
+			}
+			err = s.store.UserRepo().CreateManyUsers(ctx, exec, req.TeamName, []models.User{member})
+		}
+		if err != nil {
+			return err
+		}
+
+		return s.store.TeamRepo().CreateMemberships(ctx, exec, req.TeamName, []models.User{member})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.TeamRepo().GetTeamWithMembers(ctx, s.store.DB(), req.TeamName)
+}
+
+// RemoveMember removes the user from the team and reassigns OPEN reviews of PRs of the team by ReassignPR rules
+// in one transaction. Member removed from the primary team is deactivated and all OPEN reviews are reassigned
+func (s *TeamService) RemoveMember(ctx context.Context, req *models.RemoveTeamMemberRequest) (*models.RemoveTeamMemberResponse, error) {
+	res := &models.RemoveTeamMemberResponse{TeamName: req.TeamName, UserID: req.UserID}
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		// wait for running assignments, they could pick the member
+		if err := s.store.PRRepo().LockAssignments(ctx, exec); err != nil {
+			return err
+		}
+
+		if _, err := s.store.TeamRepo().GetMembership(ctx, exec, req.TeamName, req.UserID); err != nil {
+			if err == sql.ErrNoRows {
+				return utils.NewNotFoundError("user is not a member of the team", nil)
+			}
+			return err
+		}
+
+		var err error
+		if res.Deactivated, err = s.removeMember(ctx, exec, req.TeamName, req.UserID); err != nil {
+			return err
+		}
+		res.ReviewsReassignment, err = s.reassignReviews(ctx, exec, req.TeamName, req.UserID, res.Deactivated)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// removeMember deletes membership of the user and deactivates user removed from the primary team,
+// returns whether the user was deactivated
+func (s *TeamService) removeMember(ctx context.Context, exec sqlx.ExtContext, teamName, userID string) (bool, error) {
+	user, err := s.store.UserRepo().GetUserByID(ctx, exec, userID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.store.TeamRepo().DeleteMembership(ctx, exec, teamName, userID); err != nil {
+		return false, err
+	}
+
+	if user.TeamName != teamName {
+		return false, nil
+	}
+	if _, err := s.store.UserRepo().UpdateUserStatus(ctx, exec, userID, false); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// reassignReviews moves all OPEN reviews of deactivated user or reviews of PRs of the team otherwise
+func (s *TeamService) reassignReviews(ctx context.Context, exec sqlx.ExtContext, teamName, userID string, deactivated bool) (*models.ReviewsReassignment, error) {
+	if deactivated {
+		return s.reassigner.ReassignOpenReviews(ctx, exec, userID)
+	}
+	return s.reassigner.ReassignTeamReviews(ctx, exec, teamName, userID, models.AssignmentReasonMemberRemoved)
+}
+
+// ArchiveTeam archives the team in one transaction, members of archived team are not assigned to review PRs.
+// DRAFT and OPEN PRs reviewed by the team block the archive or are moved to the successor team by the policy,
+// repositories owned by the team are moved to the successor or lose the owner
+func (s *TeamService) ArchiveTeam(ctx context.Context, req *models.ArchiveTeamRequest) (*models.ArchiveTeamResponse, error) {
+	if req.TeamName == "" {
+		return nil, utils.NewBadRequestError("team_name is required", nil)
+	}
+	if req.Policy == "" {
+		req.Policy = models.TeamArchivePolicyBlock
+	}
+	switch req.Policy {
+	case models.TeamArchivePolicyBlock:
+		if req.SuccessorTeam != "" {
+			return nil, utils.NewBadRequestError("successor_team is allowed only with REASSIGN policy", nil)
+		}
+	case models.TeamArchivePolicyReassign:
+		if req.SuccessorTeam == "" {
+			return nil, utils.NewBadRequestError("successor_team is required for REASSIGN policy", nil)
+		}
+		if req.SuccessorTeam == req.TeamName {
+			return nil, utils.NewBadRequestError("team cannot be its own successor", nil)
+		}
+	default:
+		return nil, utils.NewBadRequestError("policy must be one of BLOCK, REASSIGN", nil)
+	}
+
+	res := &models.ArchiveTeamResponse{
+		TeamName:      req.TeamName,
+		Policy:        req.Policy,
+		SuccessorTeam: req.SuccessorTeam,
+		PullRequests:  make([]string, 0),
+		BulkReviewsReassignment: &models.BulkReviewsReassignment{
+			Reassigned:    make([]models.ReviewerReplacement, 0),
+			NotReassigned: make([]models.OpenReview, 0),
+		},
+	}
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		// wait for running assignments, they could pick members of the team
+		if err := s.store.PRRepo().LockAssignments(ctx, exec); err != nil {
+			return err
+		}
+
+		if err := s.checkActiveTeam(ctx, exec, req.TeamName); err != nil {
+			return err
+		}
+		if req.SuccessorTeam != "" {
+			if err := s.checkActiveTeam(ctx, exec, req.SuccessorTeam); err != nil {
+				return err
+			}
+		}
+
+		prIDs, err := s.store.PRRepo().GetActivePullRequestIDsByTeam(ctx, exec, req.TeamName)
+		if err != nil {
+			return err
+		}
+		if req.Policy == models.TeamArchivePolicyBlock && len(prIDs) > 0 {
+			blockedErr := utils.NewError(409, utils.ErrTeamHasOpenPRs, "team has DRAFT or OPEN PRs", nil)
+			blockedErr.Details = prIDs
+			return blockedErr
+		}
+
+		// archive first, so that replacements are not taken from the team through fallbacks
+		if res.ArchivedAt, err = s.store.TeamRepo().ArchiveTeam(ctx, exec, req.TeamName); err != nil {
+			return err
+		}
+		if err := s.store.OwnershipRepo().TransferRepositories(ctx, exec, req.TeamName, req.SuccessorTeam); err != nil {
+			return err
+		}
+		if req.Policy != models.TeamArchivePolicyReassign {
+			return nil
+		}
+
+		if res.BulkReviewsReassignment, err = s.reassigner.MoveToTeam(ctx, exec, prIDs, req.SuccessorTeam); err != nil {
+			return err
+		}
+		res.PullRequests = prIDs
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// checkActiveTeam returns not found error for unknown team and conflict for archived one
+func (s *TeamService) checkActiveTeam(ctx context.Context, exec sqlx.ExtContext, teamName string) error {
+	settings, err := s.store.TeamRepo().GetTeamSettings(ctx, exec, teamName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return utils.NewNotFoundError("resource not found", teamName)
+		}
+		return err
+	}
+	if settings.ArchivedAt != nil {
+		return utils.NewError(409, utils.ErrTeamArchived, "team is archived", teamName)
+	}
+	return nil
+}
+
 func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*models.Team, error) {
 	team, err := s.store.TeamRepo().GetTeamWithMembers(ctx, s.store.DB(), teamName)
 	if err != nil {
@@ -67,3 +431,289 @@ func (s *TeamService) GetTeam(ctx context.Context, teamName string) (*models.Tea
 
 	return team, nil
 }
+
+func (s *TeamService) GetSettings(ctx context.Context, teamName string) (*models.TeamSettings, error) {
+	settings, err := s.store.TeamRepo().GetTeamSettings(ctx, s.store.DB(), teamName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NewNotFoundError("resource not found", nil)
+		}
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (s *TeamService) SetSettings(ctx context.Context, settings *models.TeamSettings) (*models.TeamSettings, error) {
+	if err := validateSettings(settings); err != nil {
+		return nil, err
+	}
+
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		// fallback teams must exist, archived ones would never give a reviewer
+		for _, fallbackTeam := range settings.FallbackTeams {
+			fallbackSettings, err := s.store.TeamRepo().GetTeamSettings(ctx, exec, fallbackTeam)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					return utils.NewNotFoundError("fallback team not found", fallbackTeam)
+				}
+				return err
+			}
+			if fallbackSettings.ArchivedAt != nil {
+				return utils.NewError(409, utils.ErrTeamArchived, "fallback team is archived", fallbackTeam)
+			}
+		}
+
+		if err := s.store.TeamRepo().UpdateTeamSettings(ctx, exec, settings); err != nil {
+			if err == sql.ErrNoRows {
+				return utils.NewNotFoundError("resource not found", nil)
+			}
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.TeamRepo().GetTeamSettings(ctx, s.store.DB(), settings.TeamName)
+}
+
+func (s *TeamService) GetMergePolicy(ctx context.Context, teamName string) (*models.MergePolicy, error) {
+	policy, err := s.store.TeamRepo().GetMergePolicy(ctx, s.store.DB(), teamName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NewNotFoundError("resource not found", nil)
+		}
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (s *TeamService) SetMergePolicy(ctx context.Context, policy *models.MergePolicy) (*models.MergePolicy, error) {
+	if policy.MinApprovals < 0 {
+		return nil, utils.NewBadRequestError("min_approvals must not be negative", nil)
+	}
+	seen := make(map[string]bool, len(policy.RequiredApprovers))
+	for _, approver := range policy.RequiredApprovers {
+		if seen[approver] {
+			return nil, utils.NewBadRequestError("required_approvers must be unique", nil)
+		}
+		seen[approver] = true
+	}
+
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		// required approvers must exist
+		for _, approver := range policy.RequiredApprovers {
+			if _, err := s.store.UserRepo().GetUserByID(ctx, exec, approver); err != nil {
+				if err == sql.ErrNoRows {
+					return utils.NewNotFoundError("required approver not found", approver)
+				}
+				return err
+			}
+		}
+
+		if err := s.store.TeamRepo().UpdateMergePolicy(ctx, exec, policy); err != nil {
+			if err == sql.ErrNoRows {
+				return utils.NewNotFoundError("resource not found", nil)
+			}
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.TeamRepo().GetMergePolicy(ctx, s.store.DB(), policy.TeamName)
+}
+
+func (s *TeamService) GetSLAPolicy(ctx context.Context, teamName string) (*models.SLAPolicy, error) {
+	policy, err := s.store.TeamRepo().GetSLAPolicy(ctx, s.store.DB(), teamName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NewNotFoundError("resource not found", nil)
+		}
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// SetSLAPolicy replaces SLA policy of the team, escalation defaults to WEBHOOK
+func (s *TeamService) SetSLAPolicy(ctx context.Context, policy *models.SLAPolicy) (*models.SLAPolicy, error) {
+	if policy.ReviewSLAHours < 0 || policy.MergeSLAHours < 0 {
+		return nil, utils.NewBadRequestError("SLA hours must not be negative", nil)
+	}
+	switch policy.Escalation {
+	case "":
+		policy.Escalation = models.SLAEscalationWebhook
+	case models.SLAEscalationAddReviewer, models.SLAEscalationReassign, models.SLAEscalationWebhook:
+	default:
+		return nil, utils.NewBadRequestError("escalation must be one of ADD_REVIEWER, REASSIGN, WEBHOOK", nil)
+	}
+
+	if err := s.store.TeamRepo().UpdateSLAPolicy(ctx, s.store.DB(), policy); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NewNotFoundError("resource not found", nil)
+		}
+		return nil, err
+	}
+
+	return s.store.TeamRepo().GetSLAPolicy(ctx, s.store.DB(), policy.TeamName)
+}
+
+// SetMembership changes role of the team member and whether member reviews PRs for the team,
+// OPEN reviews of the member are kept
+func (s *TeamService) SetMembership(ctx context.Context, req *models.SetMembershipRequest) (*models.TeamMembership, error) {
+	if req.Role == "" {
+		req.Role = models.MembershipRoleMember
+	}
+	if !validRole(req.Role) {
+		return nil, utils.NewBadRequestError("role must be one of MEMBER, LEAD", nil)
+	}
+
+	membership, err := s.store.TeamRepo().UpdateMembership(ctx, s.store.DB(), &models.TeamMembership{
+		UserID:   req.UserID,
+		TeamName: req.TeamName,
+		Role:     req.Role,
+		IsActive: req.IsActive,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NewNotFoundError("user is not a member of the team", nil)
+		}
+		return nil, err
+	}
+
+	return membership, nil
+}
+
+// DeactivateUsers deactivates listed team members and redistributes their OPEN reviews in one transaction.
+// Members of the primary team are deactivated and lose all OPEN reviews, members of other teams are deactivated
+// only in the team and keep reviews of PRs of other teams
+func (s *TeamService) DeactivateUsers(ctx context.Context, req *models.DeactivateTeamUsersRequest) (*models.DeactivateTeamUsersResponse, error) {
+	if req.TeamName == "" {
+		return nil, utils.NewBadRequestError("team_name is required", nil)
+	}
+	if len(req.UserIDs) == 0 {
+		return nil, utils.NewBadRequestError("user_ids are required", nil)
+	}
+	seen := make(map[string]bool, len(req.UserIDs))
+	for _, userID := range req.UserIDs {
+		if seen[userID] {
+			return nil, utils.NewBadRequestError("user_ids must be unique", nil)
+		}
+		seen[userID] = true
+	}
+
+	res := &models.DeactivateTeamUsersResponse{TeamName: req.TeamName}
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		if _, err := s.store.TeamRepo().GetTeamSettings(ctx, exec, req.TeamName); err != nil {
+			if err == sql.ErrNoRows {
+				return utils.NewNotFoundError("resource not found", nil)
+			}
+			return err
+		}
+
+		// wait for running assignments, they could pick users being deactivated
+		if err := s.store.PRRepo().LockAssignments(ctx, exec); err != nil {
+			return err
+		}
+
+		deactivated, err := s.store.UserRepo().DeactivateTeamUsers(ctx, exec, req.TeamName, req.UserIDs)
+		if err != nil {
+			return err
+		}
+		if len(deactivated) != len(req.UserIDs) {
+			return utils.NewNotFoundError("user is not a member of the team", nil)
+		}
+		res.Deactivated = deactivated
+
+		res.BulkReviewsReassignment, err = s.reassignDeactivatedReviews(ctx, exec, req.TeamName, deactivated)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// reassignDeactivatedReviews moves all OPEN reviews of users deactivated everywhere at once,
+// users deactivated only in the team lose reviews of PRs of the team
+func (s *TeamService) reassignDeactivatedReviews(ctx context.Context, exec sqlx.ExtContext, teamName string, deactivated []models.User) (*models.BulkReviewsReassignment, error) {
+	primaryIDs := make([]string, 0, len(deactivated))
+	memberIDs := make([]string, 0)
+	for _, user := range deactivated {
+		if user.TeamName == teamName {
+			primaryIDs = append(primaryIDs, user.UserID)
+		} else {
+			memberIDs = append(memberIDs, user.UserID)
+		}
+	}
+
+	res := &models.BulkReviewsReassignment{
+		Reassigned:    make([]models.ReviewerReplacement, 0),
+		NotReassigned: make([]models.OpenReview, 0),
+	}
+	if len(primaryIDs) > 0 {
+		primaryRes, err := s.reassigner.ReassignOpenReviewsOfUsers(ctx, exec, primaryIDs)
+		if err != nil {
+			return nil, err
+		}
+		res.Reassigned = append(res.Reassigned, primaryRes.Reassigned...)
+		res.NotReassigned = append(res.NotReassigned, primaryRes.NotReassigned...)
+	}
+	if len(memberIDs) > 0 {
+		memberRes, err := s.reassigner.ReassignTeamReviewsOfUsers(ctx, exec, teamName, memberIDs)
+		if err != nil {
+			return nil, err
+		}
+		res.Reassigned = append(res.Reassigned, memberRes.Reassigned...)
+		res.NotReassigned = append(res.NotReassigned, memberRes.NotReassigned...)
+	}
+	return res, nil
+}
+
+// validRole reports whether role is known, empty role means MEMBER
+func validRole(role models.MembershipRole) bool {
+	switch role {
+	case "", models.MembershipRoleMember, models.MembershipRoleLead:
+		return true
+	}
+	return false
+}
+
+func validateSettings(settings *models.TeamSettings) error {
+	if settings.MinReviewers < 0 || settings.MaxReviewers < 1 || settings.MaxReviewers < settings.MinReviewers {
+		return utils.NewBadRequestError("reviewers range must satisfy 0 <= min_reviewers <= max_reviewers and max_reviewers >= 1", nil)
+	}
+
+	switch settings.UnderstaffedPolicy {
+	case models.UnderstaffedPolicyFail, models.UnderstaffedPolicyAssignFewer:
+	case models.UnderstaffedPolicyFallback:
+		if len(settings.FallbackTeams) == 0 {
+			return utils.NewBadRequestError("fallback_teams are required for FALLBACK policy", nil)
+		}
+	default:
+		return utils.NewBadRequestError("unknown understaffed_policy", nil)
+	}
+
+	seen := make(map[string]bool, len(settings.FallbackTeams))
+	for _, fallbackTeam := range settings.FallbackTeams {
+		if fallbackTeam == settings.TeamName {
+			return utils.NewBadRequestError("team cannot be fallback for itself", nil)
+		}
+		if seen[fallbackTeam] {
+			return utils.NewBadRequestError("fallback_teams must be unique", nil)
+		}
+		seen[fallbackTeam] = true
+	}
+
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/Negat1v9/pr-review-service/internal/models"
@@ -33,13 +35,51 @@ func (h *UserHanler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedUser, err := h.service.SetUserActiveStatus(ctx, req.UserID, req.IsActive)
+	res, err := h.service.SetUserActiveStatus(ctx, req.UserID, req.IsActive)
 	if err != nil {
 		h.log.Errorf("failed to set user active status: %v", err)
 		utils.WriteErrResponse(w, err)
 		return
 	}
 
+	utils.WriteJsonResponse(w, http.StatusOK, "", res)
+}
+
+func (h *UserHanler) SetReviewLimit(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+	var req models.SetUserReviewLimitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	updatedUser, err := h.service.SetReviewLimit(ctx, req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		h.log.Errorf("failed to set user review limit: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "user", updatedUser)
+}
+
+func (h *UserHanler) SetSkills(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+	var req models.SetUserSkillsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	updatedUser, err := h.service.SetSkills(ctx, req.UserID, req.Skills)
+	if err != nil {
+		h.log.Errorf("failed to set user skills: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
 	utils.WriteJsonResponse(w, http.StatusOK, "user", updatedUser)
 }
 
@@ -62,3 +102,86 @@ func (h *UserHanler) GetReview(w http.ResponseWriter, r *http.Request) {
 
 	utils.WriteJsonResponse(w, http.StatusOK, "", userReviews)
 }
+
+func (h *UserHanler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		utils.WriteErrResponse(w, utils.NewNotFoundError("resource not found", nil))
+		return
+	}
+
+	user, err := h.service.GetUser(ctx, userID)
+	if err != nil {
+		h.log.Errorf("failed to get user: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "user", user)
+}
+
+func (h *UserHanler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+
+	filter, err := parseListFilter(r.URL.Query())
+	if err != nil {
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	page, err := h.service.ListUsers(ctx, filter, r.URL.Query().Get("cursor"))
+	if err != nil {
+		h.log.Errorf("failed to list users: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "", page)
+}
+
+func (h *UserHanler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second*10)
+	defer cancel()
+	var req models.UpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrResponse(w, utils.NewBadRequestError("invalid request body", nil))
+		return
+	}
+
+	updatedUser, err := h.service.UpdateUser(ctx, &req)
+	if err != nil {
+		h.log.Errorf("failed to update user: %v", err)
+		utils.WriteErrResponse(w, err)
+		return
+	}
+
+	utils.WriteJsonResponse(w, http.StatusOK, "user", updatedUser)
+}
+
+func parseListFilter(query url.Values) (*models.UserFilter, error) {
+	filter := &models.UserFilter{
+		TeamName:   query.Get("team_name"),
+		NamePrefix: query.Get("name_prefix"),
+	}
+
+	if isActive := query.Get("is_active"); isActive != "" {
+		active, err := strconv.ParseBool(isActive)
+		if err != nil {
+			return nil, utils.NewBadRequestError("is_active must be true or false", nil)
+		}
+		filter.IsActive = &active
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, utils.NewBadRequestError("limit must be a number", nil)
+		}
+		filter.Limit = n
+	}
+	return filter, nil
+}
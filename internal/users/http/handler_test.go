@@ -2,13 +2,16 @@ package userhttp
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/Negat1v9/pr-review-service/config"
 	"github.com/Negat1v9/pr-review-service/internal/models"
+	prservice "github.com/Negat1v9/pr-review-service/internal/pullRequest/service"
 	mock_store "github.com/Negat1v9/pr-review-service/internal/store/mock"
 	userservice "github.com/Negat1v9/pr-review-service/internal/users/service"
 	"github.com/Negat1v9/pr-review-service/pkg/logger"
@@ -22,14 +25,27 @@ func TestSetIsActive(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockTeamRepo := mock_store.NewMockTeamRepository(ctrl)
+	mockPRRepo := mock_store.NewMockPullRequestRepository(ctrl)
 	mockStore := mock_store.NewMockStore(ctrl)
 	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+	mockStore.EXPECT().TeamRepo().Return(mockTeamRepo).AnyTimes()
+	mockStore.EXPECT().PRRepo().Return(mockPRRepo).AnyTimes()
+	mockPRRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	db := sqlx.DB{}
 	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+
+	selector, err := prservice.NewReviewerSelector(config.AssignmentConfig{Strategy: prservice.StrategyLeastLoaded})
+	require.NoError(t, err)
 
 	doReq := func(body any) *httptest.ResponseRecorder {
-		service := userservice.NewUserService(mockStore)
+		service := userservice.NewUserService(mockStore, prservice.NewPRService(mockStore, selector))
 		handler := NewUserHandler(logger.NewLogger("local"), service)
 		userMux := UserRouter(handler)
 
@@ -56,8 +72,10 @@ func TestSetIsActive(t *testing.T) {
 			TeamName: "team-1",
 		}
 
+		mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil)
 		mockUserRepo.EXPECT().UpdateUserStatus(gomock.Any(), gomock.Any(), req.UserID, false).
 			Return(&updatedUser, nil)
+		mockPRRepo.EXPECT().GetOpenPullRequestIDsByReviewer(gomock.Any(), gomock.Any(), "user-1").Return([]string{}, nil)
 
 		rr := doReq(req)
 		r := map[string]any{}
@@ -67,6 +85,49 @@ func TestSetIsActive(t *testing.T) {
 		require.Equal(t, false, r["user"].(map[string]any)["is_active"])
 	})
 
+	t.Run("Deactivation moves open reviews", func(t *testing.T) {
+		author := models.User{UserID: "author", Username: "author", TeamName: "team-1", IsActive: true}
+		deactivated := models.User{UserID: "user-1", Username: "username-1", TeamName: "team-1", IsActive: false}
+		prWithCandidate := models.PullRequest{ID: "pr-1", AuthorID: "author", Status: models.PullRequestStatusOpen, AssignedReviewers: []string{"user-1", "user-2"}}
+		prWithoutCandidate := models.PullRequest{ID: "pr-2", AuthorID: "author", Status: models.PullRequestStatusOpen, AssignedReviewers: []string{"user-1", "user-3"}}
+		settings := models.TeamSettings{TeamName: "team-1", MaxReviewers: 2, UnderstaffedPolicy: models.UnderstaffedPolicyAssignFewer}
+
+		mockPRRepo.EXPECT().LockAssignments(gomock.Any(), gomock.Any()).Return(nil)
+		mockUserRepo.EXPECT().UpdateUserStatus(gomock.Any(), gomock.Any(), "user-1", false).Return(&deactivated, nil)
+		mockPRRepo.EXPECT().GetOpenPullRequestIDsByReviewer(gomock.Any(), gomock.Any(), "user-1").Return([]string{"pr-1", "pr-2"}, nil)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "author").Return(&author, nil).Times(2)
+
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-1").Return(&prWithCandidate, nil)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return([]models.ReviewCandidate{
+			{UserID: "author", TeamName: "team-1", IsActive: true},
+			{UserID: "user-1", TeamName: "team-1", IsActive: false},
+			{UserID: "user-2", TeamName: "team-1", IsActive: true},
+			{UserID: "user-3", TeamName: "team-1", IsActive: true},
+		}, nil)
+		mockPRRepo.EXPECT().DeleteAssignedReviewer(gomock.Any(), gomock.Any(), "pr-1", "user-1").Return(nil)
+		mockPRRepo.EXPECT().AssignReviewer(gomock.Any(), gomock.Any(), "pr-1", "user-3").Return(nil)
+
+		// user-2 is the only active member left and already reviews pr-2
+		mockPRRepo.EXPECT().GetPullRequestByID(gomock.Any(), gomock.Any(), "pr-2").Return(&prWithoutCandidate, nil)
+		mockTeamRepo.EXPECT().GetTeamReviewCandidates(gomock.Any(), gomock.Any(), "team-1").Return([]models.ReviewCandidate{
+			{UserID: "author", TeamName: "team-1", IsActive: true},
+			{UserID: "user-1", TeamName: "team-1", IsActive: false},
+			{UserID: "user-2", TeamName: "team-1", IsActive: false},
+			{UserID: "user-3", TeamName: "team-1", IsActive: true},
+		}, nil)
+		mockTeamRepo.EXPECT().GetTeamSettings(gomock.Any(), gomock.Any(), "team-1").Return(&settings, nil)
+
+		rr := doReq(models.SetUserActiveStatusRequest{UserID: "user-1", IsActive: false})
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		reassigned := r["reassigned"].([]any)
+		require.Equal(t, 1, len(reassigned))
+		require.Equal(t, "user-3", reassigned[0].(map[string]any)["new_reviewer_id"])
+		require.Equal(t, []any{"pr-2"}, r["not_reassigned"])
+	})
+
 	t.Run("Not found user", func(t *testing.T) {
 		req := models.SetUserActiveStatusRequest{
 			UserID:   "user-2",
@@ -84,6 +145,122 @@ func TestSetIsActive(t *testing.T) {
 	})
 }
 
+func TestSetReviewLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+
+	doReq := func(body any) *httptest.ResponseRecorder {
+		service := userservice.NewUserService(mockStore, nil)
+		handler := NewUserHandler(logger.NewLogger("local"), service)
+		userMux := UserRouter(handler)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", "/setReviewLimit", bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		userMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Set review limit", func(t *testing.T) {
+		limit := 3
+		req := models.SetUserReviewLimitRequest{UserID: "user-1", MaxOpenReviews: &limit}
+		updatedUser := models.User{UserID: "user-1", Username: "username-1", IsActive: true, TeamName: "team-1", MaxOpenReviews: &limit}
+
+		mockUserRepo.EXPECT().UpdateUserReviewLimit(gomock.Any(), gomock.Any(), "user-1", &limit).Return(&updatedUser, nil)
+
+		rr := doReq(req)
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, float64(3), r["user"].(map[string]any)["max_open_reviews"])
+	})
+
+	t.Run("Negative limit", func(t *testing.T) {
+		limit := -1
+		rr := doReq(models.SetUserReviewLimitRequest{UserID: "user-1", MaxOpenReviews: &limit})
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("Not found user", func(t *testing.T) {
+		mockUserRepo.EXPECT().UpdateUserReviewLimit(gomock.Any(), gomock.Any(), "user-2", nil).Return(nil, sql.ErrNoRows)
+
+		rr := doReq(models.SetUserReviewLimitRequest{UserID: "user-2"})
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestSetSkills(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+
+	doReq := func(body any) *httptest.ResponseRecorder {
+		service := userservice.NewUserService(mockStore, nil)
+		handler := NewUserHandler(logger.NewLogger("local"), service)
+		userMux := UserRouter(handler)
+
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest("POST", "/setSkills", bytes.NewBuffer(data))
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		userMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("Set skills", func(t *testing.T) {
+		user := models.User{UserID: "user-1", Username: "username-1", IsActive: true, TeamName: "team-1"}
+
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "user-1").Return(&user, nil)
+		// skills are normalized before saving
+		mockUserRepo.EXPECT().SetUserSkills(gomock.Any(), gomock.Any(), "user-1", []string{"go", "sql"}).Return(nil)
+
+		rr := doReq(models.SetUserSkillsRequest{UserID: "user-1", Skills: []string{" Go", "sql", "go", ""}})
+		require.Equal(t, http.StatusOK, rr.Code)
+		r := map[string]any{}
+		err := json.Unmarshal(rr.Body.Bytes(), &r)
+		require.NoError(t, err)
+		require.Equal(t, []any{"go", "sql"}, r["user"].(map[string]any)["skills"])
+	})
+
+	t.Run("Not found user", func(t *testing.T) {
+		mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+				return fn(ctx, &db)
+			},
+		)
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "user-2").Return(nil, sql.ErrNoRows)
+
+		rr := doReq(models.SetUserSkillsRequest{UserID: "user-2", Skills: []string{"go"}})
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
 func TestGetReview(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -96,7 +273,7 @@ func TestGetReview(t *testing.T) {
 	mockStore.EXPECT().DB().Return(&db).AnyTimes()
 
 	doReq := func(userID string) *httptest.ResponseRecorder {
-		service := userservice.NewUserService(mockStore)
+		service := userservice.NewUserService(mockStore, nil)
 		handler := NewUserHandler(logger.NewLogger("local"), service)
 		userMux := UserRouter(handler)
 
@@ -157,3 +334,156 @@ func TestGetReview(t *testing.T) {
 		require.Equal(t, "resource not found", r["error"].(map[string]any)["message"])
 	})
 }
+
+func TestDirectory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock_store.NewMockUserRepository(ctrl)
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().UserRepo().Return(mockUserRepo).AnyTimes()
+
+	db := sqlx.DB{}
+	mockStore.EXPECT().DB().Return(&db).AnyTimes()
+	mockStore.EXPECT().DoTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context, sqlx.ExtContext) error) error {
+			return fn(ctx, &db)
+		},
+	).AnyTimes()
+
+	doReq := func(method, target string, body any) *httptest.ResponseRecorder {
+		service := userservice.NewUserService(mockStore, nil)
+		handler := NewUserHandler(logger.NewLogger("local"), service)
+		userMux := UserRouter(handler)
+
+		var buf bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&buf).Encode(body))
+		}
+		req, err := http.NewRequest(method, target, &buf)
+		require.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		userMux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	alice := models.User{UserID: "u1", Username: "alice", TeamName: "backend", IsActive: true, Email: "alice@example.com"}
+	bob := models.User{UserID: "u2", Username: "bob", TeamName: "backend", IsActive: true}
+
+	t.Run("Get user", func(t *testing.T) {
+		user := alice
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "u1").Return(&user, nil)
+		mockUserRepo.EXPECT().GetUserSkills(gomock.Any(), gomock.Any(), "u1").Return([]string{"go"}, nil)
+		mockUserRepo.EXPECT().GetUsersForgeLogins(gomock.Any(), gomock.Any(), []string{"u1"}).
+			Return(map[string]map[string]string{"u1": {"github": "alice"}}, nil)
+
+		rr := doReq("GET", "/get?user_id=u1", nil)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var r map[string]models.User
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, "alice@example.com", r["user"].Email)
+		require.Equal(t, []string{"go"}, r["user"].Skills)
+		require.Equal(t, map[string]string{"github": "alice"}, r["user"].ForgeLogins)
+	})
+
+	t.Run("Get unknown user", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), "unknown").Return(nil, sql.ErrNoRows)
+
+		require.Equal(t, http.StatusNotFound, doReq("GET", "/get?user_id=unknown", nil).Code)
+		require.Equal(t, http.StatusNotFound, doReq("GET", "/get", nil).Code)
+	})
+
+	t.Run("List pages", func(t *testing.T) {
+		active := true
+		mockUserRepo.EXPECT().ListUsers(gomock.Any(), gomock.Any(), &models.UserFilter{TeamName: "backend", IsActive: &active, NamePrefix: "a", Limit: 2}).
+			Return([]models.User{alice, bob}, nil)
+		mockUserRepo.EXPECT().GetUsersForgeLogins(gomock.Any(), gomock.Any(), []string{"u1"}).
+			Return(map[string]map[string]string{}, nil)
+
+		rr := doReq("GET", "/list?team_name=backend&is_active=true&name_prefix=a&limit=1", nil)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var page models.UserPage
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+		require.Len(t, page.Users, 1)
+		require.NotEmpty(t, page.NextCursor)
+
+		mockUserRepo.EXPECT().ListUsers(gomock.Any(), gomock.Any(), &models.UserFilter{After: "u1", Limit: 21}).
+			Return([]models.User{bob}, nil)
+		mockUserRepo.EXPECT().GetUsersForgeLogins(gomock.Any(), gomock.Any(), []string{"u2"}).
+			Return(map[string]map[string]string{}, nil)
+
+		rr = doReq("GET", "/list?cursor="+page.NextCursor, nil)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		page = models.UserPage{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+		require.Equal(t, "u2", page.Users[0].UserID)
+		require.Empty(t, page.NextCursor)
+	})
+
+	t.Run("Invalid list filters", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, doReq("GET", "/list?is_active=maybe", nil).Code)
+		require.Equal(t, http.StatusBadRequest, doReq("GET", "/list?limit=1000", nil).Code)
+		require.Equal(t, http.StatusBadRequest, doReq("GET", "/list?cursor=%25%25", nil).Code)
+	})
+
+	t.Run("Update profile", func(t *testing.T) {
+		name := "Alice L."
+		updated := alice
+		updated.DisplayName = name
+		req := models.UpdateUserRequest{UserID: "u1", DisplayName: &name, ForgeLogins: map[string]string{" GitHub ": "alice"}}
+		logins := map[string]string{"github": "alice"}
+
+		mockUserRepo.EXPECT().UpdateUserProfile(gomock.Any(), gomock.Any(), &models.UpdateUserRequest{UserID: "u1", DisplayName: &name, ForgeLogins: logins}).
+			Return(&updated, nil)
+		mockUserRepo.EXPECT().GetTakenForgeLogins(gomock.Any(), gomock.Any(), "u1", logins).Return([]string{}, nil)
+		mockUserRepo.EXPECT().SetUserForgeLogins(gomock.Any(), gomock.Any(), "u1", logins).Return(nil)
+		mockUserRepo.EXPECT().GetUserSkills(gomock.Any(), gomock.Any(), "u1").Return([]string{}, nil)
+		mockUserRepo.EXPECT().GetUsersForgeLogins(gomock.Any(), gomock.Any(), []string{"u1"}).
+			Return(map[string]map[string]string{"u1": logins}, nil)
+
+		rr := doReq("PATCH", "/update", req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var r map[string]models.User
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, name, r["user"].DisplayName)
+		require.Equal(t, logins, r["user"].ForgeLogins)
+	})
+
+	t.Run("Forge login of another user", func(t *testing.T) {
+		logins := map[string]string{"github": "alice"}
+		user := bob
+		mockUserRepo.EXPECT().UpdateUserProfile(gomock.Any(), gomock.Any(), gomock.Any()).Return(&user, nil)
+		mockUserRepo.EXPECT().GetTakenForgeLogins(gomock.Any(), gomock.Any(), "u2", logins).Return([]string{"github"}, nil)
+
+		rr := doReq("PATCH", "/update", models.UpdateUserRequest{UserID: "u2", ForgeLogins: logins})
+
+		require.Equal(t, http.StatusConflict, rr.Code)
+		r := map[string]map[string]any{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &r))
+		require.Equal(t, "FORGE_LOGIN_TAKEN", r["error"]["code"])
+		require.Equal(t, []any{"github"}, r["error"]["details"])
+	})
+
+	t.Run("Update unknown user", func(t *testing.T) {
+		mockUserRepo.EXPECT().UpdateUserProfile(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows)
+
+		rr := doReq("PATCH", "/update", models.UpdateUserRequest{UserID: "unknown"})
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("Invalid profile", func(t *testing.T) {
+		empty := ""
+		email := "Alice <alice@example.com>"
+		require.Equal(t, http.StatusBadRequest, doReq("PATCH", "/update", models.UpdateUserRequest{}).Code)
+		require.Equal(t, http.StatusBadRequest, doReq("PATCH", "/update", models.UpdateUserRequest{UserID: "u1", Username: &empty}).Code)
+		require.Equal(t, http.StatusBadRequest, doReq("PATCH", "/update", models.UpdateUserRequest{UserID: "u1", Email: &email}).Code)
+		require.Equal(t, http.StatusBadRequest, doReq("PATCH", "/update", models.UpdateUserRequest{UserID: "u1", ForgeLogins: map[string]string{"github": ""}}).Code)
+		require.Equal(t, http.StatusBadRequest, doReq("PATCH", "/update", models.UpdateUserRequest{UserID: "u1", ForgeLogins: map[string]string{"github": "a", "GitHub": "b"}}).Code)
+	})
+}
@@ -6,7 +6,12 @@ func UserRouter(h *UserHanler) http.Handler {
 	handler := http.NewServeMux()
 
 	handler.HandleFunc("POST /setIsActive", h.SetIsActive)
+	handler.HandleFunc("POST /setReviewLimit", h.SetReviewLimit)
+	handler.HandleFunc("POST /setSkills", h.SetSkills)
 	handler.HandleFunc("GET /getReview", h.GetReview)
+	handler.HandleFunc("GET /get", h.Get)
+	handler.HandleFunc("GET /list", h.List)
+	handler.HandleFunc("PATCH /update", h.Update)
 
 	return handler
 }
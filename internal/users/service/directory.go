@@ -0,0 +1,172 @@
+package userservice
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/Negat1v9/pr-review-service/internal/models"
+	"github.com/Negat1v9/pr-review-service/pkg/utils"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// GetUser returns user with profile, skills and forge logins
+func (s *UserService) GetUser(ctx context.Context, userID string) (*models.User, error) {
+	user, err := s.store.UserRepo().GetUserByID(ctx, s.store.DB(), userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, utils.NewNotFoundError("resource not found", nil)
+		}
+		return nil, err
+	}
+
+	if err := s.loadProfile(ctx, s.store.DB(), user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ListUsers returns page of users by the filter ordered by user_id, cursor is the next_cursor of the previous page
+func (s *UserService) ListUsers(ctx context.Context, filter *models.UserFilter, cursor string) (*models.UserPage, error) {
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = defaultPageSize
+	case filter.Limit < 0 || filter.Limit > maxPageSize:
+		return nil, utils.NewBadRequestError(fmt.Sprintf("limit must be between 1 and %d", maxPageSize), nil)
+	}
+
+	if cursor != "" {
+		after, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil || len(after) == 0 {
+			return nil, utils.NewBadRequestError("invalid cursor", nil)
+		}
+		filter.After = string(after)
+	}
+
+	pageSize := filter.Limit
+	// one more user shows there is a next page
+	filter.Limit++
+	users, err := s.store.UserRepo().ListUsers(ctx, s.store.DB(), filter)
+	if err != nil {
+		return nil, fmt.Errorf("ListUsers: unable to list users: %v", err)
+	}
+
+	page := &models.UserPage{Users: users}
+	if len(users) > pageSize {
+		page.Users = users[:pageSize]
+		page.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(users[pageSize-1].UserID))
+	}
+	if len(page.Users) == 0 {
+		return page, nil
+	}
+
+	userIDs := make([]string, 0, len(page.Users))
+	for _, user := range page.Users {
+		userIDs = append(userIDs, user.UserID)
+	}
+	logins, err := s.store.UserRepo().GetUsersForgeLogins(ctx, s.store.DB(), userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("ListUsers: unable to get forge logins: %v", err)
+	}
+	for i := range page.Users {
+		page.Users[i].ForgeLogins = logins[page.Users[i].UserID]
+	}
+	return page, nil
+}
+
+// UpdateUser changes profile of the user, team and status are changed by their own methods
+func (s *UserService) UpdateUser(ctx context.Context, req *models.UpdateUserRequest) (*models.User, error) {
+	if err := validateProfile(req); err != nil {
+		return nil, err
+	}
+
+	var user *models.User
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		var err error
+		user, err = s.store.UserRepo().UpdateUserProfile(ctx, exec, req)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return utils.NewNotFoundError("resource not found", nil)
+			}
+			return err
+		}
+
+		if req.ForgeLogins != nil {
+			taken, err := s.store.UserRepo().GetTakenForgeLogins(ctx, exec, req.UserID, req.ForgeLogins)
+			if err != nil {
+				return err
+			}
+			if len(taken) > 0 {
+				takenErr := utils.NewError(409, utils.ErrForgeLoginTaken, "forge login belongs to another user", nil)
+				takenErr.Details = taken
+				return takenErr
+			}
+
+			if err := s.store.UserRepo().SetUserForgeLogins(ctx, exec, req.UserID, req.ForgeLogins); err != nil {
+				return err
+			}
+		}
+
+		return s.loadProfile(ctx, exec, user)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// loadProfile fills skills and forge logins of the user
+func (s *UserService) loadProfile(ctx context.Context, exec sqlx.ExtContext, user *models.User) error {
+	skills, err := s.store.UserRepo().GetUserSkills(ctx, exec, user.UserID)
+	if err != nil {
+		return fmt.Errorf("unable to get user skills: %v", err)
+	}
+	user.Skills = skills
+
+	logins, err := s.store.UserRepo().GetUsersForgeLogins(ctx, exec, []string{user.UserID})
+	if err != nil {
+		return fmt.Errorf("unable to get forge logins: %v", err)
+	}
+	user.ForgeLogins = logins[user.UserID]
+	return nil
+}
+
+// validateProfile checks the request and stores forges in lower case
+func validateProfile(req *models.UpdateUserRequest) error {
+	if req.UserID == "" {
+		return utils.NewBadRequestError("user_id is required", nil)
+	}
+	if req.Username != nil && strings.TrimSpace(*req.Username) == "" {
+		return utils.NewBadRequestError("username must not be empty", nil)
+	}
+	if req.Email != nil && *req.Email != "" {
+		if addr, err := mail.ParseAddress(*req.Email); err != nil || addr.Address != *req.Email {
+			return utils.NewBadRequestError("invalid email", nil)
+		}
+	}
+
+	if req.ForgeLogins == nil {
+		return nil
+	}
+	logins := make(map[string]string, len(req.ForgeLogins))
+	for forge, login := range req.ForgeLogins {
+		forge = strings.ToLower(strings.TrimSpace(forge))
+		if forge == "" || login == "" {
+			return utils.NewBadRequestError("forge and login must not be empty", nil)
+		}
+		if _, ok := logins[forge]; ok {
+			return utils.NewBadRequestError("forge is listed twice", forge)
+		}
+		logins[forge] = login
+	}
+	req.ForgeLogins = logins
+	return nil
+}
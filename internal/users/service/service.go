@@ -7,20 +7,67 @@ import (
 	"github.com/Negat1v9/pr-review-service/internal/models"
 	"github.com/Negat1v9/pr-review-service/internal/store"
 	"github.com/Negat1v9/pr-review-service/pkg/utils"
+	"github.com/jmoiron/sqlx"
 )
 
+// ReviewReassigner moves OPEN reviews of the reviewer to other team members
+type ReviewReassigner interface {
+	ReassignOpenReviews(ctx context.Context, exec sqlx.ExtContext, reviewerID string) (*models.ReviewsReassignment, error)
+}
+
 type UserService struct {
-	store store.Store
+	store      store.Store
+	reassigner ReviewReassigner
 }
 
-func NewUserService(store store.Store) *UserService {
+func NewUserService(store store.Store, reassigner ReviewReassigner) *UserService {
 	return &UserService{
-		store: store,
+		store:      store,
+		reassigner: reassigner,
+	}
+}
+
+// SetUserActiveStatus updates user status, OPEN reviews of deactivated user
+// are moved to other members in the same transaction
+func (s *UserService) SetUserActiveStatus(ctx context.Context, userID string, isActive bool) (*models.SetUserActiveStatusResponse, error) {
+	res := &models.SetUserActiveStatusResponse{}
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		// wait for running assignments so no review is assigned to the user after the reviews are moved
+		if !isActive {
+			if err := s.store.PRRepo().LockAssignments(ctx, exec); err != nil {
+				return err
+			}
+		}
+
+		updatedUser, err := s.store.UserRepo().UpdateUserStatus(ctx, exec, userID, isActive)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return utils.NewNotFoundError("resource not found", nil)
+			}
+			return err
+		}
+		res.User = updatedUser
+
+		if isActive {
+			return nil
+		}
+
+		res.ReviewsReassignment, err = s.reassigner.ReassignOpenReviews(ctx, exec, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return res, nil
 }
 
-func (s *UserService) SetUserActiveStatus(ctx context.Context, userID string, isActive bool) (*models.User, error) {
-	updatedUser, err := s.store.UserRepo().UpdateUserStatus(ctx, s.store.DB(), userID, isActive)
+// SetReviewLimit sets the maximum of OPEN PRs user can review, nil removes the limit
+func (s *UserService) SetReviewLimit(ctx context.Context, userID string, maxOpenReviews *int) (*models.User, error) {
+	if maxOpenReviews != nil && *maxOpenReviews < 0 {
+		return nil, utils.NewBadRequestError("max_open_reviews must not be negative", nil)
+	}
+
+	updatedUser, err := s.store.UserRepo().UpdateUserReviewLimit(ctx, s.store.DB(), userID, maxOpenReviews)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, utils.NewNotFoundError("resource not found", nil)
@@ -30,6 +77,31 @@ func (s *UserService) SetUserActiveStatus(ctx context.Context, userID string, is
 	return updatedUser, nil
 }
 
+// SetSkills replaces skills of the user, skills are stored in lower case
+func (s *UserService) SetSkills(ctx context.Context, userID string, skills []string) (*models.User, error) {
+	skills = utils.NormalizeTags(skills)
+
+	var user *models.User
+	err := s.store.DoTx(ctx, func(ctx context.Context, exec sqlx.ExtContext) error {
+		var txErr error
+		user, txErr = s.store.UserRepo().GetUserByID(ctx, exec, userID)
+		if txErr != nil {
+			if txErr == sql.ErrNoRows {
+				return utils.NewNotFoundError("resource not found", nil)
+			}
+			return txErr
+		}
+
+		return s.store.UserRepo().SetUserSkills(ctx, exec, userID, skills)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	user.Skills = skills
+	return user, nil
+}
+
 func (s *UserService) GetReview(ctx context.Context, userID string) (*models.UserReviews, error) {
 	userReviews, err := s.store.UserRepo().GetUserReviews(ctx, s.store.DB(), userID)
 
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_assigned_reviewers_pull_request_id;
+DROP INDEX IF EXISTS idx_pull_requests_created_at;
@@ -0,0 +1,5 @@
+-- keyset pagination of PRs list
+CREATE INDEX IF NOT EXISTS idx_pull_requests_created_at ON pull_requests(created_at, pull_request_id);
+
+-- reviewers of PR are aggregated for every listed PR
+CREATE INDEX IF NOT EXISTS idx_assigned_reviewers_pull_request_id ON assigned_reviewers(pull_request_id);
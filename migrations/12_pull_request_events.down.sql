@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS pull_request_events_append_only ON pull_request_events;
+DROP FUNCTION IF EXISTS forbid_pull_request_events_change();
+
+DROP TABLE IF EXISTS pull_request_events;
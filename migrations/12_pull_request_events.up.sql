@@ -0,0 +1,21 @@
+-- append-only timeline of PR changes, written in the transaction of the change
+CREATE TABLE IF NOT EXISTS pull_request_events (
+    event_id BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id),
+    event_type VARCHAR(30) NOT NULL,
+    actor TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_events_pr ON pull_request_events(pull_request_id, event_id);
+
+CREATE OR REPLACE FUNCTION forbid_pull_request_events_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'pull_request_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER pull_request_events_append_only
+    BEFORE UPDATE OR DELETE ON pull_request_events
+    FOR EACH ROW EXECUTE FUNCTION forbid_pull_request_events_change();
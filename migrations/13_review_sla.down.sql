@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS sla_notices;
+
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS sla_escalation,
+    DROP COLUMN IF EXISTS merge_sla_hours,
+    DROP COLUMN IF EXISTS review_sla_hours;
+
+ALTER TABLE assigned_reviewers DROP COLUMN IF EXISTS assigned_at;
@@ -0,0 +1,22 @@
+-- SLA of the review starts when reviewer is assigned, existing assignments start it now
+ALTER TABLE assigned_reviewers
+    ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now();
+
+-- zero hours disable the SLA
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS review_sla_hours INT NOT NULL DEFAULT 0 CHECK (review_sla_hours >= 0),
+    ADD COLUMN IF NOT EXISTS merge_sla_hours INT NOT NULL DEFAULT 0 CHECK (merge_sla_hours >= 0),
+    ADD COLUMN IF NOT EXISTS sla_escalation VARCHAR(20) NOT NULL DEFAULT 'WEBHOOK'
+        CHECK (sla_escalation IN ('ADD_REVIEWER', 'REASSIGN', 'WEBHOOK'));
+
+-- warnings and breaches already reported, every SLA is reported once per level
+CREATE TABLE IF NOT EXISTS sla_notices (
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id),
+    sla_kind VARCHAR(10) NOT NULL CHECK (sla_kind IN ('REVIEW', 'MERGE')),
+    -- empty for time to merge
+    reviewer_user_id TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    level VARCHAR(10) NOT NULL CHECK (level IN ('WARNING', 'BREACH')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (pull_request_id, sla_kind, reviewer_user_id, started_at, level)
+);
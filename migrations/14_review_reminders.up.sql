@@ -0,0 +1,16 @@
+-- daily period in user timezone when the user gets no reminders, it may cross midnight
+CREATE TABLE IF NOT EXISTS user_quiet_hours (
+    user_id TEXT PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    starts_at TIME NOT NULL,
+    ends_at TIME NOT NULL,
+    timezone TEXT NOT NULL DEFAULT 'UTC'
+);
+
+-- reminders sent to reviewers, every assignment is reminded once
+CREATE TABLE IF NOT EXISTS review_reminders (
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id),
+    reviewer_user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    assigned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (pull_request_id, reviewer_user_id, assigned_at)
+);
@@ -0,0 +1,9 @@
+-- stacked PRs, PR is merged only after its dependencies, cycles are rejected by the service
+CREATE TABLE IF NOT EXISTS pull_request_dependencies (
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id),
+    depends_on_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id),
+    PRIMARY KEY (pull_request_id, depends_on_id),
+    CHECK (pull_request_id <> depends_on_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_dependencies_depends_on ON pull_request_dependencies(depends_on_id);
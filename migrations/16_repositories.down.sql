@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_pull_requests_repository_number;
+
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS number,
+    DROP COLUMN IF EXISTS repository;
+
+DROP TABLE IF EXISTS repositories;
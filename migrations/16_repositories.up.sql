@@ -0,0 +1,25 @@
+-- repositories of PRs, PRs of repository with owner team are reviewed by that team
+CREATE TABLE IF NOT EXISTS repositories (
+    repository TEXT PRIMARY KEY CHECK (repository <> '' AND position('#' in repository) = 0),
+    team_name TEXT REFERENCES teams(team_name) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- PRs created before repositories belong to the default one and keep their ids
+INSERT INTO repositories (repository) VALUES ('default') ON CONFLICT (repository) DO NOTHING;
+
+INSERT INTO repositories (repository)
+    SELECT repository FROM codeowners WHERE repository <> '' AND position('#' in repository) = 0
+ON CONFLICT (repository) DO NOTHING;
+
+-- pull_request_id stays the global key referenced by other tables,
+-- number is PR id inside its repository
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS repository TEXT NOT NULL DEFAULT 'default' REFERENCES repositories(repository),
+    ADD COLUMN IF NOT EXISTS number TEXT;
+
+UPDATE pull_requests SET number = pull_request_id WHERE number IS NULL;
+
+ALTER TABLE pull_requests ALTER COLUMN number SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pull_requests_repository_number ON pull_requests(repository, number);
@@ -0,0 +1,18 @@
+-- user can be a member of several teams, users.team_name stays the primary team
+CREATE TABLE IF NOT EXISTS team_memberships (
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    team_name TEXT NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    role VARCHAR(15) NOT NULL DEFAULT 'MEMBER' CHECK (role IN ('MEMBER', 'LEAD')),
+    -- inactive member is not assigned to review PRs for this team
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    PRIMARY KEY (user_id, team_name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_memberships_team_name ON team_memberships(team_name);
+
+INSERT INTO team_memberships (user_id, team_name)
+    SELECT user_id, team_name FROM users WHERE team_name IS NOT NULL
+ON CONFLICT (user_id, team_name) DO NOTHING;
+
+-- team of the author chosen on PR creation, NULL means primary team of the author
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS team_name TEXT REFERENCES teams(team_name);
@@ -0,0 +1,2 @@
+-- archived team stays readable but its members are not assigned to review PRs for it
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,6 @@
+-- teams form a tree, e.g. departments containing several teams
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS parent_team TEXT REFERENCES teams(team_name) ON DELETE SET NULL;
+
+ALTER TABLE teams ADD CONSTRAINT teams_parent_team_check CHECK (parent_team <> team_name);
+
+CREATE INDEX IF NOT EXISTS idx_teams_parent_team ON teams(parent_team);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_users_username;
+
+DROP TABLE IF EXISTS user_forge_logins;
+
+ALTER TABLE users DROP COLUMN IF EXISTS display_name;
+ALTER TABLE users DROP COLUMN IF EXISTS email;
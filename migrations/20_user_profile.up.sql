@@ -0,0 +1,13 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS display_name TEXT;
+
+-- logins of the user on code hosting platforms, a login belongs to one user
+CREATE TABLE IF NOT EXISTS user_forge_logins (
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    forge TEXT NOT NULL,
+    login TEXT NOT NULL,
+    PRIMARY KEY (user_id, forge),
+    UNIQUE (forge, login)
+);
+
+CREATE INDEX IF NOT EXISTS idx_users_username ON users(lower(username) text_pattern_ops);
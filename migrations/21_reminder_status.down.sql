@@ -0,0 +1,6 @@
+DELETE FROM review_reminders WHERE status <> 'SENT';
+
+ALTER TABLE review_reminders ALTER COLUMN sent_at SET DEFAULT now();
+ALTER TABLE review_reminders ALTER COLUMN sent_at SET NOT NULL;
+ALTER TABLE review_reminders DROP COLUMN IF EXISTS claimed_at;
+ALTER TABLE review_reminders DROP COLUMN IF EXISTS status;
@@ -0,0 +1,7 @@
+-- reminder is claimed as PENDING before delivery and marked SENT or FAILED after it,
+-- so delivery does not run in the transaction which picks reminders
+ALTER TABLE review_reminders ADD COLUMN IF NOT EXISTS status VARCHAR(10) NOT NULL DEFAULT 'SENT'
+    CHECK (status IN ('PENDING', 'SENT', 'FAILED'));
+ALTER TABLE review_reminders ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now();
+ALTER TABLE review_reminders ALTER COLUMN sent_at DROP NOT NULL;
+ALTER TABLE review_reminders ALTER COLUMN sent_at DROP DEFAULT;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_pull_requests_status;
+
+ALTER TABLE users DROP COLUMN IF EXISTS max_open_reviews;
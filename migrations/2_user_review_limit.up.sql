@@ -0,0 +1,4 @@
+-- NULL means the user has no limit of open reviews
+ALTER TABLE users ADD COLUMN IF NOT EXISTS max_open_reviews INT CHECK (max_open_reviews >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_status ON pull_requests(status);
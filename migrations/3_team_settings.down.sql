@@ -0,0 +1,7 @@
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_reviewers_range;
+
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS fallback_team,
+    DROP COLUMN IF EXISTS understaffed_policy,
+    DROP COLUMN IF EXISTS max_reviewers,
+    DROP COLUMN IF EXISTS min_reviewers;
@@ -0,0 +1,9 @@
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS min_reviewers INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS max_reviewers INT NOT NULL DEFAULT 2,
+    ADD COLUMN IF NOT EXISTS understaffed_policy VARCHAR(15) NOT NULL DEFAULT 'ASSIGN_FEWER'
+        CHECK (understaffed_policy IN ('FAIL', 'ASSIGN_FEWER', 'FALLBACK')),
+    ADD COLUMN IF NOT EXISTS fallback_team TEXT REFERENCES teams(team_name);
+
+ALTER TABLE teams
+    ADD CONSTRAINT teams_reviewers_range CHECK (min_reviewers >= 0 AND max_reviewers >= 1 AND max_reviewers >= min_reviewers);
@@ -0,0 +1,9 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS fallback_team TEXT REFERENCES teams(team_name);
+
+UPDATE teams t
+    SET fallback_team = f.fallback_team_name
+FROM team_fallbacks f
+WHERE f.team_name = t.team_name
+    AND f.position = (SELECT MIN(position) FROM team_fallbacks WHERE team_name = t.team_name);
+
+DROP TABLE IF EXISTS team_fallbacks;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS team_fallbacks (
+    team_name TEXT NOT NULL REFERENCES teams(team_name),
+    fallback_team_name TEXT NOT NULL REFERENCES teams(team_name),
+    position INT NOT NULL,
+    PRIMARY KEY (team_name, fallback_team_name),
+    CHECK (team_name <> fallback_team_name)
+);
+
+-- single fallback team becomes the first one in the list
+INSERT INTO team_fallbacks (team_name, fallback_team_name, position)
+    SELECT team_name, fallback_team, 1 FROM teams WHERE fallback_team IS NOT NULL;
+
+ALTER TABLE teams DROP COLUMN IF EXISTS fallback_team;
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS codeowners (
+    repository TEXT PRIMARY KEY,
+    content TEXT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
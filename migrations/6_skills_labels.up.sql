@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_skills (
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    skill TEXT NOT NULL,
+    PRIMARY KEY (user_id, skill)
+);
+
+CREATE TABLE IF NOT EXISTS pull_request_labels (
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    label TEXT NOT NULL,
+    PRIMARY KEY (pull_request_id, label)
+);
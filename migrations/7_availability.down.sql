@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_schedules;
+DROP TABLE IF EXISTS user_unavailability;
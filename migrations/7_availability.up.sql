@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS user_unavailability (
+    period_id SERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    CONSTRAINT user_unavailability_range CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_unavailability_user_id ON user_unavailability(user_id, ends_at);
+
+-- users without schedule work every day
+CREATE TABLE IF NOT EXISTS user_schedules (
+    user_id TEXT PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+    -- ISO days of week, 1 is monday
+    working_days INT[] NOT NULL,
+    timezone TEXT NOT NULL DEFAULT 'UTC'
+);
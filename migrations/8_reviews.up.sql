@@ -0,0 +1,11 @@
+-- every submitted review is kept, latest one per reviewer is the current decision
+CREATE TABLE IF NOT EXISTS pull_request_reviews (
+    review_id SERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    reviewer_user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    state VARCHAR(20) NOT NULL CHECK (state IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    body TEXT NOT NULL DEFAULT '',
+    submitted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pull_request_reviews_pr_reviewer ON pull_request_reviews(pull_request_id, reviewer_user_id, review_id DESC);
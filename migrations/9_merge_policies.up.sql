@@ -0,0 +1,14 @@
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS min_approvals INT NOT NULL DEFAULT 0 CHECK (min_approvals >= 0),
+    ADD COLUMN IF NOT EXISTS block_on_changes_requested BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- users whose approval is required to merge PRs of the team members
+CREATE TABLE IF NOT EXISTS team_required_approvers (
+    team_name TEXT NOT NULL REFERENCES teams(team_name),
+    user_id TEXT NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    PRIMARY KEY (team_name, user_id)
+);
+
+-- PR merged regardless of the merge policy
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS merge_override BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,19 @@
+package clock
+
+import "time"
+
+// Clock tells the current time, tests replace it to control time
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+// New returns clock of the system time
+func New() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
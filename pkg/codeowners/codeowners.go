@@ -0,0 +1,151 @@
+// Package codeowners parses GitHub CODEOWNERS files and matches paths against them.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type Rule struct {
+	Pattern string
+	// empty owners means the matched paths have no owners
+	Owners []string
+	re     *regexp.Regexp
+}
+
+// Parse parses CODEOWNERS content, comments and empty lines are skipped
+func Parse(content string) ([]Rule, error) {
+	var rules []Rule
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// inline comment
+		if idx := strings.Index(line, " #"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+
+		fields := strings.Fields(line)
+		re, err := compilePattern(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+
+		for _, owner := range fields[1:] {
+			// @user, @org/team or email
+			if !strings.Contains(owner, "@") {
+				return nil, fmt.Errorf("line %d: invalid owner %q", lineNum, owner)
+			}
+		}
+
+		rules = append(rules, Rule{Pattern: fields[0], Owners: fields[1:], re: re})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// Match returns owners of the path, the last matching rule wins as in GitHub
+func Match(rules []Rule, path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].re.MatchString(path) {
+			return rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// Owners returns unique owners of all paths in order of their first appearance
+func Owners(rules []Rule, paths []string) []string {
+	seen := make(map[string]bool)
+	var owners []string
+	for _, path := range paths {
+		for _, owner := range Match(rules, path) {
+			if !seen[owner] {
+				seen[owner] = true
+				owners = append(owners, owner)
+			}
+		}
+	}
+	return owners
+}
+
+// ParseOwner splits owner into user and team names,
+// "@user" is a user, "@org/team" is a team, emails are returned as user
+func ParseOwner(owner string) (userID, teamName string) {
+	name := strings.TrimPrefix(owner, "@")
+	if idx := strings.Index(name, "/"); idx >= 0 && strings.HasPrefix(owner, "@") {
+		return "", name[idx+1:]
+	}
+	return name, ""
+}
+
+// compilePattern converts gitignore-like pattern into regexp:
+// patterns with leading or middle slash are relative to repository root, others match at any depth,
+// "*" and "?" do not match "/", "**" matches any number of directories,
+// pattern matches the path itself and everything inside it except "dir/*" which matches only direct children
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") {
+		return nil, fmt.Errorf("negation pattern %q is not supported", pattern)
+	}
+
+	dirOnly := strings.HasSuffix(pattern, "/") && pattern != "/"
+	trimmed := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(trimmed, "/")
+	trimmed = strings.TrimPrefix(trimmed, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(trimmed); i++ {
+		switch c := trimmed[i]; c {
+		case '*':
+			if i+1 < len(trimmed) && trimmed[i+1] == '*' {
+				i++
+				if i+1 < len(trimmed) && trimmed[i+1] == '/' {
+					// "**/" is zero or more directories
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+				continue
+			}
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	switch {
+	case trimmed == "":
+		// "/" rule for the whole repository
+		b.WriteString(".*$")
+	case dirOnly:
+		b.WriteString("/.*$")
+	case strings.HasSuffix(trimmed, "/*"):
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,79 @@
+package codeowners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	content := `
+# default owners
+*       @org/backend
+
+*.js    @frontend-lead
+/docs/  @org/docs   # docs team
+apps/   @apps-owner
+/build/logs/ @ops
+docs/*  @writer
+**/migrations @dba
+/config/unowned.yaml
+`
+	rules, err := Parse(content)
+	require.NoError(t, err)
+	require.Equal(t, 8, len(rules))
+
+	tests := []struct {
+		path   string
+		owners []string
+	}{
+		{"main.go", []string{"@org/backend"}},
+		{"web/app.js", []string{"@frontend-lead"}},
+		{"docs/getting-started.md", []string{"@writer"}},
+		{"docs/build-app/troubleshooting.md", []string{"@org/docs"}},
+		{"apps/api/main.go", []string{"@apps-owner"}},
+		{"services/apps/main.go", []string{"@apps-owner"}},
+		{"build/logs/out.log", []string{"@ops"}},
+		{"src/build/logs/out.log", []string{"@org/backend"}},
+		{"migrations/1_init.up.sql", []string{"@dba"}},
+		{"db/migrations/1_init.up.sql", []string{"@dba"}},
+		{"config/unowned.yaml", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			owners := Match(rules, tt.path)
+			if len(tt.owners) == 0 {
+				require.Empty(t, owners)
+				return
+			}
+			require.Equal(t, tt.owners, owners)
+		})
+	}
+}
+
+func TestOwners(t *testing.T) {
+	rules, err := Parse("*.go @org/backend @alice\n*.sql @bob @alice\n")
+	require.NoError(t, err)
+
+	owners := Owners(rules, []string{"main.go", "schema.sql", "readme.md"})
+	require.Equal(t, []string{"@org/backend", "@alice", "@bob"}, owners)
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse("!docs/ @org/docs")
+	require.Error(t, err)
+
+	_, err = Parse("docs/ docs-team")
+	require.Error(t, err)
+}
+
+func TestParseOwner(t *testing.T) {
+	userID, teamName := ParseOwner("@alice")
+	require.Equal(t, "alice", userID)
+	require.Equal(t, "", teamName)
+
+	userID, teamName = ParseOwner("@org/backend")
+	require.Equal(t, "", userID)
+	require.Equal(t, "backend", teamName)
+}
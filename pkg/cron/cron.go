@@ -0,0 +1,168 @@
+// Package cron parses standard five field cron expressions and finds their next run time.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed expression "minute hour day-of-month month day-of-week",
+// every field keeps a bit per allowed value
+type Schedule struct {
+	Expr   string
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	// restricted day fields are matched as in cron: day matches if any of them matches
+	domStar bool
+	dowStar bool
+}
+
+type bounds struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteBounds = bounds{"minute", 0, 59}
+	hourBounds   = bounds{"hour", 0, 23}
+	domBounds    = bounds{"day of month", 1, 31}
+	monthBounds  = bounds{"month", 1, 12}
+	// 7 is also sunday
+	dowBounds = bounds{"day of week", 0, 7}
+)
+
+var macros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// Parse parses expression with *, lists, ranges and steps in fields, for example "*/15 9-18 * * 1-5",
+// and macros @hourly, @daily, @weekly, @monthly
+func Parse(expr string) (*Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := macros[spec]; ok {
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in %q", expr)
+	}
+
+	s := &Schedule{Expr: expr, domStar: fields[2] == "*", dowStar: fields[4] == "*"}
+	var err error
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, err
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			n, err := strconv.Atoi(part[idx+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %s %q", b.name, part)
+			}
+			rangePart, step = part[:idx], n
+		}
+
+		from, to := b.min, b.max
+		if rangePart != "*" {
+			var err error
+			from, to, err = parseRange(rangePart, b)
+			if err != nil {
+				return 0, err
+			}
+			// "5/10" runs from 5 to the end
+			if step > 1 && !strings.Contains(rangePart, "-") {
+				to = b.max
+			}
+		}
+
+		for v := from; v <= to; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func parseRange(value string, b bounds) (int, int, error) {
+	fromValue, toValue, isRange := strings.Cut(value, "-")
+	from, err := strconv.Atoi(fromValue)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid %s %q", b.name, value)
+	}
+	to := from
+	if isRange {
+		if to, err = strconv.Atoi(toValue); err != nil {
+			return 0, 0, fmt.Errorf("invalid %s %q", b.name, value)
+		}
+	}
+
+	if from < b.min || to > b.max || from > to {
+		return 0, 0, fmt.Errorf("%s %q is out of range %d-%d", b.name, value, b.min, b.max)
+	}
+	return from, to, nil
+}
+
+// Next returns the first time after t which matches the schedule in location of t,
+// zero time if there is no such time in five years (e.g. "0 0 31 2 *")
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
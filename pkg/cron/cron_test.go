@@ -0,0 +1,51 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNext(t *testing.T) {
+	// saturday
+	from := time.Date(2026, 1, 10, 12, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2026, 1, 10, 12, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 1, 10, 12, 15, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 1, 10, 13, 0, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2026, 1, 11, 9, 0, 0, 0, time.UTC)},
+		// next working day is monday
+		{"30 9-18 * * 1-5", time.Date(2026, 1, 12, 9, 30, 0, 0, time.UTC)},
+		{"0 0 1 */3 *", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		// 7 is sunday
+		{"0 10 * * 7", time.Date(2026, 1, 11, 10, 0, 0, 0, time.UTC)},
+		// restricted day of month and week match any of them
+		{"0 0 15 * 1", time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)},
+		{"5,10 12 * * *", time.Date(2026, 1, 10, 12, 10, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		schedule, err := Parse(tt.expr)
+		require.NoError(t, err, tt.expr)
+		require.Equal(t, tt.next, schedule.Next(from), tt.expr)
+	}
+}
+
+func TestNextNever(t *testing.T) {
+	schedule, err := Parse("0 0 31 2 *")
+	require.NoError(t, err)
+	require.True(t, schedule.Next(time.Now()).IsZero())
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		_, err := Parse(expr)
+		require.Error(t, err, expr)
+	}
+}
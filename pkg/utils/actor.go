@@ -0,0 +1,16 @@
+package utils
+
+import "context"
+
+type actorKey struct{}
+
+// WithActor stores id of the user who makes the request
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns id of the user who makes the request, empty if it is unknown
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
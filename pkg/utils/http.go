@@ -13,15 +13,34 @@ import (
 )
 
 const (
-	ErrBadRequest      = "BAD_REQUEST"
-	ErrNotFound        = "NOT_FOUND"
-	ErrPrExists        = "PR_EXISTS"
-	ErrTeamExists      = "TEAM_EXISTS"
-	ErrRequestTimeout  = "REQUEST_TIMEOUT"
-	ErrInternal        = "INTERNAL_SERVER_ERROR"
-	ErrUserNotReviewer = "NOT_ASSIGNED"
-	ErrNoCantidate     = "NO_CANDIDATE"
-	ErrPrAlredyMerged  = "PR_MERGED"
+	ErrBadRequest         = "BAD_REQUEST"
+	ErrNotFound           = "NOT_FOUND"
+	ErrPrExists           = "PR_EXISTS"
+	ErrTeamExists         = "TEAM_EXISTS"
+	ErrRequestTimeout     = "REQUEST_TIMEOUT"
+	ErrInternal           = "INTERNAL_SERVER_ERROR"
+	ErrUserNotReviewer    = "NOT_ASSIGNED"
+	ErrNoCantidate        = "NO_CANDIDATE"
+	ErrPrAlredyMerged     = "PR_MERGED"
+	ErrNotEnoughReviewers = "NOT_ENOUGH_REVIEWERS"
+	ErrAlreadyAssigned    = "ALREADY_ASSIGNED"
+	ErrUserInactive       = "USER_INACTIVE"
+	ErrReviewLimit        = "REVIEW_LIMIT_REACHED"
+	ErrTooManyReviewers   = "TOO_MANY_REVIEWERS"
+	ErrMergeBlocked       = "MERGE_BLOCKED"
+	ErrPrNotOpen          = "PR_NOT_OPEN"
+	ErrInvalidTransition  = "INVALID_STATUS_TRANSITION"
+	// PR depends on DRAFT or OPEN PRs
+	ErrDependencyNotMerged = "DEPENDENCY_NOT_MERGED"
+	ErrDependencyCycle     = "DEPENDENCY_CYCLE"
+	ErrAlreadyMember       = "ALREADY_MEMBER"
+	ErrTeamArchived        = "TEAM_ARCHIVED"
+	// archive of the team is blocked by its DRAFT and OPEN PRs
+	ErrTeamHasOpenPRs = "TEAM_HAS_OPEN_PRS"
+	// team would become its own ancestor
+	ErrHierarchyCycle = "TEAM_HIERARCHY_CYCLE"
+	// forge login belongs to another user
+	ErrForgeLoginTaken = "FORGE_LOGIN_TAKEN"
 )
 
 type Error struct {
@@ -29,6 +48,8 @@ type Error struct {
 	Code       string `json:"code"`    // error code
 	Message    string `json:"message"` // error message
 	Causes     any    `json:"-"`       // error causes for internal use
+	// additional data for client, e.g. unmet merge conditions
+	Details any `json:"details,omitempty"`
 }
 
 // Errors - implementation of the error interface